@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"tender_srevice/internal/app"
+	"tender_srevice/internal/config"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+)
+
+const importUsage = `Usage: tender_service import -file tenders.csv [-format csv|jsonl] [-dry-run] [-- config flags]
+
+Imports tenders from a CSV (with header) or JSON Lines file and prints a JSON report.
+Exits with status 1 if any row was rejected.
+`
+
+// runImport — подкоманда import: тот же импорт, что POST /api/tenders/import.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), importUsage)
+		fs.PrintDefaults()
+	}
+	file := fs.String("file", "", "path to the import file, - for stdin")
+	format := fs.String("format", "", "csv or jsonl; detected from the file extension by default")
+	dryRun := fs.Bool("dry-run", false, "validate rows without inserting them")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fs.Usage()
+		return 2
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	cfg, err := config.Load(fs.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	in := os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+
+	rows, parseErrors, err := service.ParseTenderImport(in, *format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	db, err := sql.Open("postgres", cfg.PostgresConn)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer db.Close()
+	cfg.ApplyDBPool(db)
+
+	repo := repository.NewPostgresRepository(db)
+	tenderService := service.NewTenderService(repo, app.NewBidSealing(cfg, repo), service.NewNotificationService(repo, nil))
+	report, err := tenderService.ImportTenders(context.Background(), rows, parseErrors, *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(report)
+	if len(report.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
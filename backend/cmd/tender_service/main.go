@@ -2,35 +2,54 @@ package main
 
 import (
 	"database/sql"
-	"log"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
 	"tender_srevice/internal/config"
 	"tender_srevice/internal/app/server"
+	"tender_srevice/internal/logger"
 	"tender_srevice/internal/repository"
 	"github.com/joho/godotenv"
 )
 
 func main() {
+	slog.SetDefault(logger.New(slog.LevelInfo))
+
 	err := godotenv.Load()
 	if err != nil {
-		log.Printf("Error loading .env file: %v", err)
+		slog.Debug("no .env file loaded", slog.Any("error", err))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		os.Exit(runSeed(os.Args[2:]))
 	}
 
-	cfg, err := config.Load()
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		fatal("failed to load config", err)
 	}
+	slog.SetDefault(logger.New(cfg.LogLevel))
+	slog.Info("configuration loaded", slog.Any("config", cfg))
 	
 	err = config.RunMigrations(cfg)
 	if err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+		fatal("failed to run migrations", err)
 	}
 	
 	// Создаем подключение к базе данных
 	db, err := sql.Open("postgres", cfg.PostgresConn)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("failed to connect to database", err)
 	}
 	defer db.Close()
+	cfg.ApplyDBPool(db)
 
 	// Создаем репозиторий
 	repo := repository.NewPostgresRepository(db)
@@ -38,6 +57,11 @@ func main() {
 	// Создаем сервер, передавая конфигурацию и репозиторий
 	srv := server.New(cfg, repo)
 	if err := srv.Run(); err != nil {
-		log.Fatalf("Error running server: %v", err)
+		fatal("server stopped", err)
 	}
-}
\ No newline at end of file
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"tender_srevice/internal/config"
+	"tender_srevice/internal/seed"
+	"time"
+)
+
+const seedUsage = `Usage: tender_service seed [-seed N] [-organizations N] [-responsibles N] [-bidders N] [-tenders N] [-bids N] [-dry-run] [-- config flags]
+
+Applies migrations and fills the database with reproducible fixtures: the same
+flags always produce the same organizations, employees, tenders, bids and IDs.
+Prints a JSON summary; -dry-run only prints it.
+`
+
+// runSeed — подкоманда seed: общий для всех разработчиков набор данных.
+func runSeed(args []string) int {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), seedUsage)
+		fs.PrintDefaults()
+	}
+	sc := seed.DefaultConfig()
+	fs.Int64Var(&sc.Seed, "seed", sc.Seed, "random generator seed")
+	fs.IntVar(&sc.Organizations, "organizations", sc.Organizations, "number of organizations")
+	fs.IntVar(&sc.Responsibles, "responsibles", sc.Responsibles, "responsible employees per organization")
+	fs.IntVar(&sc.Bidders, "bidders", sc.Bidders, "employees who only submit bids")
+	fs.IntVar(&sc.Tenders, "tenders", sc.Tenders, "tenders per organization, at least 3")
+	fs.IntVar(&sc.BidsPerTender, "bids", sc.BidsPerTender, "maximum bids per tender, at most -bidders")
+	dryRun := fs.Bool("dry-run", false, "generate fixtures and print the summary without writing them")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	fixtures, err := seed.Generate(sc, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	if !*dryRun {
+		cfg, err := config.Load(fs.Args())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if err := config.RunMigrations(cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		db, err := sql.Open("postgres", cfg.PostgresConn)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer db.Close()
+
+		if err := seed.Insert(context.Background(), db, fixtures); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(fixtures.Summary(sc.Seed))
+	return 0
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+)
+
+func createEmployee(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("employee create", flag.ContinueOnError)
+	var req service.CreateEmployeeRequest
+	fs.StringVar(&req.Username, "username", "", "unique username")
+	fs.StringVar(&req.FirstName, "first-name", "", "first name")
+	fs.StringVar(&req.LastName, "last-name", "", "last name")
+	fs.StringVar(&req.Email, "email", "", "email for notification letters")
+	if err := c.parse(fs, args, "username"); err != nil {
+		return err
+	}
+
+	e, err := c.ops.CreateEmployee(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.out.employees([]*domain.Employee{e})
+}
+
+func createOrganization(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("organization create", flag.ContinueOnError)
+	var req service.CreateOrganizationRequest
+	fs.StringVar(&req.Name, "name", "", "organization name")
+	fs.StringVar(&req.Description, "description", "", "description")
+	fs.StringVar(&req.Type, "type", "", "IE, LLC or JSC")
+	if err := c.parse(fs, args, "name"); err != nil {
+		return err
+	}
+
+	o, err := c.ops.CreateOrganization(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.out.organization(o)
+}
+
+func addResponsible(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("responsible add", flag.ContinueOnError)
+	organizationID := fs.String("organization", "", "organization ID")
+	username := fs.String("username", "", "employee username")
+	if err := c.parse(fs, args, "organization", "username"); err != nil {
+		return err
+	}
+
+	added, err := c.ops.AddResponsible(ctx, *organizationID, *username)
+	if err != nil {
+		return err
+	}
+	return c.out.responsible(*organizationID, *username, added)
+}
+
+func listTenders(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("tender list", flag.ContinueOnError)
+	var filter repository.TenderListFilter
+	fs.StringVar(&filter.OrganizationID, "organization", "", "only tenders of this organization")
+	fs.StringVar(&filter.Status, "status", "", "only tenders in this status")
+	fs.BoolVar(&filter.IncludeArchived, "archived", false, "include archived tenders")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+
+	tenders, err := c.ops.ListTenders(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return c.out.tenders(tenders)
+}
+
+func showTender(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("tender show", flag.ContinueOnError)
+	id := fs.String("id", "", "tender ID")
+	if err := c.parse(fs, args, "id"); err != nil {
+		return err
+	}
+
+	tender, versions, err := c.ops.TenderHistory(ctx, *id)
+	if err != nil {
+		return err
+	}
+	return c.out.tenderHistory(tender, versions)
+}
+
+func setTenderStatus(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("tender set-status", flag.ContinueOnError)
+	id := fs.String("id", "", "tender ID")
+	status := fs.String("status", "", "CREATED, PUBLISHED or CLOSED")
+	reason := fs.String("reason", "", "why the status is forced; stored in the version history")
+	if err := c.parse(fs, args, "id", "status", "reason"); err != nil {
+		return err
+	}
+
+	tender, err := c.ops.ForceTenderStatus(ctx, *id, *status, *reason, c.operator)
+	if tender != nil {
+		if perr := c.out.tenders([]*domain.Tender{tender}); perr != nil {
+			return perr
+		}
+	}
+	return err
+}
+
+func rollbackTender(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("tender rollback", flag.ContinueOnError)
+	id := fs.String("id", "", "tender ID")
+	version := fs.Int("version", 0, "version to restore")
+	if err := c.parse(fs, args, "id", "version"); err != nil {
+		return err
+	}
+
+	tender, err := c.ops.RollbackTender(ctx, *id, *version, c.operator)
+	if err != nil {
+		return err
+	}
+	return c.out.tenders([]*domain.Tender{tender})
+}
+
+func rollbackBid(ctx context.Context, c *cli, args []string) error {
+	fs := flag.NewFlagSet("bid rollback", flag.ContinueOnError)
+	id := fs.String("id", "", "bid ID")
+	version := fs.Int("version", 0, "version to restore")
+	if err := c.parse(fs, args, "id", "version"); err != nil {
+		return err
+	}
+
+	bid, err := c.ops.RollbackBid(ctx, *id, *version, c.operator)
+	if err != nil {
+		return err
+	}
+	return c.out.bid(bid)
+}
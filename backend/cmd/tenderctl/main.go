@@ -0,0 +1,174 @@
+// tenderctl — утилита оператора: справочники, ответственные, просмотр тендеров и
+// принудительные изменения через репозиторий сервиса, без HTTP API.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/user"
+	"tender_srevice/internal/app"
+	"tender_srevice/internal/config"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: tenderctl [-o table|json] [-operator name] <command> [flags] [-- config flags]
+
+Commands:
+  employee create      -username U [-first-name F] [-last-name L] [-email E]
+  organization create  -name N [-description D] [-type IE|LLC|JSC]
+  responsible add      -organization ID -username U
+  tender list          [-organization ID] [-status S] [-archived]
+  tender show          -id ID
+  tender set-status    -id ID -status CREATED|PUBLISHED|CLOSED -reason R
+  tender rollback      -id ID -version N
+  bid rollback         -id ID -version N
+
+The database is configured like tender_service: config file, environment
+(POSTGRES_CONN, ...), .env, or service flags after --. The schema must already
+be migrated. Changes are logged to stderr together with the operator name.
+
+Global flags:
+`
+
+// usageError — ошибка в аргументах; печатается вместе со справкой, код выхода 2.
+type usageError struct{ msg string }
+
+func (e usageError) Error() string { return e.msg }
+
+type commandFunc func(ctx context.Context, c *cli, args []string) error
+
+var commands = map[string]commandFunc{
+	"employee create":     createEmployee,
+	"organization create": createOrganization,
+	"responsible add":     addResponsible,
+	"tender list":         listTenders,
+	"tender show":         showTender,
+	"tender set-status":   setTenderStatus,
+	"tender rollback":     rollbackTender,
+	"bid rollback":        rollbackBid,
+}
+
+func main() {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintln(os.Stderr, "failed to load .env:", err)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("tenderctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	format := fs.String("o", "table", "output format: table or json")
+	operator := fs.String("operator", defaultOperator(), "operator name recorded in logs and unseal events")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	out, err := newPrinter(stdout, *format)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	rest := fs.Args()
+	if len(rest) < 2 {
+		fs.Usage()
+		return 2
+	}
+	cmd, ok := commands[rest[0]+" "+rest[1]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", rest[0]+" "+rest[1])
+		fs.Usage()
+		return 2
+	}
+
+	c := &cli{out: out, operator: *operator, stderr: stderr}
+	defer c.close()
+	err = cmd(ctx, c, rest[2:])
+	var uerr usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &uerr):
+		fmt.Fprintln(stderr, err)
+		return 2
+	default:
+		fmt.Fprintln(stderr, "error:", err)
+		return 1
+	}
+}
+
+// cli — состояние одного вызова: формат вывода и подключение к базе.
+type cli struct {
+	out      *printer
+	operator string
+	stderr   io.Writer
+
+	db  *sql.DB
+	ops *service.OperationsService
+}
+
+// parse разбирает флаги команды и подключается к базе с настройками после «--».
+// Флаги, перечисленные в required, обязательны.
+func (c *cli) parse(fs *flag.FlagSet, args []string, required ...string) error {
+	fs.SetOutput(c.stderr)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return usageError{err.Error()}
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range required {
+		if !set[name] {
+			return usageError{fmt.Sprintf("%s: -%s is required", fs.Name(), name)}
+		}
+	}
+
+	cfg, err := config.Load(fs.Args())
+	if err != nil {
+		return usageError{err.Error()}
+	}
+	c.db, err = sql.Open("postgres", cfg.PostgresConn)
+	if err != nil {
+		return err
+	}
+	cfg.ApplyDBPool(c.db)
+
+	repo := repository.NewPostgresRepository(c.db)
+	c.ops = service.NewOperationsService(repo, app.NewBidSealing(cfg, repo))
+	return nil
+}
+
+func (c *cli) close() {
+	if c.db != nil {
+		c.db.Close()
+	}
+}
+
+func defaultOperator() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "tenderctl"
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"tender_srevice/internal/domain"
+	"testing"
+)
+
+func TestRunRejectsBadArguments(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no command", nil, "Usage: tenderctl"},
+		{"unknown command", []string{"tender", "delete"}, `unknown command "tender delete"`},
+		{"unknown format", []string{"-o", "yaml", "tender", "list"}, `unknown output format "yaml"`},
+		{"missing required flag", []string{"tender", "set-status", "-id", "x", "-status", "CLOSED"}, "-reason is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(context.Background(), tt.args, &stdout, &stderr); code != 2 {
+				t.Errorf("exit code = %d, want 2", code)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.want)
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("stdout = %q, want empty", stdout.String())
+			}
+		})
+	}
+}
+
+func TestPrinterFormats(t *testing.T) {
+	tenders := []*domain.Tender{{ID: "t1", Name: "Ремонт", Status: domain.TenderStatusPublished, Version: 3}}
+
+	var table bytes.Buffer
+	p, _ := newPrinter(&table, "table")
+	if err := p.tenders(tenders); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "PUBLISHED") {
+		t.Errorf("table output = %q", table.String())
+	}
+
+	var out bytes.Buffer
+	p, _ = newPrinter(&out, "json")
+	if err := p.tenders(nil); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []domain.Tender
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil || decoded == nil {
+		t.Errorf("json output = %q, want an empty array", out.String())
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"tender_srevice/internal/domain"
+	"text/tabwriter"
+	"time"
+)
+
+// printer выводит результат команды таблицей для человека или JSON для скриптов.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "table":
+		return &printer{w: w}, nil
+	case "json":
+		return &printer{w: w, json: true}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q: use table or json", format)
+}
+
+// print кодирует v в JSON либо передает rows строки таблицы; первая строка — заголовок.
+func (p *printer) print(v interface{}, rows func(row func(cells ...string))) error {
+	if p.json {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	rows(func(cells ...string) {
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	})
+	return tw.Flush()
+}
+
+func (p *printer) employees(employees []*domain.Employee) error {
+	return p.print(employees, func(row func(...string)) {
+		row("ID", "USERNAME", "FIRST NAME", "LAST NAME", "EMAIL")
+		for _, e := range employees {
+			row(e.ID, e.Username, e.FirstName, e.LastName, deref(e.Email))
+		}
+	})
+}
+
+func (p *printer) organization(o *domain.Organization) error {
+	return p.print(o, func(row func(...string)) {
+		row("ID", "NAME", "TYPE", "DESCRIPTION")
+		row(o.ID, o.Name, o.Type, o.Description)
+	})
+}
+
+func (p *printer) responsible(organizationID, username string, added bool) error {
+	v := struct {
+		OrganizationID string `json:"organizationId"`
+		Username       string `json:"username"`
+		Added          bool   `json:"added"`
+	}{organizationID, username, added}
+	return p.print(v, func(row func(...string)) {
+		row("ORGANIZATION", "USERNAME", "ADDED")
+		row(organizationID, username, strconv.FormatBool(added))
+	})
+}
+
+func (p *printer) tenders(tenders []*domain.Tender) error {
+	if tenders == nil {
+		tenders = []*domain.Tender{}
+	}
+	return p.print(tenders, func(row func(...string)) {
+		row("ID", "NAME", "STATUS", "VERSION", "SERVICE TYPE", "ORGANIZATION", "CREATOR", "DEADLINE", "SEALED", "ARCHIVED")
+		for _, t := range tenders {
+			row(t.ID, t.Name, t.Status, strconv.Itoa(t.Version), t.ServiceType, t.OrganizationID, t.CreatorUsername,
+				formatTime(t.SubmissionDeadline), strconv.FormatBool(t.Sealed), formatTime(t.DeletedAt))
+		}
+	})
+}
+
+func (p *printer) tenderHistory(tender *domain.Tender, versions []*domain.TenderVersion) error {
+	if versions == nil {
+		versions = []*domain.TenderVersion{}
+	}
+	v := struct {
+		Tender   *domain.Tender          `json:"tender"`
+		Versions []*domain.TenderVersion `json:"versions"`
+	}{tender, versions}
+	return p.print(v, func(row func(...string)) {
+		row("FIELD", "VALUE")
+		row("id", tender.ID)
+		row("name", tender.Name)
+		row("description", tender.Description)
+		row("status", tender.Status)
+		row("version", strconv.Itoa(tender.Version))
+		row("service type", tender.ServiceType)
+		row("organization", tender.OrganizationID)
+		row("creator", tender.CreatorUsername)
+		row("deadline", formatTime(tender.SubmissionDeadline))
+		row("sealed", strconv.FormatBool(tender.Sealed))
+		row("archived", formatTime(tender.DeletedAt))
+		row()
+		row("VERSION", "NAME", "STATUS", "SERVICE TYPE", "DEADLINE", "REASON")
+		for _, v := range versions {
+			row(strconv.Itoa(v.Version), v.Name, v.Status, v.ServiceType, formatTime(v.SubmissionDeadline), deref(v.ChangeReason))
+		}
+	})
+}
+
+func (p *printer) bid(b *domain.Bid) error {
+	return p.print(b, func(row func(...string)) {
+		row("ID", "NAME", "STATUS", "VERSION", "TENDER", "AUTHOR", "AMOUNT", "CURRENCY", "SEALED")
+		amount := ""
+		if b.Amount != nil {
+			amount = strconv.FormatInt(*b.Amount, 10)
+		}
+		row(b.ID, b.Name, b.Status, strconv.Itoa(b.Version), b.TenderID, b.AuthorID, amount, deref(b.Currency),
+			strconv.FormatBool(b.Sealed()))
+	})
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
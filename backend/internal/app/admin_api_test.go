@@ -0,0 +1,64 @@
+package app
+
+import (
+	"net/http"
+	"tender_srevice/internal/domain"
+	"testing"
+)
+
+func TestServiceTypeAPI(t *testing.T) {
+	api := newTestAPI(t)
+
+	var types []domain.ServiceType
+	api.expect(http.StatusOK, http.MethodGet, query("/api/service-types", "lang", "en"), nil, &types)
+	if len(types) == 0 {
+		t.Fatal("service type directory is empty")
+	}
+	api.expect(http.StatusOK, http.MethodGet, "/api/service-types/CONSTRUCTION", nil, nil)
+	api.expect(http.StatusNotFound, http.MethodGet, "/api/service-types/CLEANING", nil, nil)
+
+	cleaning := map[string]interface{}{
+		"labels":  map[string]string{"ru": "Уборка", "en": "Cleaning"},
+		"aliases": []string{"клининг"},
+	}
+	api.expect(http.StatusUnauthorized, http.MethodPut, "/api/service-types/CLEANING", cleaning, nil)
+	api.expect(http.StatusUnauthorized, http.MethodPut, "/api/service-types/CLEANING", cleaning, nil, adminAuth("wrong")...)
+	api.expect(http.StatusCreated, http.MethodPut, "/api/service-types/CLEANING", cleaning, nil, adminAuth(testAdminToken)...)
+	api.expect(http.StatusOK, http.MethodPut, "/api/service-types/CLEANING", cleaning, nil, adminAuth(testAdminToken)...)
+	api.expect(http.StatusOK, http.MethodGet, "/api/service-types/CLEANING", nil, nil)
+
+	api.expect(http.StatusUnauthorized, http.MethodDelete, "/api/service-types/CLEANING", nil, nil)
+	api.expect(http.StatusNotFound, http.MethodDelete, "/api/service-types/UNKNOWN", nil, nil, adminAuth(testAdminToken)...)
+	api.expect(http.StatusNoContent, http.MethodDelete, "/api/service-types/CLEANING", nil, nil, adminAuth(testAdminToken)...)
+	api.expect(http.StatusNotFound, http.MethodGet, "/api/service-types/CLEANING", nil, nil)
+}
+
+func TestAdminAPI(t *testing.T) {
+	api := newTestAPI(t)
+
+	t.Run("rate limits", func(t *testing.T) {
+		api.expect(http.StatusOK, http.MethodGet, "/api/admin/rate-limits", nil, nil, adminAuth(testAdminToken)...)
+		api.expect(http.StatusUnauthorized, http.MethodGet, "/api/admin/rate-limits", nil, nil)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		tender := api.createTender(domain.TenderStatusCreated)
+		published := api.createTender(domain.TenderStatusPublished)
+		bid := api.createBid(published.ID, "carol")
+
+		tenderPath := "/api/admin/tenders/" + tender.ID
+		bidPath := "/api/admin/bids/" + bid.ID
+		// Удалить навсегда можно только архивную запись
+		api.expect(http.StatusConflict, http.MethodDelete, tenderPath, nil, nil, adminAuth(testAdminToken)...)
+		api.expect(http.StatusOK, http.MethodPut, query("/api/tenders/"+tender.ID+"/archive", "username", "alice"), nil, nil)
+		api.expect(http.StatusOK, http.MethodPut, query("/api/bids/"+bid.ID+"/archive", "username", "carol"), nil, nil)
+
+		for _, path := range []string{tenderPath, bidPath} {
+			api.expect(http.StatusUnauthorized, http.MethodDelete, path, nil, nil)
+			api.expect(http.StatusNoContent, http.MethodDelete, path, nil, nil, adminAuth(testAdminToken)...)
+		}
+		api.expect(http.StatusNotFound, http.MethodDelete, "/api/admin/tenders/"+missingID, nil, nil, adminAuth(testAdminToken)...)
+		api.expect(http.StatusNotFound, http.MethodDelete, "/api/admin/bids/"+missingID, nil, nil, adminAuth(testAdminToken)...)
+		api.expect(http.StatusNotFound, http.MethodPut, query("/api/tenders/"+tender.ID+"/restore", "username", "alice"), nil, nil)
+	})
+}
@@ -0,0 +1,420 @@
+package app
+
+// Сквозные тесты HTTP API. Каждый тест получает собственную схему в базе из
+// TEST_POSTGRES_CONN, применяет к ней миграции и поднимает настоящий роутер
+// SetupRouter в httptest.Server; без переменной тесты пропускаются.
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"tender_srevice/internal/config"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	_ "github.com/lib/pq"
+)
+
+const (
+	testPostgresEnv = "TEST_POSTGRES_CONN"
+	testAdminToken  = "test-admin-token"
+	// testSealingKey — ключ AES-256 запечатанных тендеров в base64.
+	testSealingKey = "c2VhbGVkLWJpZHMtdGVzdC1rZXktMzItYnl0ZXMhISE="
+
+	// missingID — корректный UUID, которого нет ни в одной таблице.
+	missingID = "5f0c0c3e-0000-4000-8000-000000000000"
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+
+	code := m.Run()
+	filtered := flag.Lookup("test.run").Value.String() != "" || flag.Lookup("test.skip").Value.String() != ""
+	if code == 0 && os.Getenv(testPostgresEnv) != "" && !filtered {
+		if missing := uncoveredRoutes(); len(missing) > 0 {
+			fmt.Fprintf(os.Stderr, "API tests do not cover:\n  %s\n", strings.Join(missing, "\n  "))
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+// testAPI — сервер с собственной схемой и фикстурами: организации acme и globex,
+// ответственные alice (acme) и bob (globex), участники carol и dave без организации.
+type testAPI struct {
+	t     *testing.T
+	url   string
+	cfg   *config.Config
+	db    *sql.DB
+	orgs  map[string]string
+	users map[string]string
+}
+
+var prepareDatabase = sync.OnceValue(func() error {
+	db, err := sql.Open("postgres", os.Getenv(testPostgresEnv))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	// Расширение одно на базу; схемы тестов находят его через search_path
+	_, err = db.Exec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp" WITH SCHEMA public`)
+	return err
+})
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	conn := os.Getenv(testPostgresEnv)
+	if conn == "" {
+		t.Skipf("%s is not set", testPostgresEnv)
+	}
+	t.Parallel()
+	if err := prepareDatabase(); err != nil {
+		t.Fatalf("failed to prepare database: %v", err)
+	}
+
+	admin, err := sql.Open("postgres", conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	suffix := make([]byte, 6)
+	rand.Read(suffix)
+	schema := "apitest_" + hex.EncodeToString(suffix)
+	if _, err := admin.Exec(`CREATE SCHEMA ` + schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`); err != nil {
+			t.Errorf("failed to drop schema %s: %v", schema, err)
+		}
+	})
+
+	cfg := config.Default()
+	cfg.PostgresConn = withSearchPath(conn, schema)
+	cfg.Admin.Token = testAdminToken
+	cfg.Bids.SealingKey = testSealingKey
+	cfg.Attachments.LocalDir = t.TempDir()
+	if err := config.RunMigrations(cfg); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	db, err := sql.Open("postgres", cfg.PostgresConn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	router := SetupRouter(cfg, repository.NewPostgresRepository(db))
+	router.Use(recordCoverage)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	api := &testAPI{t: t, url: server.URL, cfg: cfg, db: db, orgs: map[string]string{}, users: map[string]string{}}
+	api.insertFixtures()
+	return api
+}
+
+// withSearchPath добавляет search_path к строке подключения в URL- или key=value-форме;
+// lib/pq передает неизвестные параметры серверу как параметры сеанса.
+func withSearchPath(conn, schema string) string {
+	if strings.HasPrefix(conn, "postgres://") || strings.HasPrefix(conn, "postgresql://") {
+		sep := "?"
+		if strings.Contains(conn, "?") {
+			sep = "&"
+		}
+		return conn + sep + "search_path=" + url.QueryEscape(schema+",public")
+	}
+	return conn + " search_path=" + schema + ",public"
+}
+
+func (api *testAPI) insertFixtures() {
+	for _, org := range []string{"acme", "globex"} {
+		var id string
+		api.queryRow(&id, `INSERT INTO organization (name, type) VALUES ($1, 'LLC') RETURNING id`, org)
+		api.orgs[org] = id
+	}
+	for _, u := range []struct{ username, org string }{
+		{"alice", "acme"},
+		{"bob", "globex"},
+		{"carol", ""},
+		{"dave", ""},
+	} {
+		var id string
+		api.queryRow(&id, `INSERT INTO employee (username, first_name) VALUES ($1, $1) RETURNING id`, u.username)
+		api.users[u.username] = id
+		if u.org != "" {
+			api.exec(`INSERT INTO organization_responsible (organization_id, user_id) VALUES ($1, $2)`, api.orgs[u.org], id)
+		}
+	}
+}
+
+func (api *testAPI) exec(query string, args ...interface{}) {
+	api.t.Helper()
+	if _, err := api.db.Exec(query, args...); err != nil {
+		api.t.Fatalf("failed to execute %q: %v", query, err)
+	}
+}
+
+func (api *testAPI) queryRow(dst interface{}, query string, args ...interface{}) {
+	api.t.Helper()
+	if err := api.db.QueryRow(query, args...).Scan(dst); err != nil {
+		api.t.Fatalf("failed to execute %q: %v", query, err)
+	}
+}
+
+type apiResponse struct {
+	status int
+	body   []byte
+}
+
+// request отправляет JSON-тело; body типа []byte уходит как есть с заголовком
+// Content-Type из headers.
+func (api *testAPI) request(method, path string, body interface{}, headers ...string) apiResponse {
+	api.t.Helper()
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			api.t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+		headers = append(headers, "Content-Type", "application/json")
+	}
+
+	req, err := http.NewRequest(method, api.url+path, reader)
+	if err != nil {
+		api.t.Fatal(err)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		api.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		api.t.Fatalf("%s %s: failed to read response: %v", method, path, err)
+	}
+	return apiResponse{status: resp.StatusCode, body: data}
+}
+
+// expect проверяет код ответа и, если out не nil, разбирает в него JSON-ответ.
+func (api *testAPI) expect(status int, method, path string, body, out interface{}, headers ...string) {
+	api.t.Helper()
+	resp := api.request(method, path, body, headers...)
+	if resp.status != status {
+		api.t.Fatalf("%s %s: status %d, want %d; body: %s", method, path, resp.status, status, bytes.TrimSpace(resp.body))
+	}
+	if out != nil {
+		if err := json.Unmarshal(resp.body, out); err != nil {
+			api.t.Fatalf("%s %s: failed to decode %s: %v", method, path, resp.body, err)
+		}
+	}
+}
+
+// createTender создает тендер acme от имени alice и переводит его в status.
+func (api *testAPI) createTender(status string) *domain.Tender {
+	api.t.Helper()
+	return api.newTender(status, false)
+}
+
+// createSealedTender — то же для запечатанного тендера.
+func (api *testAPI) createSealedTender(status string) *domain.Tender {
+	api.t.Helper()
+	return api.newTender(status, true)
+}
+
+func (api *testAPI) newTender(status string, sealed bool) *domain.Tender {
+	api.t.Helper()
+	var tender domain.Tender
+	api.expect(http.StatusOK, http.MethodPost, "/api/tenders/new", map[string]interface{}{
+		"name":            "Ремонт склада",
+		"description":     "Замена кровли",
+		"serviceType":     "CONSTRUCTION",
+		"status":          domain.TenderStatusCreated,
+		"sealed":          sealed,
+		"organizationId":  api.orgs["acme"],
+		"creatorUsername": "alice",
+	}, &tender)
+	if status != domain.TenderStatusCreated {
+		api.expect(http.StatusOK, http.MethodPut, "/api/tenders/"+tender.ID+"/status",
+			map[string]string{"status": status, "username": "alice"}, &tender)
+	}
+	return &tender
+}
+
+// createBid подает предложение author к тендеру.
+func (api *testAPI) createBid(tenderID, author string) *domain.Bid {
+	api.t.Helper()
+	var bid domain.Bid
+	api.expect(http.StatusOK, http.MethodPost, "/api/bids/new", map[string]interface{}{
+		"name":        "Предложение " + author,
+		"description": "Сделаем за месяц",
+		"tenderId":    tenderID,
+		"authorType":  "User",
+		"authorId":    api.users[author],
+	}, &bid)
+	return &bid
+}
+
+func query(path string, params ...string) string {
+	v := url.Values{}
+	for i := 0; i+1 < len(params); i += 2 {
+		v.Set(params[i], params[i+1])
+	}
+	return path + "?" + v.Encode()
+}
+
+func adminAuth(token string) []string {
+	return []string{"Authorization", "Bearer " + token}
+}
+
+func timeIn(d time.Duration) string {
+	return time.Now().Add(d).UTC().Format(time.RFC3339)
+}
+
+// coverage — классы ответов, которые тесты получили от каждого маршрута.
+var coverage = struct {
+	sync.Mutex
+	seen map[string]bool
+}{seen: map[string]bool{}}
+
+const (
+	classSuccess  = "success"
+	classDenied   = "denied"
+	classNotFound = "not found"
+)
+
+// coverageExemptions — маршруты, у которых соответствующего ответа нет по смыслу.
+var coverageExemptions = map[string]string{
+	"GET /api/ping denied":                                                "public",
+	"GET /api/ping not found":                                             "no identifiers",
+	"GET /api/openapi.json denied":                                        "public",
+	"GET /api/openapi.json not found":                                     "no identifiers",
+	"GET /api/docs denied":                                                "public",
+	"GET /api/docs not found":                                             "no identifiers",
+	"POST /api/tenders/new denied":                                        "any employee can create a tender",
+	"POST /api/tenders/new not found":                                     "no identifiers",
+	"GET /api/tenders denied":                                             "public",
+	"GET /api/tenders not found":                                          "no identifiers",
+	"GET /api/tenders/my denied":                                          "lists the caller's own tenders",
+	"GET /api/tenders/my not found":                                       "no identifiers",
+	"GET /api/tenders/export denied":                                      "public",
+	"GET /api/tenders/export not found":                                   "no identifiers",
+	"POST /api/tenders/import not found":                                  "no identifiers",
+	"GET /api/tenders/archived not found":                                 "no identifiers",
+	"GET /api/organizations/{organizationId}/tender-templates not found":  "unknown organizations are reported as 403",
+	"POST /api/organizations/{organizationId}/tender-templates not found": "unknown organizations are reported as 403",
+	"POST /api/bids/new denied":                                           "any employee can submit a bid",
+	"GET /api/bids/my denied":                                             "lists the caller's own bids",
+	"GET /api/bids/my not found":                                          "no identifiers",
+	"GET /api/bids/archived not found":                                    "no identifiers",
+	"GET /api/tenders/{tenderId}/auction denied":                          "public",
+	"GET /api/tenders/{tenderId}/criteria denied":                         "public",
+	"GET /api/tenders/{tenderId}/questions/settings denied":               "public",
+	"GET /api/notifications not found":                                    "no identifiers",
+	"PUT /api/notifications/read not found":                               "no identifiers",
+	"GET /api/notifications/preferences not found":                        "no identifiers",
+	"PUT /api/notifications/preferences not found":                        "no identifiers",
+	"GET /api/notifications/email not found":                              "no identifiers",
+	"PUT /api/notifications/email not found":                              "no identifiers",
+	"GET /api/service-types denied":                                       "public",
+	"GET /api/service-types not found":                                    "no identifiers",
+	"GET /api/service-types/{code} denied":                                "public",
+	"PUT /api/service-types/{code} not found":                             "saving an unknown code creates it",
+	"GET /api/admin/rate-limits not found":                                "no identifiers",
+	"POST /graphql denied":                                                "reported as extensions.code FORBIDDEN, see TestGraphQLAPI",
+	"POST /graphql not found":                                             "reported as extensions.code NOT_FOUND, see TestGraphQLAPI",
+}
+
+func recordCoverage(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		var class string
+		switch {
+		case rec.status >= 200 && rec.status < 300:
+			class = classSuccess
+		case rec.status == http.StatusUnauthorized || rec.status == http.StatusForbidden:
+			class = classDenied
+		case rec.status == http.StatusNotFound:
+			class = classNotFound
+		default:
+			return
+		}
+		tpl, err := mux.CurrentRoute(r).GetPathTemplate()
+		if err != nil {
+			return
+		}
+		coverage.Lock()
+		coverage.seen[r.Method+" "+tpl+" "+class] = true
+		coverage.Unlock()
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap нужен http.ResponseController, которым загрузка вложений снимает таймаут чтения.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// uncoveredRoutes перечисляет маршруты и классы ответов, которые не встретились ни в
+// одном тесте и не освобождены в coverageExemptions.
+func uncoveredRoutes() []string {
+	var missing []string
+	router := SetupRouter(config.Default(), repository.NewPostgresRepository(nil))
+	router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, _ := route.GetMethods()
+		for _, method := range methods {
+			for _, class := range []string{classSuccess, classDenied, classNotFound} {
+				key := method + " " + tpl + " " + class
+				if !coverage.seen[key] && coverageExemptions[key] == "" {
+					missing = append(missing, key)
+				}
+			}
+		}
+		return nil
+	})
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"tender_srevice/internal/domain"
+	"testing"
+)
+
+func TestAttachmentAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createTender(domain.TenderStatusCreated)
+	published := api.createTender(domain.TenderStatusPublished)
+	bid := api.createBid(published.ID, "carol")
+
+	// Вложения неопубликованного тендера доступны только ответственным, вложения
+	// предложения — его автору и ответственным
+	for _, owner := range []struct {
+		name, path, missing, author, stranger string
+	}{
+		{"tender", "/api/tenders/" + tender.ID, "/api/tenders/" + missingID, "alice", "bob"},
+		{"bid", "/api/bids/" + bid.ID, "/api/bids/" + missingID, "carol", "dave"},
+	} {
+		t.Run(owner.name, func(t *testing.T) {
+			body, contentType := multipartFile(t, "spec.txt", "Техническое задание")
+			upload := owner.path + "/attachments"
+			api.expect(http.StatusForbidden, http.MethodPost, query(upload, "username", owner.stranger), body, nil,
+				"Content-Type", contentType)
+			api.expect(http.StatusNotFound, http.MethodPost, query(owner.missing+"/attachments", "username", owner.author), body, nil,
+				"Content-Type", contentType)
+			var uploaded []domain.Attachment
+			api.expect(http.StatusCreated, http.MethodPost, query(upload, "username", owner.author), body, &uploaded,
+				"Content-Type", contentType)
+			if len(uploaded) != 1 || uploaded[0].FileName != "spec.txt" {
+				t.Fatalf("uploaded = %+v, want spec.txt", uploaded)
+			}
+
+			var list []domain.Attachment
+			api.expect(http.StatusOK, http.MethodGet, query(upload, "username", owner.author), nil, &list)
+			if len(list) != 1 || list[0].ID != uploaded[0].ID {
+				t.Errorf("attachments = %+v, want only %s", list, uploaded[0].ID)
+			}
+			api.expect(http.StatusForbidden, http.MethodGet, query(upload, "username", owner.stranger), nil, nil)
+			api.expect(http.StatusNotFound, http.MethodGet, query(owner.missing+"/attachments", "username", owner.author), nil, nil)
+
+			file := upload + "/" + uploaded[0].ID
+			missingFile := upload + "/" + missingID
+			resp := api.request(http.MethodGet, query(file, "username", owner.author), nil)
+			if resp.status != http.StatusOK || string(resp.body) != "Техническое задание" {
+				t.Errorf("download: status %d, body %q", resp.status, resp.body)
+			}
+			api.expect(http.StatusForbidden, http.MethodGet, query(file, "username", owner.stranger), nil, nil)
+			api.expect(http.StatusNotFound, http.MethodGet, query(missingFile, "username", owner.author), nil, nil)
+
+			api.expect(http.StatusForbidden, http.MethodDelete, query(file, "username", owner.stranger), nil, nil)
+			api.expect(http.StatusNotFound, http.MethodDelete, query(missingFile, "username", owner.author), nil, nil)
+			api.expect(http.StatusNoContent, http.MethodDelete, query(file, "username", owner.author), nil, nil)
+			api.expect(http.StatusNotFound, http.MethodGet, query(file, "username", owner.author), nil, nil)
+		})
+	}
+}
+
+func multipartFile(t *testing.T, name, content string) ([]byte, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte(content))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), w.FormDataContentType()
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"net/http"
+	"tender_srevice/internal/config"
+	"tender_srevice/internal/storage"
+	"time"
+)
+
+// newAttachmentStorage выбирает хранилище вложений по attachments.storage.
+func newAttachmentStorage(cfg config.AttachmentsConfig) storage.Storage {
+	if cfg.Storage == "s3" {
+		return storage.NewS3(storage.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Bucket:    cfg.S3.Bucket,
+			Region:    cfg.S3.Region,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+		}, &http.Client{Timeout: 10 * time.Minute})
+	}
+	return storage.NewLocal(cfg.LocalDir)
+}
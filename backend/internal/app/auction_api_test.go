@@ -0,0 +1,58 @@
+package app
+
+import (
+	"net/http"
+	"tender_srevice/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestAuctionAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createTender(domain.TenderStatusPublished)
+	path := "/api/tenders/" + tender.ID + "/auction"
+	missing := "/api/tenders/" + missingID + "/auction"
+
+	api.expect(http.StatusNotFound, http.MethodGet, path, nil, nil)
+
+	settings := map[string]interface{}{
+		"startsAt":        timeIn(time.Hour),
+		"durationSeconds": 600,
+		"currency":        "RUB",
+		"minDecrement":    100,
+	}
+	api.expect(http.StatusForbidden, http.MethodPut, query(path, "username", "bob"), settings, nil)
+	api.expect(http.StatusNotFound, http.MethodPut, query(missing, "username", "alice"), settings, nil)
+	api.expect(http.StatusOK, http.MethodPut, query(path, "username", "alice"), settings, nil)
+
+	// Рейтинг ставок раскрыл бы участников запечатанного тендера до закрытия
+	sealed := api.createSealedTender(domain.TenderStatusPublished)
+	api.expect(http.StatusConflict, http.MethodPut, query("/api/tenders/"+sealed.ID+"/auction", "username", "alice"), settings, nil)
+	api.expect(http.StatusNotFound, http.MethodGet, "/api/tenders/"+sealed.ID+"/auction", nil, nil)
+
+	var auction domain.Auction
+	api.expect(http.StatusOK, http.MethodGet, path, nil, &auction)
+	if auction.Currency != "RUB" || auction.DurationSeconds != 600 {
+		t.Errorf("auction = %+v, want the configured settings", auction)
+	}
+	api.expect(http.StatusNotFound, http.MethodGet, missing, nil, nil)
+
+	// Ждать начала не нужно: сдвигаем аукцион в прошлое напрямую в базе
+	api.exec(`UPDATE tender_auctions SET starts_at = now() - interval '1 minute', ends_at = now() + interval '1 hour'
+		WHERE tender_id = $1`, tender.ID)
+
+	bids := path + "/bids"
+	api.expect(http.StatusForbidden, http.MethodPost, bids, map[string]interface{}{"username": "alice", "amount": 10000}, nil)
+	api.expect(http.StatusNotFound, http.MethodPost, missing+"/bids", map[string]interface{}{"username": "carol", "amount": 10000}, nil)
+	api.expect(http.StatusOK, http.MethodPost, bids, map[string]interface{}{"username": "carol", "amount": 10000}, nil)
+
+	var standing struct {
+		Participants int `json:"participants"`
+	}
+	api.expect(http.StatusOK, http.MethodGet, query(path+"/ranking", "username", "alice"), nil, &standing)
+	if standing.Participants != 1 {
+		t.Errorf("participants = %d, want 1", standing.Participants)
+	}
+	api.expect(http.StatusUnauthorized, http.MethodGet, query(path+"/ranking", "username", "nobody"), nil, nil)
+	api.expect(http.StatusNotFound, http.MethodGet, query(missing+"/ranking", "username", "alice"), nil, nil)
+}
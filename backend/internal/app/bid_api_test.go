@@ -0,0 +1,199 @@
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/service"
+	"testing"
+)
+
+func TestBidAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createTender(domain.TenderStatusPublished)
+	bid := api.createBid(tender.ID, "carol")
+	path := "/api/bids/" + bid.ID
+	missing := "/api/bids/" + missingID
+
+	t.Run("create", func(t *testing.T) {
+		api.expect(http.StatusNotFound, http.MethodPost, "/api/bids/new", map[string]interface{}{
+			"name":        "Предложение",
+			"description": "Без тендера",
+			"tenderId":    missingID,
+			"authorType":  "User",
+			"authorId":    api.users["carol"],
+		}, nil)
+
+		var mine service.BidPage
+		api.expect(http.StatusOK, http.MethodGet, "/api/bids/my", map[string]string{"username": "carol"}, &mine)
+		if len(mine.Bids) != 1 || mine.Bids[0].ID != bid.ID || mine.NextCursor != "" {
+			t.Errorf("carol's bids = %+v, want only %s", mine, bid.ID)
+		}
+	})
+
+	t.Run("tender bids", func(t *testing.T) {
+		for _, list := range []string{"/api/bids/" + tender.ID + "/list", "/api/tenders/" + tender.ID + "/bids"} {
+			var page service.BidPage
+			api.expect(http.StatusOK, http.MethodGet, list, map[string]string{"username": "alice"}, &page)
+			if len(page.Bids) != 1 || page.Bids[0].ID != bid.ID {
+				t.Errorf("%s = %+v, want only %s", list, page, bid.ID)
+			}
+			api.expect(http.StatusForbidden, http.MethodGet, list, map[string]string{"username": "carol"}, nil)
+		}
+		api.expect(http.StatusNotFound, http.MethodGet, "/api/bids/"+missingID+"/list", map[string]string{"username": "alice"}, nil)
+		api.expect(http.StatusNotFound, http.MethodGet, "/api/tenders/"+missingID+"/bids", map[string]string{"username": "alice"}, nil)
+
+		export := "/api/tenders/" + tender.ID + "/bids/export"
+		api.expect(http.StatusOK, http.MethodGet, query(export, "username", "alice", "format", "jsonl"), nil, nil)
+		api.expect(http.StatusForbidden, http.MethodGet, query(export, "username", "bob", "format", "jsonl"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodGet,
+			query("/api/tenders/"+missingID+"/bids/export", "username", "alice", "format", "jsonl"), nil, nil)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		var got map[string]string
+		api.expect(http.StatusOK, http.MethodGet, path+"/status", map[string]string{"username": "alice"}, &got)
+		if got["status"] != domain.BidStatusPending {
+			t.Errorf("status = %q, want %s", got["status"], domain.BidStatusPending)
+		}
+		api.expect(http.StatusForbidden, http.MethodGet, path+"/status", map[string]string{"username": "dave"}, nil)
+		api.expect(http.StatusNotFound, http.MethodGet, missing+"/status", map[string]string{"username": "alice"}, nil)
+
+		update := map[string]string{"username": "alice", "newStatus": domain.BidStatusAccepted}
+		api.expect(http.StatusForbidden, http.MethodPut, path+"/status",
+			map[string]string{"username": "dave", "newStatus": domain.BidStatusAccepted}, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, missing+"/status", update, nil)
+		api.expect(http.StatusOK, http.MethodPut, path+"/status", update, nil)
+		api.expect(http.StatusOK, http.MethodPut, path+"/status",
+			map[string]string{"username": "alice", "newStatus": domain.BidStatusPending}, nil)
+	})
+
+	t.Run("edit", func(t *testing.T) {
+		edit := map[string]string{"name": "Предложение carol v2", "description": "Сделаем за три недели"}
+		api.expect(http.StatusOK, http.MethodPatch, query(path+"/edit", "username", "alice"), edit, nil)
+		api.expect(http.StatusForbidden, http.MethodPatch, query(path+"/edit", "username", "dave"), edit, nil)
+		api.expect(http.StatusNotFound, http.MethodPatch, query(missing+"/edit", "username", "alice"), edit, nil)
+	})
+
+	t.Run("withdraw and resubmit", func(t *testing.T) {
+		reason := map[string]string{"reason": "Пересчитываем смету"}
+		api.expect(http.StatusForbidden, http.MethodPut, query(path+"/withdraw", "username", "dave"), reason, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, query(missing+"/withdraw", "username", "carol"), reason, nil)
+		var withdrawn domain.Bid
+		api.expect(http.StatusOK, http.MethodPut, query(path+"/withdraw", "username", "carol"), reason, &withdrawn)
+		if withdrawn.Status != domain.BidStatusRejected {
+			t.Errorf("status after withdrawal = %q, want %s", withdrawn.Status, domain.BidStatusRejected)
+		}
+
+		withdrawals := "/api/tenders/" + tender.ID + "/bids/withdrawals"
+		api.expect(http.StatusOK, http.MethodGet, query(withdrawals, "username", "alice"), nil, nil)
+		api.expect(http.StatusForbidden, http.MethodGet, query(withdrawals, "username", "bob"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodGet,
+			query("/api/tenders/"+missingID+"/bids/withdrawals", "username", "alice"), nil, nil)
+
+		api.expect(http.StatusForbidden, http.MethodPut, query(path+"/resubmit", "username", "dave"), reason, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, query(missing+"/resubmit", "username", "carol"), reason, nil)
+		api.expect(http.StatusOK, http.MethodPut, query(path+"/resubmit", "username", "carol"), reason, nil)
+	})
+
+	t.Run("archive and restore", func(t *testing.T) {
+		api.expect(http.StatusForbidden, http.MethodPut, query(path+"/archive", "username", "dave"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, query(missing+"/archive", "username", "carol"), nil, nil)
+		api.expect(http.StatusOK, http.MethodPut, query(path+"/archive", "username", "carol"), nil, nil)
+
+		var archived []domain.Bid
+		api.expect(http.StatusOK, http.MethodGet, query("/api/bids/archived", "username", "carol"), nil, &archived)
+		if len(archived) != 1 || archived[0].ID != bid.ID {
+			t.Errorf("archived bids = %+v, want only %s", archived, bid.ID)
+		}
+		api.expect(http.StatusUnauthorized, http.MethodGet, query("/api/bids/archived", "username", "nobody"), nil, nil)
+
+		api.expect(http.StatusForbidden, http.MethodPut, query(path+"/restore", "username", "dave"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, query(missing+"/restore", "username", "carol"), nil, nil)
+		api.expect(http.StatusOK, http.MethodPut, query(path+"/restore", "username", "carol"), nil, nil)
+	})
+}
+
+func TestBidPagination(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createTender(domain.TenderStatusPublished)
+	for i := 0; i < 5; i++ {
+		api.createBid(tender.ID, "carol")
+	}
+	list := "/api/tenders/" + tender.ID + "/bids"
+	alice := map[string]string{"username": "alice"}
+
+	var all service.BidPage
+	api.expect(http.StatusOK, http.MethodGet, list, alice, &all)
+	if len(all.Bids) != 5 || all.NextCursor != "" {
+		t.Fatalf("full list = %+v, want 5 bids on one page", all)
+	}
+
+	// Предложение, поданное между страницами, не сдвигает следующие страницы
+	var seen []string
+	cursor := ""
+	for i := 0; ; i++ {
+		params := []string{"limit", "2"}
+		if cursor != "" {
+			params = append(params, "cursor", cursor)
+		}
+		var page service.BidPage
+		api.expect(http.StatusOK, http.MethodGet, query(list, params...), alice, &page)
+		for _, b := range page.Bids {
+			seen = append(seen, b.ID)
+		}
+		if i == 0 {
+			api.createBid(tender.ID, "dave")
+		}
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+	if len(seen) != len(all.Bids) {
+		t.Fatalf("paged through %d bids, want %d", len(seen), len(all.Bids))
+	}
+	for i, b := range all.Bids {
+		if seen[i] != b.ID {
+			t.Errorf("bid %d = %s, want %s", i, seen[i], b.ID)
+		}
+	}
+
+	var first service.BidPage
+	api.expect(http.StatusOK, http.MethodGet, query(list, "limit", "1"), alice, &first)
+	api.expect(http.StatusBadRequest, http.MethodGet, query(list, "limit", "1", "cursor", first.NextCursor, "sort", "price_asc"), alice, nil)
+	api.expect(http.StatusBadRequest, http.MethodGet, query(list, "cursor", "not-a-cursor"), alice, nil)
+	api.expect(http.StatusBadRequest, http.MethodGet, query(list, "limit", "0"), alice, nil)
+
+	var mine service.BidPage
+	api.expect(http.StatusOK, http.MethodGet, query("/api/bids/my", "limit", "3"), map[string]string{"username": "carol"}, &mine)
+	if len(mine.Bids) != 3 || mine.NextCursor == "" {
+		t.Fatalf("carol's first page = %+v, want 3 bids and a cursor", mine)
+	}
+	api.expect(http.StatusOK, http.MethodGet, query("/api/bids/my", "cursor", mine.NextCursor), map[string]string{"username": "carol"}, &mine)
+	if len(mine.Bids) != 2 || mine.NextCursor != "" {
+		t.Errorf("carol's last page = %+v, want 2 bids", mine)
+	}
+}
+
+func TestSealedBidWithdrawalsAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createSealedTender(domain.TenderStatusPublished)
+	bid := api.createBid(tender.ID, "carol")
+	api.expect(http.StatusOK, http.MethodPut, query("/api/bids/"+bid.ID+"/withdraw", "username", "carol"),
+		map[string]string{"reason": "Пересчитываем смету"}, nil)
+
+	// До закрытия история раскрыла бы автора и ID запечатанного предложения
+	withdrawals := "/api/tenders/" + tender.ID + "/bids/withdrawals"
+	resp := api.request(http.MethodGet, query(withdrawals, "username", "alice"), nil)
+	if resp.status != http.StatusConflict || bytes.Contains(resp.body, []byte("carol")) {
+		t.Fatalf("withdrawals before close: status %d, body %s; want 409 without the author", resp.status, resp.body)
+	}
+
+	api.expect(http.StatusOK, http.MethodPut, "/api/tenders/"+tender.ID+"/status",
+		map[string]string{"status": domain.TenderStatusClosed, "username": "alice"}, nil)
+	var history []domain.BidWithdrawal
+	api.expect(http.StatusOK, http.MethodGet, query(withdrawals, "username", "alice"), nil, &history)
+	if len(history) != 1 || history[0].BidID != bid.ID || history[0].AuthorUsername != "carol" {
+		t.Errorf("withdrawals after close = %+v, want carol's withdrawal of %s", history, bid.ID)
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"net/http"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/service"
+	"testing"
+)
+
+func TestEvaluationAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createTender(domain.TenderStatusPublished)
+	bid := api.createBid(tender.ID, "carol")
+	api.expect(http.StatusOK, http.MethodPut, "/api/bids/"+bid.ID+"/status",
+		map[string]string{"username": "alice", "newStatus": domain.BidStatusAccepted}, nil)
+
+	criteriaPath := "/api/tenders/" + tender.ID + "/criteria"
+	missingTender := "/api/tenders/" + missingID
+	criteria := []map[string]interface{}{
+		{"name": "Цена", "description": "Чем ниже, тем лучше", "weight": 2, "scaleMin": 0, "scaleMax": 10},
+		{"name": "Сроки", "weight": 1, "scaleMin": 0, "scaleMax": 5},
+	}
+	api.expect(http.StatusForbidden, http.MethodPut, query(criteriaPath, "username", "bob"), criteria, nil)
+	api.expect(http.StatusNotFound, http.MethodPut, query(missingTender+"/criteria", "username", "alice"), criteria, nil)
+	api.expect(http.StatusOK, http.MethodPut, query(criteriaPath, "username", "alice"), criteria, nil)
+	var saved []domain.Criterion
+	api.expect(http.StatusOK, http.MethodGet, criteriaPath, nil, &saved)
+	if len(saved) != len(criteria) {
+		t.Fatalf("criteria = %+v, want %d", saved, len(criteria))
+	}
+	api.expect(http.StatusNotFound, http.MethodGet, missingTender+"/criteria", nil, nil)
+
+	scoresPath := "/api/bids/" + bid.ID + "/scores"
+	missingScores := "/api/bids/" + missingID + "/scores"
+	scores := []map[string]interface{}{
+		{"criterionId": saved[0].ID, "score": 8, "comment": "Ниже рынка"},
+		{"criterionId": saved[1].ID, "score": 4},
+	}
+	api.expect(http.StatusForbidden, http.MethodPut, query(scoresPath, "username", "bob"), scores, nil)
+	api.expect(http.StatusNotFound, http.MethodPut, query(missingScores, "username", "alice"), scores, nil)
+	api.expect(http.StatusOK, http.MethodPut, query(scoresPath, "username", "alice"), scores, nil)
+
+	var got []domain.BidScore
+	api.expect(http.StatusOK, http.MethodGet, query(scoresPath, "username", "alice"), nil, &got)
+	if len(got) != len(scores) {
+		t.Errorf("scores = %+v, want %d", got, len(scores))
+	}
+	api.expect(http.StatusForbidden, http.MethodGet, query(scoresPath, "username", "bob"), nil, nil)
+	api.expect(http.StatusNotFound, http.MethodGet, query(missingScores, "username", "alice"), nil, nil)
+
+	// После оценок критерии менять нельзя
+	api.expect(http.StatusConflict, http.MethodPut, query(criteriaPath, "username", "alice"), criteria, nil)
+
+	ranking := "/api/tenders/" + tender.ID + "/bids/ranking"
+	api.expect(http.StatusOK, http.MethodGet, query(ranking, "username", "alice"), nil, nil)
+	api.expect(http.StatusOK, http.MethodGet, query(ranking, "username", "carol"), nil, nil)
+	api.expect(http.StatusForbidden, http.MethodGet, query(ranking, "username", "dave"), nil, nil)
+	api.expect(http.StatusNotFound, http.MethodGet, query(missingTender+"/bids/ranking", "username", "alice"), nil, nil)
+}
+
+func TestEvaluationSealedAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createSealedTender(domain.TenderStatusPublished)
+	bid := api.createBid(tender.ID, "carol")
+	api.expect(http.StatusOK, http.MethodPut, "/api/bids/"+bid.ID+"/status",
+		map[string]string{"username": "alice", "newStatus": domain.BidStatusAccepted}, nil)
+
+	// До закрытия рейтинг раскрыл бы авторов и ID запечатанных предложений
+	ranking := "/api/tenders/" + tender.ID + "/bids/ranking"
+	scores := "/api/bids/" + bid.ID + "/scores"
+	api.expect(http.StatusConflict, http.MethodGet, query(ranking, "username", "alice"), nil, nil)
+	api.expect(http.StatusConflict, http.MethodGet, query(ranking, "username", "carol"), nil, nil)
+	api.expect(http.StatusConflict, http.MethodGet, query(scores, "username", "alice"), nil, nil)
+
+	api.expect(http.StatusOK, http.MethodPut, "/api/tenders/"+tender.ID+"/status",
+		map[string]string{"status": domain.TenderStatusClosed, "username": "alice"}, nil)
+	var result service.BidRanking
+	api.expect(http.StatusOK, http.MethodGet, query(ranking, "username", "alice"), nil, &result)
+	if len(result.Ranking) != 1 || result.Ranking[0].BidID != bid.ID || result.Ranking[0].BidName != bid.Name {
+		t.Errorf("ranking after close = %+v, want the unsealed bid %s", result.Ranking, bid.ID)
+	}
+	api.expect(http.StatusOK, http.MethodGet, query(scores, "username", "alice"), nil, nil)
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"net/http"
+	"tender_srevice/internal/domain"
+	"testing"
+)
+
+func TestServiceRoutes(t *testing.T) {
+	api := newTestAPI(t)
+	for _, path := range []string{"/api/ping", "/api/openapi.json", "/api/docs"} {
+		api.expect(http.StatusOK, http.MethodGet, path, nil, nil)
+	}
+}
+
+// Ошибки GraphQL приходят со статусом 200, а вид отказа передается в extensions.code.
+func TestGraphQLAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createTender(domain.TenderStatusCreated)
+
+	const tenderQuery = `query($id: ID!) { tender(id: $id) { id name status organization { id } } }`
+	for _, tc := range []struct {
+		name, username, id, code string
+	}{
+		{"responsible", "alice", tender.ID, ""},
+		{"stranger", "bob", tender.ID, "FORBIDDEN"},
+		{"missing", "alice", missingID, "NOT_FOUND"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp struct {
+				Data struct {
+					Tender *struct {
+						ID string `json:"id"`
+					} `json:"tender"`
+				} `json:"data"`
+				Errors []struct {
+					Message    string `json:"message"`
+					Extensions struct {
+						Code string `json:"code"`
+					} `json:"extensions"`
+				} `json:"errors"`
+			}
+			body := map[string]interface{}{"query": tenderQuery, "variables": map[string]string{"id": tc.id}}
+			api.expect(http.StatusOK, http.MethodPost, query("/graphql", "username", tc.username), body, &resp)
+
+			if tc.code == "" {
+				if len(resp.Errors) != 0 || resp.Data.Tender == nil || resp.Data.Tender.ID != tc.id {
+					t.Errorf("response = %+v, want tender %s", resp, tc.id)
+				}
+				return
+			}
+			if len(resp.Errors) != 1 || resp.Errors[0].Extensions.Code != tc.code {
+				t.Errorf("errors = %+v, want code %s", resp.Errors, tc.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"tender_srevice/internal/config"
+	"tender_srevice/internal/grpcapi"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"google.golang.org/grpc"
+)
+
+// NewGRPCServer собирает TenderService и BidService так же, как SetupRouter,
+// и отдает их gRPC-серверу.
+func NewGRPCServer(cfg *config.Config, repo *repository.PostgresRepository) *grpc.Server {
+	bidSealing := NewBidSealing(cfg, repo)
+	notificationService := service.NewNotificationService(repo, NewMailTemplates(cfg))
+	return grpcapi.NewServer(
+		service.NewTenderService(repo, bidSealing, notificationService),
+		service.NewBidService(repo, cfg.Bids.Currencies, bidSealing, notificationService),
+		cfg.GRPC.Reflection,
+	)
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"log/slog"
+	"tender_srevice/internal/config"
+	"tender_srevice/internal/mailer"
+	"tender_srevice/internal/repository"
+	"time"
+)
+
+// mailLease — на сколько взятое из очереди письмо скрывается от других обработчиков.
+const mailLease = 5 * time.Minute
+
+// NewMailTemplates возвращает шаблоны писем, если mail.enabled, иначе nil (письма не отправляются).
+func NewMailTemplates(cfg *config.Config) *mailer.Templates {
+	if !cfg.Mail.Enabled {
+		return nil
+	}
+	templates, err := mailer.LoadTemplates()
+	if err != nil {
+		slog.Error("notification emails are disabled", slog.Any("error", err))
+		return nil
+	}
+	return templates
+}
+
+// NewMailDispatcher создает обработчик очереди писем; вызывается только при mail.enabled.
+func NewMailDispatcher(cfg *config.Config, repo *repository.PostgresRepository) (*mailer.Dispatcher, error) {
+	sender, err := mailer.NewSMTPSender(mailer.SMTPConfig{
+		Host:     cfg.Mail.SMTP.Host,
+		Port:     cfg.Mail.SMTP.Port,
+		Username: cfg.Mail.SMTP.Username,
+		Password: cfg.Mail.SMTP.Password,
+		TLS:      cfg.Mail.SMTP.TLS,
+		From:     cfg.Mail.From,
+		Timeout:  cfg.Mail.SMTP.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mailer.NewDispatcher(repo, sender, mailer.QueueConfig{
+		Interval:     cfg.Mail.Queue.Interval,
+		BatchSize:    cfg.Mail.Queue.BatchSize,
+		MaxAttempts:  cfg.Mail.Queue.MaxAttempts,
+		RetryBackoff: cfg.Mail.Queue.RetryBackoff,
+		Lease:        mailLease,
+	}), nil
+}
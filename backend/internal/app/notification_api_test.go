@@ -0,0 +1,51 @@
+package app
+
+import (
+	"net/http"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/service"
+	"testing"
+)
+
+func TestNotificationAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createTender(domain.TenderStatusPublished)
+	api.createBid(tender.ID, "carol")
+
+	var inbox service.NotificationInbox
+	api.expect(http.StatusOK, http.MethodGet, query("/api/notifications", "username", "alice"), nil, &inbox)
+	if len(inbox.Notifications) != 1 || inbox.Notifications[0].Type != domain.NotificationBidCreated {
+		t.Fatalf("alice's notifications = %+v, want one %s", inbox.Notifications, domain.NotificationBidCreated)
+	}
+	api.expect(http.StatusUnauthorized, http.MethodGet, query("/api/notifications", "username", "nobody"), nil, nil)
+
+	read := "/api/notifications/" + inbox.Notifications[0].ID + "/read"
+	api.expect(http.StatusUnauthorized, http.MethodPut, query(read, "username", "nobody"), nil, nil)
+	// Чужое уведомление для bob не существует
+	api.expect(http.StatusNotFound, http.MethodPut, query(read, "username", "bob"), nil, nil)
+	api.expect(http.StatusNotFound, http.MethodPut, query("/api/notifications/"+missingID+"/read", "username", "alice"), nil, nil)
+	api.expect(http.StatusNoContent, http.MethodPut, query(read, "username", "alice"), nil, nil)
+
+	api.expect(http.StatusOK, http.MethodPut, query("/api/notifications/read", "username", "alice"), nil, nil)
+	api.expect(http.StatusUnauthorized, http.MethodPut, query("/api/notifications/read", "username", "nobody"), nil, nil)
+
+	prefs := "/api/notifications/preferences"
+	api.expect(http.StatusOK, http.MethodGet, query(prefs, "username", "alice"), nil, nil)
+	api.expect(http.StatusUnauthorized, http.MethodGet, query(prefs, "username", "nobody"), nil, nil)
+	disable := map[string]bool{domain.NotificationBidCreated: false}
+	var saved []domain.NotificationPreference
+	api.expect(http.StatusOK, http.MethodPut, query(prefs, "username", "alice"), disable, &saved)
+	for _, p := range saved {
+		if p.Type == domain.NotificationBidCreated && p.Enabled {
+			t.Errorf("%s is still enabled", p.Type)
+		}
+	}
+	api.expect(http.StatusUnauthorized, http.MethodPut, query(prefs, "username", "nobody"), disable, nil)
+
+	email := "/api/notifications/email"
+	api.expect(http.StatusOK, http.MethodGet, query(email, "username", "alice"), nil, nil)
+	api.expect(http.StatusUnauthorized, http.MethodGet, query(email, "username", "nobody"), nil, nil)
+	settings := map[string]interface{}{"email": "alice@example.com", "language": "en", "enabled": true}
+	api.expect(http.StatusOK, http.MethodPut, query(email, "username", "alice"), settings, nil)
+	api.expect(http.StatusUnauthorized, http.MethodPut, query(email, "username", "nobody"), settings, nil)
+}
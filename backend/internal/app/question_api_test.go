@@ -0,0 +1,71 @@
+package app
+
+import (
+	"net/http"
+	"tender_srevice/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestQuestionAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createTender(domain.TenderStatusPublished)
+	path := "/api/tenders/" + tender.ID + "/questions"
+	missing := "/api/tenders/" + missingID + "/questions"
+
+	t.Run("settings", func(t *testing.T) {
+		api.expect(http.StatusOK, http.MethodGet, path+"/settings", nil, nil)
+		api.expect(http.StatusNotFound, http.MethodGet, missing+"/settings", nil, nil)
+
+		deadline := map[string]string{"deadline": timeIn(24 * time.Hour)}
+		api.expect(http.StatusForbidden, http.MethodPut, query(path+"/settings", "username", "bob"), deadline, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, query(missing+"/settings", "username", "alice"), deadline, nil)
+		var settings domain.QuestionSettings
+		api.expect(http.StatusOK, http.MethodPut, query(path+"/settings", "username", "alice"), deadline, &settings)
+		if settings.Deadline == nil {
+			t.Error("deadline was not saved")
+		}
+	})
+
+	ask := func(text string) *domain.TenderQuestion {
+		t.Helper()
+		var q domain.TenderQuestion
+		api.expect(http.StatusCreated, http.MethodPost, path, map[string]string{"username": "carol", "text": text}, &q)
+		return &q
+	}
+
+	t.Run("ask and list", func(t *testing.T) {
+		// Организация тендера не задает вопросы сама себе
+		api.expect(http.StatusForbidden, http.MethodPost, path, map[string]string{"username": "alice", "text": "Вопрос"}, nil)
+		api.expect(http.StatusNotFound, http.MethodPost, missing, map[string]string{"username": "carol", "text": "Вопрос"}, nil)
+		ask("Можно ли использовать металлочерепицу?")
+
+		var questions []domain.TenderQuestion
+		api.expect(http.StatusOK, http.MethodGet, query(path, "username", "alice"), nil, &questions)
+		if len(questions) != 1 {
+			t.Errorf("questions = %+v, want one", questions)
+		}
+		api.expect(http.StatusUnauthorized, http.MethodGet, query(path, "username", "nobody"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodGet, missing, nil, nil)
+	})
+
+	t.Run("answer and reject", func(t *testing.T) {
+		q := ask("Какой срок гарантии?")
+		answer := "/api/questions/" + q.ID + "/answer"
+		body := map[string]string{"answer": "Пять лет"}
+		api.expect(http.StatusForbidden, http.MethodPut, query(answer, "username", "bob"), body, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, query("/api/questions/"+missingID+"/answer", "username", "alice"), body, nil)
+		var answered domain.TenderQuestion
+		api.expect(http.StatusOK, http.MethodPut, query(answer, "username", "alice"), body, &answered)
+		if answered.Status != domain.QuestionStatusAnswered {
+			t.Errorf("status = %q, want %s", answered.Status, domain.QuestionStatusAnswered)
+		}
+
+		q = ask("Вопрос не по теме")
+		reject := "/api/questions/" + q.ID + "/reject"
+		reason := map[string]string{"reason": "Не относится к тендеру"}
+		api.expect(http.StatusForbidden, http.MethodPut, query(reject, "username", "bob"), reason, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, query("/api/questions/"+missingID+"/reject", "username", "alice"), reason, nil)
+		api.expect(http.StatusOK, http.MethodPut, query(reject, "username", "alice"), reason, nil)
+	})
+}
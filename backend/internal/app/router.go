@@ -3,7 +3,11 @@ package app
 import (
 	"net/http"
 	"tender_srevice/internal/config"
+	"tender_srevice/internal/graphapi"
 	"tender_srevice/internal/handler"
+	"tender_srevice/internal/middleware"
+	"tender_srevice/internal/openapi"
+	"tender_srevice/internal/ratelimit"
 	"tender_srevice/internal/service"
 	"tender_srevice/internal/repository"
 
@@ -12,20 +16,50 @@ import (
 
 func SetupRouter(cfg *config.Config, repo *repository.PostgresRepository) *mux.Router {
 	router := mux.NewRouter()
+	router.Use(middleware.RequestID, middleware.AccessLog)
 
-	tenderService := service.NewTenderService(repo)
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		limiter = newRateLimiter(cfg.RateLimit, repo)
+		router.Use(middleware.RateLimit(limiter, cfg.RateLimit.TrustForwardedFor))
+	}
+	if cfg.Features.OpenAPIValidation {
+		router.Use(middleware.ValidateRequest(openapi.MustLoad()))
+	}
+
+	adminOnly := middleware.AdminOnly(cfg.Admin.Token)
+	bidSealing := NewBidSealing(cfg, repo)
+	notificationService := service.NewNotificationService(repo, NewMailTemplates(cfg))
+	tenderService := service.NewTenderService(repo, bidSealing, notificationService)
 	tenderHandler := handler.NewTenderHandler(tenderService)
 
 	router.HandleFunc("/api/ping", handler.PingHandler).Methods(http.MethodGet)
+	if cfg.Features.APIDocs {
+		router.HandleFunc("/api/openapi.json", openapi.SpecHandler).Methods(http.MethodGet)
+		router.HandleFunc("/api/docs", openapi.UIHandler).Methods(http.MethodGet)
+	}
 	router.HandleFunc("/api/tenders/new", tenderHandler.CreateTender).Methods(http.MethodPost)
 	router.HandleFunc("/api/tenders", tenderHandler.GetTenders).Methods(http.MethodGet)
 	router.HandleFunc("/api/tenders/my", tenderHandler.GetMyTenders).Methods(http.MethodGet)
+	router.HandleFunc("/api/tenders/export", tenderHandler.ExportTenders).Methods(http.MethodGet)
+	router.HandleFunc("/api/tenders/import", adminOnly(tenderHandler.ImportTenders)).Methods(http.MethodPost)
 	router.HandleFunc("/api/tenders/{tenderId}/status", tenderHandler.GetTenderStatus).Methods(http.MethodGet)
 	router.HandleFunc("/api/tenders/{tenderId}/status", tenderHandler.UpdateTenderStatus).Methods(http.MethodPut)
 	router.HandleFunc("/api/tenders/{tenderId}/edit", tenderHandler.UpdateTender).Methods(http.MethodPatch)
 	router.HandleFunc("/api/tenders/{tenderId}/rollback/{version}", tenderHandler.RollbackTender).Methods(http.MethodPut)
+	router.HandleFunc("/api/tenders/{tenderId}/clone", tenderHandler.CloneTender).Methods(http.MethodPost)
+	router.HandleFunc("/api/tenders/archived", tenderHandler.GetArchivedTenders).Methods(http.MethodGet)
+	router.HandleFunc("/api/tenders/{tenderId}/archive", tenderHandler.ArchiveTender).Methods(http.MethodPut)
+	router.HandleFunc("/api/tenders/{tenderId}/restore", tenderHandler.RestoreTender).Methods(http.MethodPut)
+
+	templateHandler := handler.NewTemplateHandler(service.NewTemplateService(repo))
+
+	router.HandleFunc("/api/organizations/{organizationId}/tender-templates", templateHandler.ListTemplates).Methods(http.MethodGet)
+	router.HandleFunc("/api/organizations/{organizationId}/tender-templates", templateHandler.CreateTemplate).Methods(http.MethodPost)
+	router.HandleFunc("/api/tender-templates/{templateId}", templateHandler.GetTemplate).Methods(http.MethodGet)
+	router.HandleFunc("/api/tender-templates/{templateId}", templateHandler.DeleteTemplate).Methods(http.MethodDelete)
 
-	bidService := service.NewBidService(repo)
+	bidService := service.NewBidService(repo, cfg.Bids.Currencies, bidSealing, notificationService)
 	bidHandler := handler.NewBidHandler(bidService)
 
 	router.HandleFunc("/api/bids/new", bidHandler.CreateBid).Methods(http.MethodPost)
@@ -34,10 +68,92 @@ func SetupRouter(cfg *config.Config, repo *repository.PostgresRepository) *mux.R
 	router.HandleFunc("/api/bids/{bidId}/status", bidHandler.GetBidStatusByID).Methods(http.MethodGet)
 	router.HandleFunc("/api/bids/{bidId}/status", bidHandler.UpdateBidStatus).Methods(http.MethodPut)
 	router.HandleFunc("/api/bids/{bidId}/edit", bidHandler.EditBid).Methods(http.MethodPatch)
+	router.HandleFunc("/api/bids/{bidId}/withdraw", bidHandler.WithdrawBid).Methods(http.MethodPut)
+	router.HandleFunc("/api/bids/{bidId}/resubmit", bidHandler.ResubmitBid).Methods(http.MethodPut)
+	router.HandleFunc("/api/bids/archived", bidHandler.GetArchivedBids).Methods(http.MethodGet)
+	router.HandleFunc("/api/bids/{bidId}/archive", bidHandler.ArchiveBid).Methods(http.MethodPut)
+	router.HandleFunc("/api/bids/{bidId}/restore", bidHandler.RestoreBid).Methods(http.MethodPut)
 
 
 	router.HandleFunc("/api/tenders/{tenderId}/bids", bidHandler.GetBidsByTenderID).Methods(http.MethodGet)
-	
+	router.HandleFunc("/api/tenders/{tenderId}/bids/export", bidHandler.ExportBidsByTenderID).Methods(http.MethodGet)
+	router.HandleFunc("/api/tenders/{tenderId}/bids/withdrawals", bidHandler.GetBidWithdrawals).Methods(http.MethodGet)
+
+	attachmentStore := newAttachmentStorage(cfg.Attachments)
+	attachmentService := service.NewAttachmentService(repo, attachmentStore,
+		int64(cfg.Attachments.MaxSize), cfg.Attachments.AllowedTypes)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService)
+
+	for _, prefix := range []string{"/api/tenders/{tenderId}", "/api/bids/{bidId}"} {
+		router.HandleFunc(prefix+"/attachments", attachmentHandler.ListAttachments).Methods(http.MethodGet)
+		router.HandleFunc(prefix+"/attachments", attachmentHandler.UploadAttachments).Methods(http.MethodPost)
+		router.HandleFunc(prefix+"/attachments/{attachmentId}", attachmentHandler.DownloadAttachment).Methods(http.MethodGet)
+		router.HandleFunc(prefix+"/attachments/{attachmentId}", attachmentHandler.DeleteAttachment).Methods(http.MethodDelete)
+	}
+
+	auctionHandler := handler.NewAuctionHandler(service.NewAuctionService(repo, cfg.Bids.Currencies))
+
+	router.HandleFunc("/api/tenders/{tenderId}/auction", auctionHandler.GetAuction).Methods(http.MethodGet)
+	router.HandleFunc("/api/tenders/{tenderId}/auction", auctionHandler.ConfigureAuction).Methods(http.MethodPut)
+	router.HandleFunc("/api/tenders/{tenderId}/auction/bids", auctionHandler.PlaceBid).Methods(http.MethodPost)
+	router.HandleFunc("/api/tenders/{tenderId}/auction/ranking", auctionHandler.GetRanking).Methods(http.MethodGet)
+
+	evaluationHandler := handler.NewEvaluationHandler(service.NewEvaluationService(repo))
+
+	router.HandleFunc("/api/tenders/{tenderId}/criteria", evaluationHandler.GetCriteria).Methods(http.MethodGet)
+	router.HandleFunc("/api/tenders/{tenderId}/criteria", evaluationHandler.SetCriteria).Methods(http.MethodPut)
+	router.HandleFunc("/api/tenders/{tenderId}/bids/ranking", evaluationHandler.GetRanking).Methods(http.MethodGet)
+	router.HandleFunc("/api/bids/{bidId}/scores", evaluationHandler.GetBidScores).Methods(http.MethodGet)
+	router.HandleFunc("/api/bids/{bidId}/scores", evaluationHandler.ScoreBid).Methods(http.MethodPut)
+
+	questionHandler := handler.NewQuestionHandler(service.NewQuestionService(repo, notificationService))
+
+	router.HandleFunc("/api/tenders/{tenderId}/questions", questionHandler.ListQuestions).Methods(http.MethodGet)
+	router.HandleFunc("/api/tenders/{tenderId}/questions", questionHandler.AskQuestion).Methods(http.MethodPost)
+	router.HandleFunc("/api/tenders/{tenderId}/questions/settings", questionHandler.GetSettings).Methods(http.MethodGet)
+	router.HandleFunc("/api/tenders/{tenderId}/questions/settings", questionHandler.UpdateSettings).Methods(http.MethodPut)
+	router.HandleFunc("/api/questions/{questionId}/answer", questionHandler.AnswerQuestion).Methods(http.MethodPut)
+	router.HandleFunc("/api/questions/{questionId}/reject", questionHandler.RejectQuestion).Methods(http.MethodPut)
+
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+
+	router.HandleFunc("/api/notifications", notificationHandler.GetNotifications).Methods(http.MethodGet)
+	router.HandleFunc("/api/notifications/read", notificationHandler.MarkAllRead).Methods(http.MethodPut)
+	router.HandleFunc("/api/notifications/preferences", notificationHandler.GetPreferences).Methods(http.MethodGet)
+	router.HandleFunc("/api/notifications/preferences", notificationHandler.UpdatePreferences).Methods(http.MethodPut)
+	router.HandleFunc("/api/notifications/email", notificationHandler.GetEmailSettings).Methods(http.MethodGet)
+	router.HandleFunc("/api/notifications/email", notificationHandler.UpdateEmailSettings).Methods(http.MethodPut)
+	router.HandleFunc("/api/notifications/{notificationId}/read", notificationHandler.MarkRead).Methods(http.MethodPut)
+
+	adminHandler := handler.NewAdminHandler(limiter)
+	serviceTypeHandler := handler.NewServiceTypeHandler(service.NewServiceTypeService(repo))
+
+	router.HandleFunc("/api/service-types", serviceTypeHandler.ListServiceTypes).Methods(http.MethodGet)
+	router.HandleFunc("/api/service-types/{code}", serviceTypeHandler.GetServiceType).Methods(http.MethodGet)
+	router.HandleFunc("/api/service-types/{code}", adminOnly(serviceTypeHandler.SaveServiceType)).Methods(http.MethodPut)
+	router.HandleFunc("/api/service-types/{code}", adminOnly(serviceTypeHandler.DeleteServiceType)).Methods(http.MethodDelete)
+
+	router.HandleFunc("/api/admin/rate-limits", adminOnly(adminHandler.GetRateLimitStats)).Methods(http.MethodGet)
+
+	purgeHandler := handler.NewPurgeHandler(service.NewPurgeService(repo, attachmentStore))
+
+	router.HandleFunc("/api/admin/tenders/{tenderId}", adminOnly(purgeHandler.PurgeTender)).Methods(http.MethodDelete)
+	router.HandleFunc("/api/admin/bids/{bidId}", adminOnly(purgeHandler.PurgeBid)).Methods(http.MethodDelete)
+
+	if cfg.GraphQL.Enabled {
+		resolver := graphapi.NewResolver(repo, tenderService, bidService, bidSealing)
+		router.Handle("/graphql", graphapi.NewHandler(resolver, cfg.GraphQL.MaxComplexity)).Methods(http.MethodPost)
+	}
+
 
 	return router
-}
\ No newline at end of file
+}
+
+func newRateLimiter(cfg config.RateLimitConfig, repo *repository.PostgresRepository) *ratelimit.Limiter {
+	read := ratelimit.Rule{Rate: cfg.RequestsPerSecond, Burst: cfg.Burst}
+	write := ratelimit.Rule{Rate: cfg.MutationRPS, Burst: cfg.MutationBurst}
+	if cfg.Store == "postgres" {
+		return ratelimit.New(ratelimit.NewPostgresStore(repo), cfg.Store, read, write)
+	}
+	return ratelimit.New(ratelimit.NewMemoryStore(), cfg.Store, read, write)
+}
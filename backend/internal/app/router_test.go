@@ -0,0 +1,51 @@
+package app
+
+import (
+	"strings"
+	"tender_srevice/internal/config"
+	"tender_srevice/internal/openapi"
+	"tender_srevice/internal/repository"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestEveryRouteIsDescribedInOpenAPISpec(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("failed to load openapi spec: %v", err)
+	}
+
+	router := SetupRouter(config.Default(), repository.NewPostgresRepository(nil))
+
+	registered := map[string]bool{}
+	err = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			t.Errorf("route %s has no methods", tpl)
+			return nil
+		}
+		for _, method := range methods {
+			registered[method+" "+tpl] = true
+			if spec.Operation(tpl, method) == nil {
+				t.Errorf("route %s %s is missing from openapi.json", method, tpl)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk router: %v", err)
+	}
+
+	for path, item := range spec.Paths {
+		for method := range item {
+			if !registered[strings.ToUpper(method)+" "+path] {
+				t.Errorf("openapi.json describes %s %s, but the route is not registered", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+	"testing"
+)
+
+func TestSealedBidsAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createSealedTender(domain.TenderStatusPublished)
+	if !tender.Sealed {
+		t.Fatalf("tender = %+v, want sealed", tender)
+	}
+	tenderPath := "/api/tenders/" + tender.ID
+	bid := api.createBid(tender.ID, "carol")
+	bidPath := "/api/bids/" + bid.ID
+	alice := map[string]string{"username": "alice"}
+
+	// Автору предложение возвращается расшифрованным, в базе остается только шифртекст
+	if bid.Name != "Предложение carol" {
+		t.Errorf("created bid name = %q, want it revealed to the author", bid.Name)
+	}
+	var name string
+	var sealed bool
+	api.queryRow(&sealed, `SELECT sealed_payload IS NOT NULL FROM bid WHERE id = $1`, bid.ID)
+	api.queryRow(&name, `SELECT name FROM bid WHERE id = $1`, bid.ID)
+	if !sealed || name != "" {
+		t.Errorf("stored bid: sealed = %v, name = %q; want only the sealed payload", sealed, name)
+	}
+
+	t.Run("summary until close", func(t *testing.T) {
+		resp := api.request(http.MethodGet, tenderPath+"/bids", alice)
+		if resp.status != http.StatusOK {
+			t.Fatalf("status %d, want 200; body: %s", resp.status, resp.body)
+		}
+		if bytes.Contains(resp.body, []byte(bid.ID)) || bytes.Contains(resp.body, []byte("carol")) {
+			t.Errorf("sealed summary exposes the bid: %s", resp.body)
+		}
+		var summary service.SealedBids
+		api.expect(http.StatusOK, http.MethodGet, tenderPath+"/bids", alice, &summary)
+		if !summary.Sealed || summary.Count != 1 || len(summary.SubmittedAt) != 1 {
+			t.Errorf("summary = %+v, want one sealed bid", summary)
+		}
+		api.expect(http.StatusConflict, http.MethodGet, query(tenderPath+"/bids/export", "username", "alice", "format", "jsonl"), nil, nil)
+	})
+
+	t.Run("author reveal and edit", func(t *testing.T) {
+		var mine service.BidPage
+		api.expect(http.StatusOK, http.MethodGet, "/api/bids/my", map[string]string{"username": "carol"}, &mine)
+		if len(mine.Bids) != 1 || mine.Bids[0].Name != bid.Name {
+			t.Errorf("carol's bids = %+v, want %q revealed", mine.Bids, bid.Name)
+		}
+
+		edit := map[string]string{"name": "Предложение carol v2", "description": "Сделаем за три недели"}
+		api.expect(http.StatusConflict, http.MethodPatch, query(bidPath+"/edit", "username", "alice"), edit, nil)
+		api.expect(http.StatusForbidden, http.MethodPatch, query(bidPath+"/edit", "username", "dave"), edit, nil)
+		api.expect(http.StatusOK, http.MethodPatch, query(bidPath+"/edit", "username", "carol"), edit, nil)
+		api.queryRow(&name, `SELECT name FROM bid WHERE id = $1`, bid.ID)
+		if name != "" {
+			t.Errorf("edited bid is stored in the clear: name = %q", name)
+		}
+	})
+
+	t.Run("unseal on close", func(t *testing.T) {
+		api.expect(http.StatusOK, http.MethodPut, tenderPath+"/status",
+			map[string]string{"status": domain.TenderStatusClosed, "username": "alice"}, nil)
+
+		var events int
+		var unsealedBy, reason string
+		api.queryRow(&events, `SELECT count(*) FROM bid_unseal_events WHERE tender_id = $1`, tender.ID)
+		if events != 1 {
+			t.Fatalf("unseal events = %d, want 1", events)
+		}
+		api.queryRow(&unsealedBy, `SELECT unsealed_by FROM bid_unseal_events WHERE tender_id = $1`, tender.ID)
+		api.queryRow(&reason, `SELECT reason FROM bid_unseal_events WHERE tender_id = $1`, tender.ID)
+		if unsealedBy != "alice" || reason != service.UnsealOnCloseReason {
+			t.Errorf("unseal event by %q with reason %q, want alice and %q", unsealedBy, reason, service.UnsealOnCloseReason)
+		}
+
+		var page service.BidPage
+		api.expect(http.StatusOK, http.MethodGet, tenderPath+"/bids", alice, &page)
+		if len(page.Bids) != 1 || page.Bids[0].Name != "Предложение carol v2" {
+			t.Errorf("bids after close = %+v, want the edited bid in the clear", page.Bids)
+		}
+		api.queryRow(&sealed, `SELECT sealed_payload IS NOT NULL FROM bid WHERE id = $1`, bid.ID)
+		if sealed {
+			t.Error("bid is still sealed after close")
+		}
+	})
+
+	t.Run("no reopening", func(t *testing.T) {
+		api.expect(http.StatusConflict, http.MethodPut, tenderPath+"/status",
+			map[string]string{"status": domain.TenderStatusPublished, "username": "alice"}, nil)
+		// Первая версия — CREATED, откат к ней открыл бы тендер снова
+		api.expect(http.StatusConflict, http.MethodPut, tenderPath+"/rollback/1", alice, nil)
+
+		repo := repository.NewPostgresRepository(api.db)
+		ops := service.NewOperationsService(repo, NewBidSealing(api.cfg, repo))
+		ctx := context.Background()
+		if _, err := ops.ForceTenderStatus(ctx, tender.ID, domain.TenderStatusPublished, "reopen", "ops"); !errors.Is(err, service.ErrSealedTenderClosed) {
+			t.Errorf("forced reopening: err = %v, want ErrSealedTenderClosed", err)
+		}
+		if _, err := ops.RollbackTender(ctx, tender.ID, 1, "ops"); !errors.Is(err, service.ErrSealedTenderClosed) {
+			t.Errorf("operator rollback: err = %v, want ErrSealedTenderClosed", err)
+		}
+
+		var status map[string]string
+		api.expect(http.StatusOK, http.MethodGet, query(tenderPath+"/status", "username", "alice"), nil, &status)
+		if status["status"] != domain.TenderStatusClosed {
+			t.Errorf("status = %q, want %s", status["status"], domain.TenderStatusClosed)
+		}
+	})
+}
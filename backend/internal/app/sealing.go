@@ -0,0 +1,24 @@
+package app
+
+import (
+	"log/slog"
+	"tender_srevice/internal/config"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/sealing"
+	"tender_srevice/internal/service"
+)
+
+// NewBidSealing создает шифрование запечатанных предложений по bids.sealing_key.
+// Без ключа запечатанные тендеры отключены; корректность ключа проверяет config.Validate.
+func NewBidSealing(cfg *config.Config, repo *repository.PostgresRepository) *service.BidSealing {
+	key, err := cfg.Bids.SealingKeyBytes()
+	if err != nil || key == nil {
+		return service.NewBidSealing(repo, nil)
+	}
+	sealer, err := sealing.New(key)
+	if err != nil {
+		slog.Error("sealed tenders are disabled", slog.Any("error", err))
+		return service.NewBidSealing(repo, nil)
+	}
+	return service.NewBidSealing(repo, sealer)
+}
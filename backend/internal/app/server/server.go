@@ -1,13 +1,22 @@
 package server
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"tender_srevice/internal/app"
 	"tender_srevice/internal/config"
+	"tender_srevice/internal/middleware"
 	"tender_srevice/internal/repository"
+	"tender_srevice/internal/scheduler"
 
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
 )
 
 type Server struct {
@@ -25,7 +34,87 @@ func New(cfg *config.Config, repo *repository.PostgresRepository) *Server {
 	return s
 }
 
+// Run обслуживает запросы до SIGINT/SIGTERM, после чего дожидается
+// завершения активных запросов в пределах ShutdownTimeout.
 func (s *Server) Run() error {
-	fmt.Printf("Server is running on %s\n", s.config.ServerAddress)
-	return http.ListenAndServe(s.config.ServerAddress, s.router)
-}
\ No newline at end of file
+	srv := &http.Server{
+		Addr: s.config.ServerAddress,
+		// CORS оборачивает роутер целиком: preflight-запросы OPTIONS не совпадают ни с одним маршрутом
+		Handler:      middleware.CORS(s.config.CORS)(s.router),
+		ReadTimeout:  s.config.Server.ReadTimeout,
+		WriteTimeout: s.config.Server.WriteTimeout,
+		IdleTimeout:  s.config.Server.IdleTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if s.config.Scheduler.Enabled {
+		unsealer := app.NewBidSealing(s.config, s.repo)
+		go scheduler.NewDeadlineScheduler(s.repo, s.config.Scheduler.DeadlineInterval, unsealer).Run(ctx)
+	}
+
+	if s.config.Mail.Enabled {
+		dispatcher, err := app.NewMailDispatcher(s.config, s.repo)
+		if err != nil {
+			return err
+		}
+		go dispatcher.Run(ctx)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("server is running", slog.String("address", s.config.ServerAddress))
+		errCh <- srv.ListenAndServe()
+	}()
+
+	var grpcSrv *grpc.Server
+	grpcErrCh := make(chan error, 1)
+	if s.config.GRPC.Enabled {
+		lis, err := net.Listen("tcp", s.config.GRPC.Address)
+		if err != nil {
+			return err
+		}
+		grpcSrv = app.NewGRPCServer(s.config, s.repo)
+		go func() {
+			slog.Info("grpc server is running", slog.String("address", s.config.GRPC.Address))
+			if err := grpcSrv.Serve(lis); err != nil {
+				grpcErrCh <- err
+			}
+		}()
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	case err := <-grpcErrCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.Server.ShutdownTimeout)
+	defer cancel()
+	grpcStopped := make(chan struct{})
+	if grpcSrv != nil {
+		go func() {
+			grpcSrv.GracefulStop()
+			close(grpcStopped)
+		}()
+	} else {
+		close(grpcStopped)
+	}
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	// Незавершенные потоки gRPC обрываются по тому же ShutdownTimeout
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		grpcSrv.Stop()
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
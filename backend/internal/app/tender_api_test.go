@@ -0,0 +1,161 @@
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"tender_srevice/internal/domain"
+	"testing"
+)
+
+func TestTenderAPI(t *testing.T) {
+	api := newTestAPI(t)
+	tender := api.createTender(domain.TenderStatusCreated)
+	path := "/api/tenders/" + tender.ID
+	missing := "/api/tenders/" + missingID
+
+	t.Run("lists", func(t *testing.T) {
+		api.expect(http.StatusOK, http.MethodGet, "/api/tenders", nil, nil)
+		var mine []domain.Tender
+		api.expect(http.StatusOK, http.MethodGet, query("/api/tenders/my", "username", "alice"), nil, &mine)
+		if len(mine) != 1 || mine[0].ID != tender.ID {
+			t.Errorf("alice's tenders = %+v, want only %s", mine, tender.ID)
+		}
+		api.expect(http.StatusOK, http.MethodGet, query("/api/tenders/export", "format", "jsonl"), nil, nil)
+
+		// Выгрузка фильтрует по виду услуг так же, как список
+		export := api.request(http.MethodGet, query("/api/tenders/export", "format", "jsonl", "serviceType", "DELIVERY"), nil)
+		if export.status != http.StatusOK || len(bytes.TrimSpace(export.body)) != 0 {
+			t.Errorf("DELIVERY export: status %d, body %s; want no CONSTRUCTION tenders", export.status, export.body)
+		}
+		export = api.request(http.MethodGet, query("/api/tenders/export", "format", "jsonl", "serviceType", "construction"), nil)
+		if export.status != http.StatusOK || !bytes.Contains(export.body, []byte(tender.ID)) {
+			t.Errorf("CONSTRUCTION export: status %d, body %s; want %s", export.status, export.body, tender.ID)
+		}
+		api.expect(http.StatusBadRequest, http.MethodGet, query("/api/tenders/export", "serviceType", "NO_SUCH_TYPE"), nil, nil)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		// Закрытый тендер переносится с прошедшим сроком подачи
+		rows := `{"name":"Поставка труб","description":"100 м","serviceType":"DELIVERY","status":"CREATED",` +
+			`"organizationId":"` + api.orgs["acme"] + `","creatorUsername":"alice"}` + "\n" +
+			`{"name":"Ремонт кровли 2019","description":"Архив","serviceType":"CONSTRUCTION","status":"CLOSED",` +
+			`"submissionDeadline":"2019-06-01T00:00:00Z","organizationId":"` + api.orgs["acme"] + `","creatorUsername":"alice"}` + "\n"
+		path := query("/api/tenders/import", "format", "jsonl", "dryRun", "true")
+		var report struct {
+			DryRun bool `json:"dryRun"`
+			Valid  int  `json:"valid"`
+		}
+		api.expect(http.StatusOK, http.MethodPost, path, []byte(rows), &report,
+			append(adminAuth(testAdminToken), "Content-Type", "application/x-ndjson")...)
+		if !report.DryRun || report.Valid != 2 {
+			t.Errorf("import report = %+v, want two valid rows in a dry run", report)
+		}
+		api.expect(http.StatusUnauthorized, http.MethodPost, path, []byte(rows), nil, "Content-Type", "application/x-ndjson")
+	})
+
+	t.Run("status", func(t *testing.T) {
+		var got map[string]string
+		api.expect(http.StatusOK, http.MethodGet, query(path+"/status", "username", "alice"), nil, &got)
+		if got["status"] != domain.TenderStatusCreated {
+			t.Errorf("status = %q, want %s", got["status"], domain.TenderStatusCreated)
+		}
+		api.expect(http.StatusForbidden, http.MethodGet, query(path+"/status", "username", "bob"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodGet, query(missing+"/status", "username", "alice"), nil, nil)
+
+		api.expect(http.StatusForbidden, http.MethodPut, path+"/status",
+			map[string]string{"status": domain.TenderStatusPublished, "username": "bob"}, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, missing+"/status",
+			map[string]string{"status": domain.TenderStatusPublished, "username": "alice"}, nil)
+		api.expect(http.StatusOK, http.MethodPut, path+"/status",
+			map[string]string{"status": domain.TenderStatusPublished, "username": "alice"}, nil)
+
+		// Опубликованный тендер виден всем
+		api.expect(http.StatusOK, http.MethodGet, query(path+"/status", "username", "bob"), nil, nil)
+		api.expect(http.StatusOK, http.MethodPut, path+"/status",
+			map[string]string{"status": domain.TenderStatusCreated, "username": "alice"}, nil)
+	})
+
+	t.Run("edit and rollback", func(t *testing.T) {
+		edit := map[string]string{"username": "alice", "name": "Ремонт склада №2"}
+		var edited domain.Tender
+		api.expect(http.StatusOK, http.MethodPatch, path+"/edit", edit, &edited)
+		if edited.Name != edit["name"] {
+			t.Errorf("edited name = %q, want %q", edited.Name, edit["name"])
+		}
+		api.expect(http.StatusForbidden, http.MethodPatch, path+"/edit", map[string]string{"username": "bob", "name": "x"}, nil)
+		api.expect(http.StatusNotFound, http.MethodPatch, missing+"/edit", edit, nil)
+
+		var rolledBack domain.Tender
+		api.expect(http.StatusOK, http.MethodPut, path+"/rollback/1", map[string]string{"username": "alice"}, &rolledBack)
+		if rolledBack.Name != tender.Name {
+			t.Errorf("name after rollback = %q, want %q", rolledBack.Name, tender.Name)
+		}
+		api.expect(http.StatusForbidden, http.MethodPut, path+"/rollback/1", map[string]string{"username": "bob"}, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, missing+"/rollback/1", map[string]string{"username": "alice"}, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, path+"/rollback/99", map[string]string{"username": "alice"}, nil)
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		var clone domain.Tender
+		api.expect(http.StatusOK, http.MethodPost, query(path+"/clone", "username", "alice"), nil, &clone)
+		if clone.ID == tender.ID || clone.Status != domain.TenderStatusCreated {
+			t.Errorf("clone = %+v, want a new CREATED tender", clone)
+		}
+		api.expect(http.StatusForbidden, http.MethodPost, query(path+"/clone", "username", "bob"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodPost, query(missing+"/clone", "username", "alice"), nil, nil)
+	})
+
+	t.Run("archive and restore", func(t *testing.T) {
+		api.expect(http.StatusForbidden, http.MethodPut, query(path+"/archive", "username", "bob"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, query(missing+"/archive", "username", "alice"), nil, nil)
+		api.expect(http.StatusOK, http.MethodPut, query(path+"/archive", "username", "alice"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodGet, query(path+"/status", "username", "alice"), nil, nil)
+
+		var archived []domain.Tender
+		api.expect(http.StatusOK, http.MethodGet, query("/api/tenders/archived", "username", "alice"), nil, &archived)
+		if len(archived) != 1 || archived[0].ID != tender.ID {
+			t.Errorf("archived tenders = %+v, want only %s", archived, tender.ID)
+		}
+		api.expect(http.StatusUnauthorized, http.MethodGet, query("/api/tenders/archived", "username", "nobody"), nil, nil)
+
+		api.expect(http.StatusForbidden, http.MethodPut, query(path+"/restore", "username", "bob"), nil, nil)
+		api.expect(http.StatusNotFound, http.MethodPut, query(missing+"/restore", "username", "alice"), nil, nil)
+		api.expect(http.StatusOK, http.MethodPut, query(path+"/restore", "username", "alice"), nil, nil)
+		api.expect(http.StatusOK, http.MethodGet, query(path+"/status", "username", "alice"), nil, nil)
+	})
+}
+
+func TestTenderTemplateAPI(t *testing.T) {
+	api := newTestAPI(t)
+	templates := "/api/organizations/" + api.orgs["acme"] + "/tender-templates"
+	request := map[string]interface{}{
+		"title":       "Ремонт",
+		"name":        "Ремонт {{object}}",
+		"description": "Ремонт объекта {{object}}",
+		"serviceType": "CONSTRUCTION",
+		"sealed":      false,
+		"criteria":    []interface{}{},
+	}
+
+	var template domain.TenderTemplate
+	api.expect(http.StatusCreated, http.MethodPost, query(templates, "username", "alice"), request, &template)
+	api.expect(http.StatusForbidden, http.MethodPost, query(templates, "username", "bob"), request, nil)
+
+	var list []domain.TenderTemplate
+	api.expect(http.StatusOK, http.MethodGet, query(templates, "username", "alice"), nil, &list)
+	if len(list) != 1 || list[0].ID != template.ID {
+		t.Errorf("templates = %+v, want only %s", list, template.ID)
+	}
+	api.expect(http.StatusForbidden, http.MethodGet, query(templates, "username", "bob"), nil, nil)
+
+	path := "/api/tender-templates/" + template.ID
+	missing := "/api/tender-templates/" + missingID
+	api.expect(http.StatusOK, http.MethodGet, query(path, "username", "alice"), nil, nil)
+	api.expect(http.StatusForbidden, http.MethodGet, query(path, "username", "bob"), nil, nil)
+	api.expect(http.StatusNotFound, http.MethodGet, query(missing, "username", "alice"), nil, nil)
+
+	api.expect(http.StatusForbidden, http.MethodDelete, query(path, "username", "bob"), nil, nil)
+	api.expect(http.StatusNotFound, http.MethodDelete, query(missing, "username", "alice"), nil, nil)
+	api.expect(http.StatusNoContent, http.MethodDelete, query(path, "username", "alice"), nil, nil)
+	api.expect(http.StatusNotFound, http.MethodGet, query(path, "username", "alice"), nil, nil)
+}
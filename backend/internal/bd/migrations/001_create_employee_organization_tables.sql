@@ -1,4 +1,6 @@
-CREATE TABLE employee (
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS employee (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     username VARCHAR(50) UNIQUE NOT NULL,
     first_name VARCHAR(50),
@@ -7,13 +9,18 @@ CREATE TABLE employee (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
-CREATE TYPE organization_type AS ENUM (
-    'IE',
-    'LLC',
-    'JSC'
-);
+DO $$
+BEGIN
+    CREATE TYPE organization_type AS ENUM (
+        'IE',
+        'LLC',
+        'JSC'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
 
-CREATE TABLE organization (
+CREATE TABLE IF NOT EXISTS organization (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     name VARCHAR(100) NOT NULL,
     description TEXT,
@@ -22,7 +29,7 @@ CREATE TABLE organization (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
-CREATE TABLE organization_responsible (
+CREATE TABLE IF NOT EXISTS organization_responsible (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
     user_id UUID REFERENCES employee(id) ON DELETE CASCADE
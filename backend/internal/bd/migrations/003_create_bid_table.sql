@@ -26,25 +26,27 @@ CREATE TABLE IF NOT EXISTS bid_versions (
     FOREIGN KEY (bid_id) REFERENCES bid(id) ON DELETE CASCADE
 );
 
--- Удаляем триггер, если он существует
-DROP TRIGGER IF EXISTS trigger_save_bid_version ON bid;
-
--- Удаляем функцию, если она существует
-DROP FUNCTION IF EXISTS save_bid_version();
-
-CREATE OR REPLACE FUNCTION save_bid_version() RETURNS TRIGGER AS $$
+-- Функция и триггер создаются только в новой базе; полное определение функции — в 017
+DO $$
 BEGIN
-    -- Сохранение текущей версии предложения в таблицу bid_versions перед обновлением
-    INSERT INTO bid_versions (bid_id, name, description, status, tender_id, author_type, author_id, version, created_at)
-    SELECT OLD.id, OLD.name, OLD.description, OLD.status, OLD.tender_id, OLD.author_type, OLD.author_id, OLD.version, OLD.created_at;
-    -- Увеличиваем версию на 1 при каждом обновлении
-    NEW.version := OLD.version + 1;
-    RETURN NEW;
-END;
-$$ LANGUAGE plpgsql;
+    IF NOT EXISTS (SELECT 1 FROM pg_proc WHERE proname = 'save_bid_version' AND pronamespace = current_schema()::regnamespace) THEN
+        CREATE FUNCTION save_bid_version() RETURNS TRIGGER AS $fn$
+        BEGIN
+            -- Сохранение текущей версии предложения в таблицу bid_versions перед обновлением
+            INSERT INTO bid_versions (bid_id, name, description, status, tender_id, author_type, author_id, version, created_at)
+            SELECT OLD.id, OLD.name, OLD.description, OLD.status, OLD.tender_id, OLD.author_type, OLD.author_id, OLD.version, OLD.created_at;
+            -- Увеличиваем версию на 1 при каждом обновлении
+            NEW.version := OLD.version + 1;
+            RETURN NEW;
+        END;
+        $fn$ LANGUAGE plpgsql;
+    END IF;
 
-CREATE TRIGGER trigger_save_bid_version
-BEFORE UPDATE ON bid
-FOR EACH ROW
-EXECUTE FUNCTION save_bid_version();
+    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trigger_save_bid_version' AND tgrelid = 'bid'::regclass) THEN
+        CREATE TRIGGER trigger_save_bid_version
+        BEFORE UPDATE ON bid
+        FOR EACH ROW
+        EXECUTE FUNCTION save_bid_version();
+    END IF;
+END $$;
 
@@ -0,0 +1,8 @@
+-- Состояние token bucket для ограничения частоты запросов в режиме rate_limit.store = postgres
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key TEXT PRIMARY KEY, -- Группа маршрутов и идентификатор клиента
+    tokens DOUBLE PRECISION NOT NULL, -- Доступные токены на момент updated_at
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
@@ -0,0 +1,13 @@
+-- Срок подачи предложений и причина последнего изменения тендера
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS change_reason TEXT;
+
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tender_versions ADD COLUMN IF NOT EXISTS change_reason TEXT;
+
+-- Планировщик ищет опубликованные тендеры с истекшим сроком
+CREATE INDEX IF NOT EXISTS idx_tenders_published_deadline
+    ON tenders (submission_deadline)
+    WHERE status = 'PUBLISHED' AND submission_deadline IS NOT NULL;
+
+-- Новые колонки попадают в историю через save_tender_version, определенную в 017
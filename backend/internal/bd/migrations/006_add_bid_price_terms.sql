@@ -0,0 +1,17 @@
+-- Цена (в минимальных единицах валюты), валюта ISO 4217, срок поставки и срок действия предложения
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS amount BIGINT CHECK (amount >= 0);
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS currency CHAR(3);
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS delivery_days INTEGER CHECK (delivery_days >= 0);
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS valid_from TIMESTAMP WITH TIME ZONE;
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS valid_until TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS amount BIGINT;
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS currency CHAR(3);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS delivery_days INTEGER;
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS valid_from TIMESTAMP WITH TIME ZONE;
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS valid_until TIMESTAMP WITH TIME ZONE;
+
+-- Сортировка и фильтрация предложений тендера по цене
+CREATE INDEX IF NOT EXISTS idx_bid_tender_amount ON bid (tender_id, currency, amount);
+
+-- Новые колонки попадают в историю через save_bid_version, определенную в 017
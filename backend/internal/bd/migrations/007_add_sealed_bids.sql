@@ -0,0 +1,25 @@
+-- Запечатанные тендеры: содержимое предложений скрыто от организации до закрытия
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Пока тендер открыт, название, описание и условия предложения хранятся только
+-- в sealed_payload (AES-256-GCM), а открытые колонки пусты
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS sealed_payload BYTEA;
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS sealed_payload BYTEA;
+
+CREATE INDEX IF NOT EXISTS idx_bid_sealed ON bid (tender_id) WHERE sealed_payload IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_bid_versions_sealed ON bid_versions (tender_id) WHERE sealed_payload IS NOT NULL;
+
+-- Журнал вскрытия предложений
+CREATE TABLE IF NOT EXISTS bid_unseal_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tenders(id) ON DELETE CASCADE,
+    bids_count INTEGER NOT NULL, -- Сколько предложений вскрыто
+    versions_count INTEGER NOT NULL, -- Сколько прошлых версий вскрыто
+    unsealed_by VARCHAR(50) NOT NULL, -- Пользователь или фоновый процесс
+    reason TEXT NOT NULL,
+    unsealed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_bid_unseal_events_tender ON bid_unseal_events (tender_id, unsealed_at);
+
+-- sealed_payload попадает в историю через save_bid_version, определенную в 017
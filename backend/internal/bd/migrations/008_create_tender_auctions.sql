@@ -0,0 +1,27 @@
+-- Реверсивный аукцион: поставщики снижают цену в реальном времени
+CREATE TABLE IF NOT EXISTS tender_auctions (
+    tender_id UUID PRIMARY KEY REFERENCES tenders(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    duration_seconds INTEGER NOT NULL CHECK (duration_seconds > 0),
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Окончание с учетом продлений
+    currency CHAR(3) NOT NULL,
+    start_price BIGINT CHECK (start_price > 0), -- Начальная (максимальная) цена в минимальных единицах валюты
+    min_decrement BIGINT NOT NULL CHECK (min_decrement > 0), -- Минимальный шаг снижения
+    extension_window_seconds INTEGER NOT NULL DEFAULT 0 CHECK (extension_window_seconds >= 0), -- Последние секунды, в которые ставка продлевает аукцион
+    extension_seconds INTEGER NOT NULL DEFAULT 0 CHECK (extension_seconds >= 0), -- На сколько продлевается аукцион от момента ставки
+    extensions INTEGER NOT NULL DEFAULT 0, -- Сколько раз аукцион продлевался
+    best_amount BIGINT, -- Лучшая (наименьшая) ставка
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS auction_bids (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender_auctions(tender_id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    placed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Лучшая ставка каждого участника для рейтинга
+CREATE INDEX IF NOT EXISTS idx_auction_bids_ranking ON auction_bids (tender_id, author_id, amount, placed_at);
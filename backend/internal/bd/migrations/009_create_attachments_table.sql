@@ -0,0 +1,18 @@
+-- Вложения тендеров и предложений; содержимое хранится во внешнем хранилище по storage_key
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE,
+    bid_id UUID REFERENCES bid(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL, -- Имя файла, переданное клиентом
+    content_type VARCHAR(255) NOT NULL, -- Тип, определенный по содержимому
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    sha256 CHAR(64) NOT NULL, -- Hex-дайджест содержимого
+    storage_key TEXT NOT NULL UNIQUE,
+    uploaded_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Вложение принадлежит ровно одному тендеру или одному предложению
+    CHECK ((tender_id IS NULL) <> (bid_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_tender ON attachments (tender_id, created_at) WHERE tender_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_attachments_bid ON attachments (bid_id, created_at) WHERE bid_id IS NOT NULL;
@@ -0,0 +1,97 @@
+-- Справочник типов услуг: иерархия через parent_code, подписи по языкам в labels ({"ru": ..., "en": ...})
+CREATE TABLE IF NOT EXISTS service_types (
+    code VARCHAR(100) PRIMARY KEY CHECK (code ~ '^[A-Z][A-Z0-9_]*$'),
+    parent_code VARCHAR(100) REFERENCES service_types(code),
+    labels JSONB NOT NULL DEFAULT '{}'::jsonb,
+    active BOOLEAN NOT NULL DEFAULT TRUE, -- Неактивный тип нельзя указать в новом или измененном тендере
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (parent_code IS NULL OR parent_code <> code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_types_parent ON service_types (parent_code);
+
+-- Произвольные значения, которые принимаются вместо кода; хранятся в нижнем регистре без крайних пробелов
+CREATE TABLE IF NOT EXISTS service_type_aliases (
+    alias VARCHAR(100) PRIMARY KEY,
+    code VARCHAR(100) NOT NULL REFERENCES service_types(code) ON DELETE CASCADE
+);
+
+-- Исходные значения тендеров, которые не удалось сопоставить с кодом, для ручного разбора
+CREATE TABLE IF NOT EXISTS service_type_legacy_values (
+    tender_id UUID NOT NULL,
+    version INT NOT NULL,
+    original_value VARCHAR(100) NOT NULL,
+    mapped_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tender_id, version)
+);
+
+-- Справочник заполняется и свободный текст переводится в коды один раз — пока у tenders
+-- нет внешнего ключа на справочник. Миграции выполняются при каждом старте, и повторная
+-- вставка вернула бы типы и синонимы, удаленные администратором.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_tenders_service_type' AND conrelid = 'tenders'::regclass) THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO service_types (code, labels) VALUES
+        ('CONSTRUCTION', '{"ru": "Строительство", "en": "Construction"}'),
+        ('DELIVERY', '{"ru": "Доставка", "en": "Delivery"}'),
+        ('MANUFACTURE', '{"ru": "Производство", "en": "Manufacture"}'),
+        ('OTHER', '{"ru": "Прочее", "en": "Other"}')
+    ON CONFLICT (code) DO NOTHING;
+
+    INSERT INTO service_type_aliases (alias, code) VALUES
+        ('construction', 'CONSTRUCTION'),
+        ('строительство', 'CONSTRUCTION'),
+        ('delivery', 'DELIVERY'),
+        ('доставка', 'DELIVERY'),
+        ('manufacture', 'MANUFACTURE'),
+        ('manufacturing', 'MANUFACTURE'),
+        ('производство', 'MANUFACTURE'),
+        ('other', 'OTHER'),
+        ('прочее', 'OTHER')
+    ON CONFLICT (alias) DO NOTHING;
+
+    -- Перенос не должен создавать новых версий. Как и в 018, триггер выключается внутри
+    -- транзакции миграции: для этого достаточно владеть таблицей, а блокировка ALTER TABLE
+    -- держит записи других экземпляров сервиса до фиксации, и мимо истории они не пройдут
+    ALTER TABLE tenders DISABLE TRIGGER trigger_save_tender_version;
+
+    UPDATE tenders t SET service_type = a.code
+    FROM service_type_aliases a
+    WHERE a.alias = lower(btrim(t.service_type)) AND t.service_type <> a.code;
+
+    INSERT INTO service_type_legacy_values (tender_id, version, original_value)
+    SELECT t.id, COALESCE(t.version, 1), t.service_type FROM tenders t
+    WHERE NOT EXISTS (SELECT 1 FROM service_types st WHERE st.code = t.service_type)
+    ON CONFLICT DO NOTHING;
+
+    UPDATE tenders t SET service_type = 'OTHER'
+    WHERE NOT EXISTS (SELECT 1 FROM service_types st WHERE st.code = t.service_type);
+
+    ALTER TABLE tenders ENABLE TRIGGER trigger_save_tender_version;
+
+    -- Старые версии переводятся так же, чтобы откат не возвращал свободный текст
+    UPDATE tender_versions v SET service_type = a.code
+    FROM service_type_aliases a
+    WHERE a.alias = lower(btrim(v.service_type)) AND v.service_type <> a.code;
+
+    INSERT INTO service_type_legacy_values (tender_id, version, original_value)
+    SELECT v.tender_id, v.version, v.service_type FROM tender_versions v
+    WHERE NOT EXISTS (SELECT 1 FROM service_types st WHERE st.code = v.service_type)
+    ON CONFLICT DO NOTHING;
+
+    UPDATE tender_versions v SET service_type = 'OTHER'
+    WHERE NOT EXISTS (SELECT 1 FROM service_types st WHERE st.code = v.service_type);
+
+    ALTER TABLE tenders ADD CONSTRAINT fk_tenders_service_type
+        FOREIGN KEY (service_type) REFERENCES service_types(code);
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_tender_versions_service_type' AND conrelid = 'tender_versions'::regclass) THEN
+        ALTER TABLE tender_versions ADD CONSTRAINT fk_tender_versions_service_type
+            FOREIGN KEY (service_type) REFERENCES service_types(code);
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_tenders_service_type ON tenders (service_type);
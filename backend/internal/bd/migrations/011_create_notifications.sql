@@ -0,0 +1,23 @@
+-- Уведомления сотрудников о событиях тендеров и предложений
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recipient_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL, -- Тип события (domain.Notification*)
+    tender_id UUID REFERENCES tenders(id) ON DELETE CASCADE,
+    bid_id UUID REFERENCES bid(id) ON DELETE CASCADE,
+    data JSONB NOT NULL DEFAULT '{}'::jsonb, -- Подробности события: название тендера, статусы
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient ON notifications (recipient_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications (recipient_id, type) WHERE read_at IS NULL;
+
+-- Отключенные типы уведомлений; отсутствие строки означает, что тип включен
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (employee_id, type)
+);
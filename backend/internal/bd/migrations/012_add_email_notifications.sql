@@ -0,0 +1,22 @@
+-- Адрес и настройки почтовых уведомлений сотрудника
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS email VARCHAR(254);
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS language VARCHAR(8) NOT NULL DEFAULT 'ru'; -- Язык писем
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS email_notifications BOOLEAN NOT NULL DEFAULT TRUE; -- false — отказ от писем
+
+-- Очередь писем: текст формируется при постановке в очередь, отправкой занимается фоновый обработчик
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    notification_id UUID REFERENCES notifications(id) ON DELETE SET NULL,
+    recipient_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    to_address VARCHAR(254) NOT NULL,
+    subject TEXT NOT NULL,
+    html_body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_outbox_due ON email_outbox (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,29 @@
+-- Взвешенные критерии оценки предложений по тендеру
+CREATE TABLE IF NOT EXISTS tender_criteria (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tenders(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL, -- Порядок вывода критериев
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    weight DOUBLE PRECISION NOT NULL CHECK (weight > 0), -- Относительный вес, нормируется по сумме весов тендера
+    scale_min INTEGER NOT NULL,
+    scale_max INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (scale_max > scale_min),
+    UNIQUE (tender_id, position)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tender_criteria_name ON tender_criteria (tender_id, lower(name));
+
+-- Оценки предложений ответственными за организацию тендера: одна оценка на критерий от каждого
+CREATE TABLE IF NOT EXISTS bid_scores (
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    criterion_id UUID NOT NULL REFERENCES tender_criteria(id) ON DELETE CASCADE,
+    evaluator_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    score INTEGER NOT NULL,
+    comment VARCHAR(1000) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (bid_id, criterion_id, evaluator_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bid_scores_criterion ON bid_scores (criterion_id);
@@ -0,0 +1,26 @@
+-- Вопросы участников по условиям опубликованного тендера и ответы организации
+CREATE TABLE IF NOT EXISTS tender_questions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tenders(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    text VARCHAR(2000) NOT NULL, -- Публикуемый текст вопроса
+    original_text VARCHAR(2000), -- Исходный текст, если модератор его отредактировал
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ANSWERED', 'REJECTED')),
+    answer TEXT,
+    answered_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    answered_at TIMESTAMP WITH TIME ZONE,
+    amended_version INTEGER, -- Версия тендера, созданная вместе с ответом
+    rejection_reason VARCHAR(500),
+    moderated_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    moderated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tender_questions_tender ON tender_questions (tender_id, status, created_at);
+
+-- Срок приема вопросов хранится отдельно: любое изменение tenders создает новую версию тендера
+CREATE TABLE IF NOT EXISTS tender_question_settings (
+    tender_id UUID PRIMARY KEY REFERENCES tenders(id) ON DELETE CASCADE,
+    deadline TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,13 @@
+-- Отзыв и повторная подача предложения автором: действие и причина хранятся в строке,
+-- получившейся в результате изменения, и вместе с ней переходят в bid_versions
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS change_action VARCHAR(20) CHECK (change_action IN ('withdraw', 'resubmit'));
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS change_reason TEXT;
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS changed_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS change_action VARCHAR(20);
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS change_reason TEXT;
+ALTER TABLE bid_versions ADD COLUMN IF NOT EXISTS changed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_bid_versions_withdrawals ON bid_versions (tender_id) WHERE change_action IS NOT NULL;
+
+-- Действие и причина попадают в историю через save_bid_version, определенную в 017
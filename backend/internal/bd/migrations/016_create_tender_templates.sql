@@ -0,0 +1,17 @@
+-- Шаблоны тендеров организации. Название и описание могут содержать плейсхолдеры {{имя}},
+-- которые заполняются при создании тендера по шаблону
+CREATE TABLE IF NOT EXISTS tender_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL, -- Название шаблона в списке
+    name VARCHAR(255) NOT NULL, -- Название создаваемого тендера
+    description TEXT NOT NULL DEFAULT '',
+    service_type VARCHAR(100) NOT NULL REFERENCES service_types(code),
+    sealed BOOLEAN NOT NULL DEFAULT FALSE,
+    criteria JSONB NOT NULL DEFAULT '[]', -- Критерии оценки (domain.CriterionSpec)
+    created_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tender_templates_title ON tender_templates (organization_id, lower(title));
@@ -0,0 +1,78 @@
+-- Архивирование (мягкое удаление) тендеров и предложений. Архивные строки не попадают
+-- в списки и не доступны по ID; окончательно их удаляет только администратор
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tenders ADD COLUMN IF NOT EXISTS deleted_by VARCHAR(50);
+
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS deleted_by VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_tenders_archived ON tenders (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_bid_archived ON bid (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Удаление сотрудника или организации больше не стирает тендеры, предложения и их историю,
+-- а удаление тендера или предложения — их версии: такие строки удаляются только явно
+DO $$
+DECLARE
+    fk RECORD;
+BEGIN
+    FOR fk IN
+        SELECT c.conname, c.conrelid::regclass AS tbl, pg_get_constraintdef(c.oid) AS def
+        FROM pg_constraint c
+        WHERE c.contype = 'f' AND c.confdeltype = 'c'
+          AND (c.conrelid::regclass::text, c.confrelid::regclass::text) IN (
+              ('tenders', 'organization'), ('tenders', 'employee'), ('tender_versions', 'tenders'),
+              ('bid', 'tenders'), ('bid', 'employee'), ('bid_versions', 'bid'),
+              ('auction_bids', 'employee'), ('bid_scores', 'employee'), ('tender_questions', 'employee'))
+    LOOP
+        EXECUTE format('ALTER TABLE %s DROP CONSTRAINT %I, ADD CONSTRAINT %I %s',
+                       fk.tbl, fk.conname, fk.conname, replace(fk.def, 'ON DELETE CASCADE', 'ON DELETE RESTRICT'));
+    END LOOP;
+END;
+$$;
+
+-- Единственное определение функций истории. 002 и 003 создают только начальные версии
+-- в новой базе, так что при каждом старте функции заменяются на те же самые. Новые
+-- колонки версий добавляются в эти определения, а не в новые миграции
+CREATE OR REPLACE FUNCTION save_tender_version() RETURNS TRIGGER AS $$
+BEGIN
+    -- Архивирование и восстановление не меняют тендер по существу и новую версию не создают
+    IF NEW.deleted_at IS DISTINCT FROM OLD.deleted_at THEN
+        RETURN NEW;
+    END IF;
+    -- Сохранение текущей версии тендера в таблицу tender_versions перед обновлением
+    INSERT INTO tender_versions (tender_id, name, description, status, organization_id, creator_username, service_type, version, created_at, submission_deadline, change_reason)
+    SELECT OLD.id, OLD.name, OLD.description, OLD.status, OLD.organization_id, OLD.creator_username, OLD.service_type, OLD.version, OLD.created_at, OLD.submission_deadline, OLD.change_reason;
+    -- Причина относится только к тому изменению, в котором ее указали
+    IF NEW.change_reason IS NOT DISTINCT FROM OLD.change_reason THEN
+        NEW.change_reason := NULL;
+    END IF;
+    -- Увеличиваем версию на 1 при каждом обновлении
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION save_bid_version() RETURNS TRIGGER AS $$
+BEGIN
+    -- Вскрытие, архивирование и восстановление не меняют предложение по существу и новую версию не создают
+    IF (OLD.sealed_payload IS NOT NULL AND NEW.sealed_payload IS NULL) OR NEW.deleted_at IS DISTINCT FROM OLD.deleted_at THEN
+        RETURN NEW;
+    END IF;
+    -- Сохранение текущей версии предложения в таблицу bid_versions перед обновлением
+    INSERT INTO bid_versions (bid_id, name, description, status, tender_id, author_type, author_id, version, created_at,
+                              amount, currency, delivery_days, valid_from, valid_until, sealed_payload,
+                              change_action, change_reason, changed_at)
+    SELECT OLD.id, OLD.name, OLD.description, OLD.status, OLD.tender_id, OLD.author_type, OLD.author_id, OLD.version, OLD.created_at,
+           OLD.amount, OLD.currency, OLD.delivery_days, OLD.valid_from, OLD.valid_until, OLD.sealed_payload,
+           OLD.change_action, OLD.change_reason, OLD.changed_at;
+    -- Действие относится только к тому изменению, в котором его указали
+    IF NEW.change_action IS NOT DISTINCT FROM OLD.change_action AND NEW.change_reason IS NOT DISTINCT FROM OLD.change_reason THEN
+        NEW.change_action := NULL;
+        NEW.change_reason := NULL;
+        NEW.changed_at := NULL;
+    END IF;
+    -- Увеличиваем версию на 1 при каждом обновлении
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
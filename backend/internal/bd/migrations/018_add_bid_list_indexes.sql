@@ -0,0 +1,17 @@
+-- Постраничная выдача предложений по тендеру и по автору: курсор — (created_at, id)
+-- последнего предложения страницы, следующая страница читается по индексу с этого места
+DO $$
+BEGIN
+    -- Строку без created_at курсор не может ни пропустить, ни выдать; история версий
+    -- при этом исправлении не пишется
+    IF EXISTS (SELECT 1 FROM bid WHERE created_at IS NULL) THEN
+        ALTER TABLE bid DISABLE TRIGGER trigger_save_bid_version;
+        UPDATE bid SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+        ALTER TABLE bid ENABLE TRIGGER trigger_save_bid_version;
+    END IF;
+END $$;
+
+ALTER TABLE bid ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_bid_tender_created ON bid (tender_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_bid_author_created ON bid (author_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
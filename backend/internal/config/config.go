@@ -1,41 +1,280 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
+	"tender_srevice/internal/logger"
 	_ "github.com/lib/pq"
 	"database/sql"
 )
 
+// Config собирается из YAML-файла, переменных окружения и флагов командной строки
+// (каждый следующий источник переопределяет предыдущий). Описание всех ключей — в fields().
 type Config struct {
 	ServerAddress string
 	PostgresConn  string
+	LogLevel      slog.Level
+
+	Server      ServerConfig
+	DB          DBConfig
+	CORS        CORSConfig
+	RateLimit   RateLimitConfig
+	Features    FeatureConfig
+	Admin       AdminConfig
+	Scheduler   SchedulerConfig
+	Bids        BidsConfig
+	Attachments AttachmentsConfig
+	Mail        MailConfig
+	GRPC        GRPCConfig
+	GraphQL     GraphQLConfig
 }
 
-func Load() (*Config, error) {
-	serverAddr := os.Getenv("SERVER_ADDRESS")
-	if serverAddr == "" {
-		return nil, fmt.Errorf("SERVER_ADDRESS environment variable is not set")
-	}
+type ServerConfig struct {
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+type DBConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// CORSConfig — пустой список AllowedOrigins отключает CORS-заголовки.
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// RateLimitConfig — Store: "memory" (в процессе) или "postgres" (общие лимиты для всех реплик).
+type RateLimitConfig struct {
+	Enabled           bool
+	Store             string
+	RequestsPerSecond float64
+	Burst             int
+	MutationRPS       float64
+	MutationBurst     int
+	TrustForwardedFor bool
+}
 
-	postgresConn := os.Getenv("POSTGRES_CONN")
-	if postgresConn == "" {
-		return nil, fmt.Errorf("POSTGRES_CONN environment variable is not set")
+// SchedulerConfig — фоновое закрытие тендеров по сроку подачи предложений.
+type SchedulerConfig struct {
+	Enabled          bool
+	DeadlineInterval time.Duration
+}
+
+// BidsConfig — Currencies перечисляет допустимые коды валют ISO 4217 для цены предложения.
+// SealingKey — ключ AES-256 в base64 для запечатанных тендеров; пустой ключ запрещает их создание.
+type BidsConfig struct {
+	Currencies []string
+	SealingKey string
+}
+
+// SealingKeyBytes декодирует SealingKey; для пустого ключа возвращает nil.
+func (c BidsConfig) SealingKeyBytes() ([]byte, error) {
+	if c.SealingKey == "" {
+		return nil, nil
 	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(c.SealingKey))
+}
+
+// AttachmentsConfig — хранилище вложений тендеров и предложений: Storage "local" или "s3".
+// AllowedTypes сверяется с типом, определенным по содержимому файла, а не с заявленным клиентом.
+type AttachmentsConfig struct {
+	Storage      string
+	MaxSize      int
+	AllowedTypes []string
+	LocalDir     string
+	S3           S3Config
+}
+
+// S3Config — S3-совместимое хранилище (AWS S3, MinIO); адресация path-style.
+type S3Config struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+// GRPCConfig — отдельный gRPC-listener с операциями тендеров и предложений.
+// Reflection позволяет grpcurl и подобным клиентам обходиться без .proto-файлов.
+type GRPCConfig struct {
+	Enabled    bool
+	Address    string
+	Reflection bool
+}
+
+// GraphQLConfig — MaxComplexity ограничивает оценку сложности запроса: каждое поле
+// стоит 1, поля-списки умножают стоимость вложенных полей.
+type GraphQLConfig struct {
+	Enabled       bool
+	MaxComplexity int
+}
+
+// MailConfig — письма по уведомлениям. Без Enabled уведомления только сохраняются во входящих.
+type MailConfig struct {
+	Enabled bool
+	From    string
+	SMTP    SMTPConfig
+	Queue   MailQueueConfig
+}
+
+// SMTPConfig — TLS: "none", "starttls" или "tls" (TLS с первого байта, обычно порт 465).
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	TLS      string
+	Timeout  time.Duration
+}
+
+// MailQueueConfig — MaxAttempts включает первую попытку; паузы между попытками
+// начинаются с RetryBackoff и удваиваются.
+type MailQueueConfig struct {
+	Interval     time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// AdminConfig — пустой Token отключает административные эндпоинты.
+type AdminConfig struct {
+	Token string
+}
+
+type FeatureConfig struct {
+	OpenAPIValidation bool
+	APIDocs           bool
+}
 
-	cfg := &Config{
-		ServerAddress: serverAddr,
-		PostgresConn:  postgresConn,
+// Default возвращает конфигурацию со значениями по умолчанию.
+func Default() *Config {
+	return &Config{
+		ServerAddress: "0.0.0.0:8080",
+		LogLevel:      slog.LevelInfo,
+		Server: ServerConfig{
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 10 * time.Second,
+		},
+		DB: DBConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		CORS: CORSConfig{
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowedHeaders: []string{"Content-Type", "X-Request-ID"},
+			MaxAge:         10 * time.Minute,
+		},
+		RateLimit: RateLimitConfig{
+			Store:             "memory",
+			RequestsPerSecond: 10,
+			Burst:             20,
+			MutationRPS:       2,
+			MutationBurst:     5,
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:          true,
+			DeadlineInterval: time.Minute,
+		},
+		Bids: BidsConfig{
+			Currencies: []string{"RUB", "USD", "EUR"},
+		},
+		Attachments: AttachmentsConfig{
+			Storage: "local",
+			MaxSize: 20 << 20,
+			AllowedTypes: []string{
+				"application/pdf", "image/png", "image/jpeg", "image/gif", "image/webp",
+				"application/zip", "text/plain", "application/octet-stream",
+			},
+			LocalDir: "data/attachments",
+			S3:       S3Config{Region: "us-east-1"},
+		},
+		Mail: MailConfig{
+			From: "Tender Service <noreply@localhost>",
+			SMTP: SMTPConfig{
+				Host:    "localhost",
+				Port:    587,
+				TLS:     "starttls",
+				Timeout: 30 * time.Second,
+			},
+			Queue: MailQueueConfig{
+				Interval:     10 * time.Second,
+				BatchSize:    50,
+				MaxAttempts:  8,
+				RetryBackoff: time.Minute,
+			},
+		},
+		GRPC: GRPCConfig{
+			Address: "0.0.0.0:9090",
+		},
+		GraphQL: GraphQLConfig{
+			Enabled:       true,
+			MaxComplexity: 1000,
+		},
+		Features: FeatureConfig{
+			OpenAPIValidation: true,
+			APIDocs:           true,
+		},
 	}
+}
 
-	logDatabaseConnection(cfg)
+// Load собирает конфигурацию из файла (путь в --config или CONFIG_FILE),
+// переменных окружения и аргументов командной строки и проверяет ее.
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+	fields := cfg.fields()
 
+	flags, configPath, err := parseFlags(fields, args)
+	if err != nil {
+		return nil, err
+	}
+	if configPath == "" {
+		configPath = os.Getenv("CONFIG_FILE")
+	}
+	if configPath != "" {
+		if err := applyFile(fields, configPath); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyEnv(fields); err != nil {
+		return nil, err
+	}
+	if err := applyFlags(fields, flags); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
+// ApplyDBPool переносит настройки пула соединений на *sql.DB.
+func (c *Config) ApplyDBPool(db *sql.DB) {
+	db.SetMaxOpenConns(c.DB.MaxOpenConns)
+	db.SetMaxIdleConns(c.DB.MaxIdleConns)
+	db.SetConnMaxLifetime(c.DB.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.DB.ConnMaxIdleTime)
+}
+
 func RunMigrations(cfg *Config) error {
 	db, err := sql.Open("postgres", cfg.PostgresConn)
 	if err != nil {
@@ -65,12 +304,24 @@ func RunMigrations(cfg *Config) error {
 			return fmt.Errorf("failed to execute migration %s: %v", file, err)
 		}
 
-		log.Printf("Executed migration: %s", file)
+		slog.Info("executed migration", slog.String("file", filepath.Base(file)))
 	}
 
 	return nil
 }
 
-func logDatabaseConnection(cfg *Config) {
-	log.Printf("Подключение к базе данных установлено. Строка подключения: %s", cfg)
+// LogValue выводит все настройки, маскируя секреты.
+func (c *Config) LogValue() slog.Value {
+	var attrs []slog.Attr
+	for _, f := range c.fields() {
+		v := f.value.String()
+		if f.secret {
+			v = logger.Redact(v)
+			if !strings.Contains(v, "[REDACTED]") && v != "" {
+				v = "[REDACTED]"
+			}
+		}
+		attrs = append(attrs, slog.String(f.key, v))
+	}
+	return slog.GroupValue(attrs...)
 }
@@ -0,0 +1,304 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"tender_srevice/internal/logger"
+
+	"gopkg.in/yaml.v3"
+)
+
+// field связывает ключ YAML, переменную окружения и флаг с полем Config.
+// Имя флага получается из ключа: "db.max_open_conns" -> --db-max-open-conns.
+type field struct {
+	key    string
+	env    string
+	usage  string
+	secret bool
+	value  flag.Value
+}
+
+func (f field) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(f.key)
+}
+
+func (c *Config) fields() []field {
+	return []field{
+		{key: "server.address", env: "SERVER_ADDRESS", usage: "HTTP listen address", value: (*stringValue)(&c.ServerAddress)},
+		{key: "server.read_timeout", env: "SERVER_READ_TIMEOUT", usage: "HTTP read timeout", value: (*durationValue)(&c.Server.ReadTimeout)},
+		{key: "server.write_timeout", env: "SERVER_WRITE_TIMEOUT", usage: "HTTP write timeout", value: (*durationValue)(&c.Server.WriteTimeout)},
+		{key: "server.idle_timeout", env: "SERVER_IDLE_TIMEOUT", usage: "HTTP keep-alive idle timeout", value: (*durationValue)(&c.Server.IdleTimeout)},
+		{key: "server.shutdown_timeout", env: "SERVER_SHUTDOWN_TIMEOUT", usage: "graceful shutdown timeout", value: (*durationValue)(&c.Server.ShutdownTimeout)},
+
+		{key: "postgres.conn", env: "POSTGRES_CONN", usage: "PostgreSQL connection string", secret: true, value: (*stringValue)(&c.PostgresConn)},
+		{key: "postgres.max_open_conns", env: "POSTGRES_MAX_OPEN_CONNS", usage: "maximum open connections", value: (*intValue)(&c.DB.MaxOpenConns)},
+		{key: "postgres.max_idle_conns", env: "POSTGRES_MAX_IDLE_CONNS", usage: "maximum idle connections", value: (*intValue)(&c.DB.MaxIdleConns)},
+		{key: "postgres.conn_max_lifetime", env: "POSTGRES_CONN_MAX_LIFETIME", usage: "maximum connection lifetime", value: (*durationValue)(&c.DB.ConnMaxLifetime)},
+		{key: "postgres.conn_max_idle_time", env: "POSTGRES_CONN_MAX_IDLE_TIME", usage: "maximum connection idle time", value: (*durationValue)(&c.DB.ConnMaxIdleTime)},
+
+		{key: "log.level", env: "LOG_LEVEL", usage: "log level: debug, info, warn, error", value: (*levelValue)(&c.LogLevel)},
+
+		{key: "cors.allowed_origins", env: "CORS_ALLOWED_ORIGINS", usage: "comma-separated allowed origins, * for any", value: (*stringsValue)(&c.CORS.AllowedOrigins)},
+		{key: "cors.allowed_methods", env: "CORS_ALLOWED_METHODS", usage: "comma-separated allowed methods", value: (*stringsValue)(&c.CORS.AllowedMethods)},
+		{key: "cors.allowed_headers", env: "CORS_ALLOWED_HEADERS", usage: "comma-separated allowed request headers", value: (*stringsValue)(&c.CORS.AllowedHeaders)},
+		{key: "cors.allow_credentials", env: "CORS_ALLOW_CREDENTIALS", usage: "allow credentials in CORS requests", value: (*boolValue)(&c.CORS.AllowCredentials)},
+		{key: "cors.max_age", env: "CORS_MAX_AGE", usage: "preflight cache duration", value: (*durationValue)(&c.CORS.MaxAge)},
+
+		{key: "rate_limit.enabled", env: "RATE_LIMIT_ENABLED", usage: "enable request rate limiting", value: (*boolValue)(&c.RateLimit.Enabled)},
+		{key: "rate_limit.store", env: "RATE_LIMIT_STORE", usage: "rate limiter state: memory or postgres", value: (*stringValue)(&c.RateLimit.Store)},
+		{key: "rate_limit.rps", env: "RATE_LIMIT_RPS", usage: "sustained requests per second", value: (*floatValue)(&c.RateLimit.RequestsPerSecond)},
+		{key: "rate_limit.burst", env: "RATE_LIMIT_BURST", usage: "request burst size", value: (*intValue)(&c.RateLimit.Burst)},
+		{key: "rate_limit.mutation_rps", env: "RATE_LIMIT_MUTATION_RPS", usage: "sustained write requests per second", value: (*floatValue)(&c.RateLimit.MutationRPS)},
+		{key: "rate_limit.mutation_burst", env: "RATE_LIMIT_MUTATION_BURST", usage: "write request burst size", value: (*intValue)(&c.RateLimit.MutationBurst)},
+		{key: "rate_limit.trust_forwarded_for", env: "RATE_LIMIT_TRUST_FORWARDED_FOR", usage: "take client IP from X-Forwarded-For (only behind a trusted proxy)", value: (*boolValue)(&c.RateLimit.TrustForwardedFor)},
+
+		{key: "scheduler.enabled", env: "SCHEDULER_ENABLED", usage: "close tenders automatically when the submission deadline passes", value: (*boolValue)(&c.Scheduler.Enabled)},
+		{key: "scheduler.deadline_interval", env: "SCHEDULER_DEADLINE_INTERVAL", usage: "how often to look for expired tenders", value: (*durationValue)(&c.Scheduler.DeadlineInterval)},
+
+		{key: "bids.currencies", env: "BIDS_CURRENCIES", usage: "comma-separated ISO 4217 currency codes accepted in bids", value: (*stringsValue)(&c.Bids.Currencies)},
+		{key: "bids.sealing_key", env: "BIDS_SEALING_KEY", usage: "base64 AES-256 key encrypting sealed bids until the tender closes", secret: true, value: (*stringValue)(&c.Bids.SealingKey)},
+
+		{key: "attachments.storage", env: "ATTACHMENTS_STORAGE", usage: "attachment storage backend: local or s3", value: (*stringValue)(&c.Attachments.Storage)},
+		{key: "attachments.max_size", env: "ATTACHMENTS_MAX_SIZE", usage: "maximum attachment size in bytes", value: (*intValue)(&c.Attachments.MaxSize)},
+		{key: "attachments.allowed_types", env: "ATTACHMENTS_ALLOWED_TYPES", usage: "comma-separated MIME types accepted after content sniffing", value: (*stringsValue)(&c.Attachments.AllowedTypes)},
+		{key: "attachments.local_dir", env: "ATTACHMENTS_LOCAL_DIR", usage: "directory for the local attachment storage", value: (*stringValue)(&c.Attachments.LocalDir)},
+		{key: "attachments.s3.endpoint", env: "ATTACHMENTS_S3_ENDPOINT", usage: "S3-compatible endpoint URL, e.g. http://localhost:9000", value: (*stringValue)(&c.Attachments.S3.Endpoint)},
+		{key: "attachments.s3.bucket", env: "ATTACHMENTS_S3_BUCKET", usage: "S3 bucket for attachments", value: (*stringValue)(&c.Attachments.S3.Bucket)},
+		{key: "attachments.s3.region", env: "ATTACHMENTS_S3_REGION", usage: "S3 region used for request signing", value: (*stringValue)(&c.Attachments.S3.Region)},
+		{key: "attachments.s3.access_key", env: "ATTACHMENTS_S3_ACCESS_KEY", usage: "S3 access key", secret: true, value: (*stringValue)(&c.Attachments.S3.AccessKey)},
+		{key: "attachments.s3.secret_key", env: "ATTACHMENTS_S3_SECRET_KEY", usage: "S3 secret key", secret: true, value: (*stringValue)(&c.Attachments.S3.SecretKey)},
+
+		{key: "mail.enabled", env: "MAIL_ENABLED", usage: "send notification emails through SMTP", value: (*boolValue)(&c.Mail.Enabled)},
+		{key: "mail.from", env: "MAIL_FROM", usage: "sender address, e.g. \"Tenders <noreply@example.com>\"", value: (*stringValue)(&c.Mail.From)},
+		{key: "mail.smtp.host", env: "MAIL_SMTP_HOST", usage: "SMTP server host", value: (*stringValue)(&c.Mail.SMTP.Host)},
+		{key: "mail.smtp.port", env: "MAIL_SMTP_PORT", usage: "SMTP server port", value: (*intValue)(&c.Mail.SMTP.Port)},
+		{key: "mail.smtp.username", env: "MAIL_SMTP_USERNAME", usage: "SMTP login, empty for no authentication", value: (*stringValue)(&c.Mail.SMTP.Username)},
+		{key: "mail.smtp.password", env: "MAIL_SMTP_PASSWORD", usage: "SMTP password", secret: true, value: (*stringValue)(&c.Mail.SMTP.Password)},
+		{key: "mail.smtp.tls", env: "MAIL_SMTP_TLS", usage: "connection security: none, starttls or tls", value: (*stringValue)(&c.Mail.SMTP.TLS)},
+		{key: "mail.smtp.timeout", env: "MAIL_SMTP_TIMEOUT", usage: "timeout of one SMTP delivery", value: (*durationValue)(&c.Mail.SMTP.Timeout)},
+		{key: "mail.queue.interval", env: "MAIL_QUEUE_INTERVAL", usage: "how often to poll the email queue", value: (*durationValue)(&c.Mail.Queue.Interval)},
+		{key: "mail.queue.batch_size", env: "MAIL_QUEUE_BATCH_SIZE", usage: "emails sent per poll", value: (*intValue)(&c.Mail.Queue.BatchSize)},
+		{key: "mail.queue.max_attempts", env: "MAIL_QUEUE_MAX_ATTEMPTS", usage: "delivery attempts before an email is marked failed", value: (*intValue)(&c.Mail.Queue.MaxAttempts)},
+		{key: "mail.queue.retry_backoff", env: "MAIL_QUEUE_RETRY_BACKOFF", usage: "delay before the first retry, doubled on each next one", value: (*durationValue)(&c.Mail.Queue.RetryBackoff)},
+
+		{key: "grpc.enabled", env: "GRPC_ENABLED", usage: "serve the gRPC API on a separate listener", value: (*boolValue)(&c.GRPC.Enabled)},
+		{key: "grpc.address", env: "GRPC_ADDRESS", usage: "gRPC listen address", value: (*stringValue)(&c.GRPC.Address)},
+		{key: "grpc.reflection", env: "GRPC_REFLECTION", usage: "enable the gRPC server reflection service", value: (*boolValue)(&c.GRPC.Reflection)},
+
+		{key: "graphql.enabled", env: "GRAPHQL_ENABLED", usage: "serve the GraphQL API at /graphql", value: (*boolValue)(&c.GraphQL.Enabled)},
+		{key: "graphql.max_complexity", env: "GRAPHQL_MAX_COMPLEXITY", usage: "reject GraphQL queries with a higher complexity score", value: (*intValue)(&c.GraphQL.MaxComplexity)},
+
+		{key: "admin.token", env: "ADMIN_TOKEN", usage: "bearer token for /api/admin endpoints", secret: true, value: (*stringValue)(&c.Admin.Token)},
+
+		{key: "features.openapi_validation", env: "FEATURE_OPENAPI_VALIDATION", usage: "validate requests against the OpenAPI spec", value: (*boolValue)(&c.Features.OpenAPIValidation)},
+		{key: "features.api_docs", env: "FEATURE_API_DOCS", usage: "serve /api/docs and /api/openapi.json", value: (*boolValue)(&c.Features.APIDocs)},
+	}
+}
+
+// parseFlags разбирает аргументы, но не применяет их: флаги имеют наивысший
+// приоритет и накладываются после файла и окружения.
+func parseFlags(fields []field, args []string) (map[string]string, string, error) {
+	fs := flag.NewFlagSet("tender_service", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to YAML configuration file")
+	set := map[string]string{}
+	for _, f := range fields {
+		name := f.flagName()
+		record := func(v string) error {
+			set[name] = v
+			return nil
+		}
+		if _, ok := f.value.(*boolValue); ok {
+			fs.BoolFunc(name, f.usage+" (env "+f.env+")", record)
+		} else {
+			fs.Func(name, f.usage+" (env "+f.env+")", record)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, "", err
+	}
+	return set, *configPath, nil
+}
+
+func applyFlags(fields []field, set map[string]string) error {
+	for _, f := range fields {
+		raw, ok := set[f.flagName()]
+		if !ok {
+			continue
+		}
+		if err := f.value.Set(raw); err != nil {
+			return fmt.Errorf("flag --%s: %w", f.flagName(), err)
+		}
+	}
+	return nil
+}
+
+// applyEnv читает VAR и VAR_FILE (путь к файлу с секретом, как в Docker и Kubernetes secrets).
+// Если заданы обе, побеждает VAR.
+func applyEnv(fields []field) error {
+	for _, f := range fields {
+		if path := os.Getenv(f.env + "_FILE"); path != "" {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("%s_FILE: %w", f.env, err)
+			}
+			if err := f.value.Set(strings.TrimRight(string(content), "\r\n")); err != nil {
+				return fmt.Errorf("%s_FILE (%s): %w", f.env, path, err)
+			}
+		}
+		if raw, ok := os.LookupEnv(f.env); ok && raw != "" {
+			if err := f.value.Set(raw); err != nil {
+				return fmt.Errorf("%s: %w", f.env, err)
+			}
+		}
+	}
+	return nil
+}
+
+func applyFile(fields []field, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("%s: invalid YAML: %w", path, err)
+	}
+
+	values := map[string]string{}
+	if err := flatten("", doc, values); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		f, ok := byKey[k]
+		if !ok {
+			return fmt.Errorf("%s: unknown key %q", path, k)
+		}
+		if err := f.value.Set(values[k]); err != nil {
+			return fmt.Errorf("%s: %s: %w", path, k, err)
+		}
+	}
+	return nil
+}
+
+// flatten переводит вложенный YAML в плоские ключи вида "postgres.max_open_conns".
+func flatten(prefix string, node interface{}, out map[string]string) error {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			if err := flatten(key, child, out); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		out[prefix] = strings.Join(items, ",")
+	case nil:
+		out[prefix] = ""
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+	return nil
+}
+
+type stringValue string
+
+func (v *stringValue) Set(s string) error { *v = stringValue(s); return nil }
+func (v *stringValue) String() string     { return string(*v) }
+
+type intValue int
+
+func (v *intValue) Set(s string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", s)
+	}
+	*v = intValue(n)
+	return nil
+}
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+
+type floatValue float64
+
+func (v *floatValue) Set(s string) error {
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	*v = floatValue(n)
+	return nil
+}
+func (v *floatValue) String() string { return strconv.FormatFloat(float64(*v), 'g', -1, 64) }
+
+type boolValue bool
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", s)
+	}
+	*v = boolValue(b)
+	return nil
+}
+func (v *boolValue) String() string { return strconv.FormatBool(bool(*v)) }
+
+type durationValue time.Duration
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q (use values like 500ms, 15s, 5m)", s)
+	}
+	*v = durationValue(d)
+	return nil
+}
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+type stringsValue []string
+
+func (v *stringsValue) Set(s string) error {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*v = items
+	return nil
+}
+func (v *stringsValue) String() string { return strings.Join(*v, ",") }
+
+type levelValue slog.Level
+
+func (v *levelValue) Set(s string) error {
+	level, err := logger.ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	*v = levelValue(level)
+	return nil
+}
+func (v *levelValue) String() string { return slog.Level(*v).String() }
@@ -0,0 +1,230 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+	"tender_srevice/internal/sealing"
+	"time"
+)
+
+// Validate проверяет конфигурацию целиком и возвращает все найденные проблемы сразу.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(key, format string, args ...interface{}) {
+		f := c.field(key)
+		errs = append(errs, fmt.Errorf("%s (env %s, flag --%s): %s", key, f.env, f.flagName(), fmt.Sprintf(format, args...)))
+	}
+
+	if c.ServerAddress == "" {
+		add("server.address", "is required")
+	} else if _, _, err := net.SplitHostPort(c.ServerAddress); err != nil {
+		add("server.address", "must be host:port, got %q", c.ServerAddress)
+	}
+	if c.PostgresConn == "" {
+		add("postgres.conn", "is required")
+	}
+
+	timeouts := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+	}
+	for _, t := range timeouts {
+		if t.value <= 0 {
+			add(t.key, "must be positive")
+		}
+	}
+
+	if c.DB.MaxOpenConns < 0 {
+		add("postgres.max_open_conns", "must not be negative")
+	}
+	if c.DB.MaxIdleConns < 0 {
+		add("postgres.max_idle_conns", "must not be negative")
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		add("postgres.max_idle_conns", "must not exceed postgres.max_open_conns (%d)", c.DB.MaxOpenConns)
+	}
+	if c.DB.ConnMaxLifetime < 0 {
+		add("postgres.conn_max_lifetime", "must not be negative")
+	}
+	if c.DB.ConnMaxIdleTime < 0 {
+		add("postgres.conn_max_idle_time", "must not be negative")
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" && c.CORS.AllowCredentials {
+			add("cors.allowed_origins", "wildcard origin cannot be combined with cors.allow_credentials")
+		}
+		if origin != "*" && !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			add("cors.allowed_origins", "origin %q must start with http:// or https://", origin)
+		}
+	}
+
+	if c.RateLimit.Store != "memory" && c.RateLimit.Store != "postgres" {
+		add("rate_limit.store", "must be memory or postgres, got %q", c.RateLimit.Store)
+	}
+	if c.RateLimit.Enabled {
+		if c.RateLimit.RequestsPerSecond <= 0 {
+			add("rate_limit.rps", "must be positive when rate limiting is enabled")
+		}
+		if c.RateLimit.Burst < 1 {
+			add("rate_limit.burst", "must be at least 1")
+		}
+		if c.RateLimit.MutationRPS <= 0 {
+			add("rate_limit.mutation_rps", "must be positive when rate limiting is enabled")
+		}
+		if c.RateLimit.MutationBurst < 1 {
+			add("rate_limit.mutation_burst", "must be at least 1")
+		}
+	}
+
+	if c.Scheduler.Enabled && c.Scheduler.DeadlineInterval <= 0 {
+		add("scheduler.deadline_interval", "must be positive when the scheduler is enabled")
+	}
+
+	if len(c.Bids.Currencies) == 0 {
+		add("bids.currencies", "must list at least one currency")
+	}
+	for _, code := range c.Bids.Currencies {
+		if !isCurrencyCode(code) {
+			add("bids.currencies", "%q is not a three-letter uppercase ISO 4217 code", code)
+		}
+	}
+
+	if key, err := c.Bids.SealingKeyBytes(); err != nil {
+		add("bids.sealing_key", "must be base64-encoded")
+	} else if key != nil && len(key) != sealing.KeySize {
+		add("bids.sealing_key", "must decode to %d bytes, got %d", sealing.KeySize, len(key))
+	}
+
+	if c.Attachments.MaxSize <= 0 {
+		add("attachments.max_size", "must be positive")
+	}
+	for _, t := range c.Attachments.AllowedTypes {
+		if _, _, err := mime.ParseMediaType(t); err != nil {
+			add("attachments.allowed_types", "%q is not a MIME type", t)
+		}
+	}
+	switch c.Attachments.Storage {
+	case "local":
+		if c.Attachments.LocalDir == "" {
+			add("attachments.local_dir", "is required for the local storage")
+		}
+	case "s3":
+		if u, err := url.Parse(c.Attachments.S3.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("attachments.s3.endpoint", "must be an http:// or https:// URL, got %q", c.Attachments.S3.Endpoint)
+		}
+		required := []struct{ key, value string }{
+			{"attachments.s3.bucket", c.Attachments.S3.Bucket},
+			{"attachments.s3.region", c.Attachments.S3.Region},
+			{"attachments.s3.access_key", c.Attachments.S3.AccessKey},
+			{"attachments.s3.secret_key", c.Attachments.S3.SecretKey},
+		}
+		for _, r := range required {
+			if r.value == "" {
+				add(r.key, "is required for the s3 storage")
+			}
+		}
+	default:
+		add("attachments.storage", "must be local or s3, got %q", c.Attachments.Storage)
+	}
+
+	if c.Mail.Enabled {
+		if addr, err := mail.ParseAddress(c.Mail.From); err != nil || !strings.Contains(addr.Address, "@") {
+			add("mail.from", "must be an email address, got %q", c.Mail.From)
+		}
+		if c.Mail.SMTP.Host == "" {
+			add("mail.smtp.host", "is required when mail is enabled")
+		}
+		if c.Mail.SMTP.Port < 1 || c.Mail.SMTP.Port > 65535 {
+			add("mail.smtp.port", "must be between 1 and 65535")
+		}
+		switch c.Mail.SMTP.TLS {
+		case "none", "starttls", "tls":
+			// net/smtp не передает пароль по открытому соединению, кроме как на localhost
+			if c.Mail.SMTP.TLS == "none" && c.Mail.SMTP.Username != "" && !isLoopbackHost(c.Mail.SMTP.Host) {
+				add("mail.smtp.username", "authentication requires mail.smtp.tls starttls or tls for a remote server")
+			}
+		default:
+			add("mail.smtp.tls", "must be none, starttls or tls, got %q", c.Mail.SMTP.TLS)
+		}
+		if c.Mail.SMTP.Timeout <= 0 {
+			add("mail.smtp.timeout", "must be positive")
+		}
+		if c.Mail.Queue.Interval <= 0 {
+			add("mail.queue.interval", "must be positive")
+		}
+		if c.Mail.Queue.BatchSize < 1 {
+			add("mail.queue.batch_size", "must be at least 1")
+		}
+		if c.Mail.Queue.MaxAttempts < 1 {
+			add("mail.queue.max_attempts", "must be at least 1")
+		}
+		if c.Mail.Queue.RetryBackoff <= 0 {
+			add("mail.queue.retry_backoff", "must be positive")
+		}
+	}
+
+	if c.GRPC.Enabled {
+		if _, _, err := net.SplitHostPort(c.GRPC.Address); err != nil {
+			add("grpc.address", "must be host:port, got %q", c.GRPC.Address)
+		} else if c.GRPC.Address == c.ServerAddress {
+			add("grpc.address", "must differ from server.address")
+		}
+	}
+
+	if c.GraphQL.Enabled && c.GraphQL.MaxComplexity < 1 {
+		add("graphql.max_complexity", "must be at least 1")
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n  %w", joinLines(errs))
+}
+
+func (c *Config) field(key string) field {
+	for _, f := range c.fields() {
+		if f.key == key {
+			return f
+		}
+	}
+	return field{key: key}
+}
+
+func joinLines(errs []error) error {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return errors.New(strings.Join(msgs, "\n  "))
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
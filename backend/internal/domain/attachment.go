@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+const (
+	AttachmentOwnerTender = "tender"
+	AttachmentOwnerBid    = "bid"
+)
+
+// Attachment — метаданные файла, приложенного к тендеру или предложению.
+// Заполнено ровно одно из TenderID и BidID.
+type Attachment struct {
+	ID          string    `json:"id"`
+	TenderID    *string   `json:"tenderId,omitempty"`
+	BidID       *string   `json:"bidId,omitempty"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	UploadedBy  string    `json:"uploadedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	StorageKey  string    `json:"-"`
+}
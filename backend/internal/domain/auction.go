@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+const (
+	AuctionStatusScheduled = "SCHEDULED"
+	AuctionStatusRunning   = "RUNNING"
+	AuctionStatusFinished  = "FINISHED"
+)
+
+// Auction — реверсивный аукцион по тендеру. Суммы указываются в минимальных
+// единицах Currency, длительности — в секундах. EndsAt учитывает продления.
+type Auction struct {
+	TenderID               string    `json:"tenderId"`
+	StartsAt               time.Time `json:"startsAt"`
+	DurationSeconds        int       `json:"durationSeconds"`
+	EndsAt                 time.Time `json:"endsAt"`
+	Currency               string    `json:"currency"`
+	StartPrice             *int64    `json:"startPrice,omitempty"`
+	MinDecrement           int64     `json:"minDecrement"`
+	ExtensionWindowSeconds int       `json:"extensionWindowSeconds"`
+	ExtensionSeconds       int       `json:"extensionSeconds"`
+	Extensions             int       `json:"extensions"`
+	BestAmount             *int64    `json:"bestAmount,omitempty"`
+	Status                 string    `json:"status"`
+}
+
+// StatusAt вычисляет состояние аукциона на момент now.
+func (a *Auction) StatusAt(now time.Time) string {
+	switch {
+	case now.Before(a.StartsAt):
+		return AuctionStatusScheduled
+	case now.Before(a.EndsAt):
+		return AuctionStatusRunning
+	default:
+		return AuctionStatusFinished
+	}
+}
+
+// AuctionBid — одна ставка участника аукциона.
+type AuctionBid struct {
+	ID       string    `json:"id"`
+	TenderID string    `json:"tenderId"`
+	AuthorID string    `json:"authorId"`
+	Amount   int64     `json:"amount"`
+	PlacedAt time.Time `json:"placedAt"`
+}
+
+// AuctionRank — место участника по его лучшей ставке; при равной цене выше тот, кто поставил раньше.
+type AuctionRank struct {
+	Rank       int       `json:"rank"`
+	AuthorID   string    `json:"authorId"`
+	Username   string    `json:"username"`
+	BestAmount int64     `json:"bestAmount"`
+	PlacedAt   time.Time `json:"placedAt"`
+}
@@ -12,10 +12,76 @@ type Bid struct {
 	AuthorID    string    `json:"authorId"`
 	Version     int       `json:"version"`
 	CreatedAt   time.Time `json:"createdAt"`
+	// Amount — цена в минимальных единицах валюты (копейках, центах)
+	Amount       *int64     `json:"amount,omitempty"`
+	Currency     *string    `json:"currency,omitempty"`
+	DeliveryDays *int       `json:"deliveryDays,omitempty"`
+	ValidFrom    *time.Time `json:"validFrom,omitempty"`
+	ValidUntil   *time.Time `json:"validUntil,omitempty"`
+	// SealedPayload — зашифрованный BidContent, пока тендер запечатан и не закрыт
+	SealedPayload []byte `json:"-"`
+	// DeletedAt заполнен только у архивных предложений
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// BidContent — часть предложения, которая у запечатанного тендера скрыта до закрытия.
+type BidContent struct {
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Amount       *int64     `json:"amount,omitempty"`
+	Currency     *string    `json:"currency,omitempty"`
+	DeliveryDays *int       `json:"deliveryDays,omitempty"`
+	ValidFrom    *time.Time `json:"validFrom,omitempty"`
+	ValidUntil   *time.Time `json:"validUntil,omitempty"`
+}
+
+func (b *Bid) Sealed() bool {
+	return b.SealedPayload != nil
+}
+
+func (b *Bid) Content() BidContent {
+	return BidContent{
+		Name:         b.Name,
+		Description:  b.Description,
+		Amount:       b.Amount,
+		Currency:     b.Currency,
+		DeliveryDays: b.DeliveryDays,
+		ValidFrom:    b.ValidFrom,
+		ValidUntil:   b.ValidUntil,
+	}
+}
+
+func (b *Bid) SetContent(c BidContent) {
+	b.Name = c.Name
+	b.Description = c.Description
+	b.Amount = c.Amount
+	b.Currency = c.Currency
+	b.DeliveryDays = c.DeliveryDays
+	b.ValidFrom = c.ValidFrom
+	b.ValidUntil = c.ValidUntil
 }
 
 const (
 	BidStatusPending  = "Created"
 	BidStatusAccepted = "Published"
 	BidStatusRejected = "Canceled"
-)
\ No newline at end of file
+)
+
+// Действия автора с поданным предложением.
+const (
+	BidActionWithdraw = "withdraw"
+	BidActionResubmit = "resubmit"
+)
+
+// BidWithdrawal — отзыв или повторная подача предложения автором. Status и Version —
+// состояние предложения после действия.
+type BidWithdrawal struct {
+	BidID          string    `json:"bidId"`
+	BidName        string    `json:"bidName"`
+	AuthorUsername string    `json:"authorUsername"`
+	Action         string    `json:"action"`
+	Reason         string    `json:"reason"`
+	Status         string    `json:"status"`
+	Version        int       `json:"version"`
+	ChangedAt      time.Time `json:"changedAt"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+// Статусы письма в очереди email_outbox.
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed"
+)
+
+// OutboxEmail — письмо в очереди на отправку. Attempts включает текущую попытку.
+type OutboxEmail struct {
+	ID             string
+	NotificationID *string
+	RecipientID    string
+	To             string
+	Subject        string
+	HTMLBody       string
+	Attempts       int
+}
+
+// EmailSettings — почтовые уведомления сотрудника. Пустой Email отключает письма.
+type EmailSettings struct {
+	Email    *string `json:"email"`
+	Language string  `json:"language"`
+	Enabled  bool    `json:"enabled"`
+}
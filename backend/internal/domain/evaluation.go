@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// CriterionSpec — описание критерия оценки без привязки к тендеру (например, в шаблоне).
+// Оценка ставится целым числом от ScaleMin до ScaleMax; вклад критерия в итог
+// пропорционален Weight.
+type CriterionSpec struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Weight      float64 `json:"weight"`
+	ScaleMin    int     `json:"scaleMin"`
+	ScaleMax    int     `json:"scaleMax"`
+}
+
+// Criterion — критерий оценки предложений тендера.
+type Criterion struct {
+	ID       string `json:"id"`
+	TenderID string `json:"tenderId"`
+	CriterionSpec
+}
+
+// Normalize переводит оценку в долю шкалы: ScaleMin — 0, ScaleMax — 1.
+func (c *CriterionSpec) Normalize(score int) float64 {
+	return float64(score-c.ScaleMin) / float64(c.ScaleMax-c.ScaleMin)
+}
+
+// BidScore — оценка предложения одним сотрудником по одному критерию.
+type BidScore struct {
+	BidID       string    `json:"bidId"`
+	CriterionID string    `json:"criterionId"`
+	EvaluatorID string    `json:"-"`
+	Evaluator   string    `json:"evaluator"`
+	Score       int       `json:"score"`
+	Comment     string    `json:"comment,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// CriterionResult — средняя оценка предложения по критерию; AverageScore пуст, пока оценок нет.
+type CriterionResult struct {
+	CriterionID  string   `json:"criterionId"`
+	Name         string   `json:"name"`
+	Weight       float64  `json:"weight"`
+	AverageScore *float64 `json:"averageScore"`
+	Evaluations  int      `json:"evaluations"`
+}
+
+// EvaluatorResult — итог предложения по оценкам одного сотрудника.
+type EvaluatorResult struct {
+	Evaluator string     `json:"evaluator"`
+	Total     float64    `json:"total"`
+	Scores    []BidScore `json:"scores"`
+}
+
+// BidRank — место предложения по взвешенной оценке. Total — от 0 до 100;
+// критерий без оценок дает 0. При равном итоге выше предложение, поданное раньше.
+type BidRank struct {
+	Rank        int               `json:"rank"`
+	BidID       string            `json:"bidId"`
+	BidName     string            `json:"bidName"`
+	AuthorID    string            `json:"authorId"`
+	Total       float64           `json:"total"`
+	Evaluators  int               `json:"evaluators"`
+	Criteria    []CriterionResult `json:"criteria"`
+	ByEvaluator []EvaluatorResult `json:"byEvaluator,omitempty"`
+}
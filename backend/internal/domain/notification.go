@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// Типы уведомлений. Получатели:
+// bid_created — ответственные за организацию тендера;
+// bid_status_changed — автор предложения;
+// tender_status_changed и tender_updated — авторы предложений к тендеру;
+// question_asked — ответственные за организацию тендера;
+// question_answered — автор вопроса.
+const (
+	NotificationBidCreated          = "bid_created"
+	NotificationBidStatusChanged    = "bid_status_changed"
+	NotificationTenderStatusChanged = "tender_status_changed"
+	NotificationTenderUpdated       = "tender_updated"
+	NotificationQuestionAsked       = "question_asked"
+	NotificationQuestionAnswered    = "question_answered"
+)
+
+// NotificationTypes перечисляет все типы в порядке выдачи настроек.
+var NotificationTypes = []string{
+	NotificationBidCreated,
+	NotificationBidStatusChanged,
+	NotificationTenderStatusChanged,
+	NotificationTenderUpdated,
+	NotificationQuestionAsked,
+	NotificationQuestionAnswered,
+}
+
+type Notification struct {
+	ID       string  `json:"id"`
+	Type     string  `json:"type"`
+	TenderID *string `json:"tenderId,omitempty"`
+	BidID    *string `json:"bidId,omitempty"`
+	// Data — подробности события: tenderName, bidName, status, previousStatus, questionId
+	Data      map[string]string `json:"data"`
+	CreatedAt time.Time         `json:"createdAt"`
+	ReadAt    *time.Time        `json:"readAt,omitempty"`
+}
+
+type NotificationPreference struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
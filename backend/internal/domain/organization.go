@@ -1 +1,9 @@
 package domain
+
+// Organization.Type — IE, LLC или JSC, пустая строка, если тип не указан.
+type Organization struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
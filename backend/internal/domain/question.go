@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+const (
+	QuestionStatusPending  = "PENDING"
+	QuestionStatusAnswered = "ANSWERED"
+	QuestionStatusRejected = "REJECTED"
+)
+
+// TenderQuestion — вопрос участника по тендеру. Автор виден только ответственным
+// за организацию тендера; другим участникам вопрос показывается анонимно.
+type TenderQuestion struct {
+	ID       string `json:"id"`
+	TenderID string `json:"tenderId"`
+	Text     string `json:"text"`
+	Status   string `json:"status"`
+	// AuthorUsername и OriginalText заполняются только для ответственных
+	AuthorUsername string  `json:"authorUsername,omitempty"`
+	OriginalText   *string `json:"originalText,omitempty"`
+	// Mine отмечает вопросы, заданные тем, кто запрашивает список
+	Mine       bool       `json:"mine,omitempty"`
+	Answer     *string    `json:"answer,omitempty"`
+	AnsweredAt *time.Time `json:"answeredAt,omitempty"`
+	// AmendedVersion — версия тендера, в которой его условия изменены по этому вопросу
+	AmendedVersion  *int      `json:"amendedVersion,omitempty"`
+	RejectionReason *string   `json:"rejectionReason,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+	AuthorID        string    `json:"-"`
+}
+
+// QuestionSettings — срок приема вопросов. Без Deadline вопросы принимаются
+// до срока подачи предложений, а без него — пока тендер опубликован.
+type QuestionSettings struct {
+	TenderID          string     `json:"tenderId"`
+	Deadline          *time.Time `json:"deadline"`
+	EffectiveDeadline *time.Time `json:"effectiveDeadline"`
+}
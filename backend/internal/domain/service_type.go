@@ -0,0 +1,26 @@
+package domain
+
+// DefaultLanguage — язык подписей типов услуг, если запрошенного нет.
+const DefaultLanguage = "ru"
+
+// ServiceType — элемент справочника типов услуг. Тендер хранит Code.
+type ServiceType struct {
+	Code       string            `json:"code"`
+	ParentCode *string           `json:"parentCode,omitempty"`
+	Labels     map[string]string `json:"labels"`
+	// Label — подпись на языке запроса; заполняется при выдаче списка
+	Label   string   `json:"label,omitempty"`
+	Aliases []string `json:"aliases"`
+	Active  bool     `json:"active"`
+}
+
+// LabelFor возвращает подпись на языке lang, иначе на DefaultLanguage, иначе код.
+func (s *ServiceType) LabelFor(lang string) string {
+	if label := s.Labels[lang]; label != "" {
+		return label
+	}
+	if label := s.Labels[DefaultLanguage]; label != "" {
+		return label
+	}
+	return s.Code
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 const (
 	TenderStatusCreated   = "CREATED"
 	TenderStatusPublished = "PUBLISHED"
@@ -7,12 +9,22 @@ const (
 )
 
 type Tender struct {
-	ID               string  `json:"id"`
-	Name             string `json:"name"`
-	Description      string `json:"description"`
-	ServiceType      string `json:"serviceType"`
-	Version          int    `json:"version"`
-	Status           string `json:"status"`
-	OrganizationID   string  `json:"organizationId"`
-	CreatorUsername  string `json:"creatorUsername"`
-}
\ No newline at end of file
+	ID                 string     `json:"id"`
+	Name               string     `json:"name"`
+	Description        string     `json:"description"`
+	ServiceType        string     `json:"serviceType"`
+	Version            int        `json:"version"`
+	Status             string     `json:"status"`
+	OrganizationID     string     `json:"organizationId"`
+	CreatorUsername    string     `json:"creatorUsername"`
+	SubmissionDeadline *time.Time `json:"submissionDeadline,omitempty"`
+	// Sealed скрывает содержимое предложений от организации до закрытия тендера
+	Sealed bool `json:"sealed"`
+	// DeletedAt заполнен только у архивных тендеров
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// DeadlinePassed сообщает, истек ли срок подачи предложений к моменту now.
+func (t *Tender) DeadlinePassed(now time.Time) bool {
+	return t.SubmissionDeadline != nil && !now.Before(*t.SubmissionDeadline)
+}
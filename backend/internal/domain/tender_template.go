@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// TenderTemplate — шаблон тендера организации. Name и Description могут содержать
+// плейсхолдеры вида {{quarter}}; их имена перечислены в Placeholders.
+type TenderTemplate struct {
+	ID             string          `json:"id"`
+	OrganizationID string          `json:"organizationId"`
+	Title          string          `json:"title"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	ServiceType    string          `json:"serviceType"`
+	Sealed         bool            `json:"sealed"`
+	Criteria       []CriterionSpec `json:"criteria"`
+	Placeholders   []string        `json:"placeholders"`
+	CreatedBy      string          `json:"createdBy"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
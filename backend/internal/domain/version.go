@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// TenderVersion — сохраненное состояние тендера до очередного изменения.
+// ChangeReason — причина изменения, которое привело к этой версии.
+type TenderVersion struct {
+	TenderID           string     `json:"tenderId"`
+	Version            int        `json:"version"`
+	Name               string     `json:"name"`
+	Description        string     `json:"description"`
+	ServiceType        string     `json:"serviceType"`
+	Status             string     `json:"status"`
+	SubmissionDeadline *time.Time `json:"submissionDeadline,omitempty"`
+	ChangeReason       *string    `json:"changeReason,omitempty"`
+}
+
+// BidVersion — сохраненное состояние предложения. У версий запечатанного предложения
+// содержимое лежит в SealedPayload до вскрытия тендера.
+type BidVersion struct {
+	BidID         string     `json:"bidId"`
+	Version       int        `json:"version"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	Status        string     `json:"status"`
+	Amount        *int64     `json:"amount,omitempty"`
+	Currency      *string    `json:"currency,omitempty"`
+	DeliveryDays  *int       `json:"deliveryDays,omitempty"`
+	ValidFrom     *time.Time `json:"validFrom,omitempty"`
+	ValidUntil    *time.Time `json:"validUntil,omitempty"`
+	ChangeAction  *string    `json:"changeAction,omitempty"`
+	ChangeReason  *string    `json:"changeReason,omitempty"`
+	ChangedAt     *time.Time `json:"changedAt,omitempty"`
+	SealedPayload []byte     `json:"-"`
+}
+
+func (v *BidVersion) Sealed() bool {
+	return v.SealedPayload != nil
+}
@@ -0,0 +1,75 @@
+package graphapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+var (
+	errUsernameRequired     = errors.New("username query parameter is required")
+	errOrganizationNotFound = errors.New("organization not found")
+	errInternal             = errors.New("internal server error")
+)
+
+// legacyForbidden — отказы в доступе, которые часть сервисов возвращает без сентинел-ошибок.
+var legacyForbidden = map[string]bool{
+	"unauthorized": true,
+	"user is not authorized to view bids for this tender":               true,
+	"user is not authorized to view this bid status":                    true,
+	"доступ запрещен: пользователь не является сотрудником организации": true,
+}
+
+// presentError добавляет к ошибке резолвера extensions.code — аналог HTTP-статуса
+// соответствующего REST-метода. Внутренние ошибки логируются и не раскрываются клиенту.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) && gqlErr.Err == nil {
+		// Ошибки разбора, валидации и лимита сложности gqlgen формирует сам
+		return gqlErr
+	}
+
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+	code := errorCode(err)
+	if code == "INTERNAL" {
+		if !errors.Is(err, errInternal) {
+			slog.ErrorContext(ctx, "graphql request failed", slog.Any("error", err))
+		}
+		presented.Message = errInternal.Error()
+	}
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]interface{}{}
+	}
+	presented.Extensions["code"] = code
+	return presented
+}
+
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, repository.ErrBidNotFound),
+		errors.Is(err, errOrganizationNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, errUsernameRequired), errors.Is(err, service.ErrUnknownServiceType):
+		return "BAD_REQUEST"
+	case errors.Is(err, service.ErrUnknownUser):
+		return "UNAUTHENTICATED"
+	case errors.Is(err, service.ErrNotResponsible), legacyForbidden[err.Error()]:
+		return "FORBIDDEN"
+	case errors.Is(err, service.ErrBidsSealed):
+		return "CONFLICT"
+	case errors.Is(err, service.ErrSealingUnavailable):
+		return "SERVICE_UNAVAILABLE"
+	default:
+		return "INTERNAL"
+	}
+}
+
+func recoverPanic(ctx context.Context, p interface{}) error {
+	slog.ErrorContext(ctx, "graphql resolver panicked", slog.Any("panic", p))
+	return errInternal
+}
@@ -0,0 +1,53 @@
+package graphapi
+
+import (
+	"net/http"
+	"tender_srevice/internal/repository"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// listComplexity — во сколько раз поле-список дороже своего элемента при расчете
+// сложности запроса: вложенные списки быстро упираются в лимит.
+const listComplexity = 10
+
+// NewHandler возвращает обработчик POST /graphql. Запросы сложнее maxComplexity
+// отклоняются до выполнения.
+func NewHandler(resolver *Resolver, maxComplexity int) http.Handler {
+	cfg := Config{Resolvers: resolver}
+	setComplexity(&cfg.Complexity)
+
+	srv := handler.New(NewExecutableSchema(cfg))
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(maxComplexity))
+	srv.SetErrorPresenter(presentError)
+	srv.SetRecoverFunc(recoverPanic)
+
+	return withRequestState(resolver.repo, srv)
+}
+
+// withRequestState создает для каждого HTTP-запроса свои загрузчики и пользователя.
+func withRequestState(repo *repository.PostgresRepository, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := newRequest(repo, r.URL.Query().Get("username"))
+		next.ServeHTTP(w, r.WithContext(withRequest(r.Context(), req)))
+	})
+}
+
+func list(childComplexity int) int {
+	return 1 + listComplexity*childComplexity
+}
+
+func setComplexity(c *ComplexityRoot) {
+	c.Query.Tenders = func(childComplexity int, _ *string) int { return list(childComplexity) }
+	c.Query.MyTenders = list
+	c.Query.MyBids = list
+	c.Tender.Bids = list
+	c.Tender.Versions = list
+	c.Bid.Versions = list
+	c.Organization.Responsibles = list
+	c.Organization.Tenders = list
+}
@@ -0,0 +1,47 @@
+package graphapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"tender_srevice/internal/repository"
+	"testing"
+)
+
+func TestHandlerRejectsTooComplexQueries(t *testing.T) {
+	// Без базы: запрос должен отклоняться до вызова резолверов
+	h := NewHandler(NewResolver(repository.NewPostgresRepository(nil), nil, nil, nil), 100)
+
+	query := `{"query":"{ tenders { bids { versions { version } } } }"}`
+	req := httptest.NewRequest(http.MethodPost, "/graphql?username=user1", strings.NewReader(query))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message    string                 `json:"message"`
+			Extensions map[string]interface{} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "COMPLEXITY_LIMIT_EXCEEDED" {
+		t.Fatalf("errors = %+v, want a single COMPLEXITY_LIMIT_EXCEEDED error", resp.Errors)
+	}
+}
+
+func TestErrorCodeTreatsLegacyDenialsAsForbidden(t *testing.T) {
+	for msg := range legacyForbidden {
+		if code := errorCode(errorString(msg)); code != "FORBIDDEN" {
+			t.Errorf("errorCode(%q) = %s, want FORBIDDEN", msg, code)
+		}
+	}
+}
+
+type errorString string
+
+func (e errorString) Error() string { return string(e) }
@@ -0,0 +1,130 @@
+package graphapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// loaderWait — сколько загрузчик ждет остальные ключи после первого. gqlgen резолвит
+	// элементы списка параллельно, так что ключи всех родителей успевают собраться.
+	loaderWait     = 2 * time.Millisecond
+	loaderMaxBatch = 500
+)
+
+// loader собирает ключи, которые резолверы запрашивают почти одновременно, и загружает
+// их одной выборкой. Результаты кешируются на время одного GraphQL-запроса.
+type loader[K comparable, V any] struct {
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	cache   map[K]*loadResult[V]
+	pending *loadBatch[K, V]
+}
+
+type loadResult[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type loadBatch[K comparable, V any] struct {
+	results map[K]*loadResult[V]
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:    fetch,
+		wait:     loaderWait,
+		maxBatch: loaderMaxBatch,
+		cache:    map[K]*loadResult[V]{},
+	}
+}
+
+// Load возвращает нулевое значение V без ошибки, если по ключу ничего не нашлось.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r, ok := l.cache[key]
+	if !ok {
+		r = &loadResult[V]{done: make(chan struct{})}
+		l.cache[key] = r
+
+		b := l.pending
+		if b == nil {
+			b = &loadBatch[K, V]{results: map[K]*loadResult[V]{}}
+			l.pending = b
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx, b) })
+		}
+		b.results[key] = r
+		if len(b.results) >= l.maxBatch {
+			l.pending = nil
+			go l.fetchBatch(ctx, b)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *loader[K, V]) dispatch(ctx context.Context, b *loadBatch[K, V]) {
+	l.mu.Lock()
+	if l.pending != b {
+		// Пачку уже отправили, набрав maxBatch ключей
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+	l.fetchBatch(ctx, b)
+}
+
+func (l *loader[K, V]) fetchBatch(ctx context.Context, b *loadBatch[K, V]) {
+	keys := make([]K, 0, len(b.results))
+	for k := range b.results {
+		keys = append(keys, k)
+	}
+	values, err := l.fetch(ctx, keys)
+	for k, r := range b.results {
+		r.value, r.err = values[k], err
+		close(r.done)
+	}
+}
+
+// byKey превращает пакетную выборку строк в выборку по ключу.
+func byKey[V any](fetch func(context.Context, []string) ([]V, error), key func(V) string) func(context.Context, []string) (map[string]V, error) {
+	return func(ctx context.Context, keys []string) (map[string]V, error) {
+		rows, err := fetch(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]V, len(rows))
+		for _, row := range rows {
+			result[key(row)] = row
+		}
+		return result, nil
+	}
+}
+
+// groupBy — то же для связей один-ко-многим; порядок строк внутри ключа сохраняется.
+func groupBy[V any](fetch func(context.Context, []string) ([]V, error), key func(V) string) func(context.Context, []string) (map[string][]V, error) {
+	return func(ctx context.Context, keys []string) (map[string][]V, error) {
+		rows, err := fetch(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string][]V, len(keys))
+		for _, row := range rows {
+			result[key(row)] = append(result[key(row)], row)
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,101 @@
+package graphapi
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestLoaderBatchesConcurrentLoads(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]string
+	)
+	l := newLoader(func(_ context.Context, keys []string) (map[string]int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		sorted := append([]string(nil), keys...)
+		sort.Strings(sorted)
+		batches = append(batches, sorted)
+		result := map[string]int{}
+		for _, k := range keys {
+			if k != "missing" {
+				result[k] = len(k)
+			}
+		}
+		return result, nil
+	})
+
+	keys := []string{"a", "bb", "ccc", "bb", "missing"}
+	got := make([]int, len(keys))
+	var wg sync.WaitGroup
+	for i, k := range keys {
+		wg.Add(1)
+		go func(i int, k string) {
+			defer wg.Done()
+			v, err := l.Load(context.Background(), k)
+			if err != nil {
+				t.Errorf("Load(%q): %v", k, err)
+			}
+			got[i] = v
+		}(i, k)
+	}
+	wg.Wait()
+
+	want := []int{1, 2, 3, 2, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Load(%q) = %d, want %d", keys[i], got[i], want[i])
+		}
+	}
+	if len(batches) != 1 || len(batches[0]) != 4 {
+		t.Fatalf("batches = %v, want one batch of 4 distinct keys", batches)
+	}
+
+	// Повторная загрузка берется из кеша
+	if v, _ := l.Load(context.Background(), "ccc"); v != 3 || len(batches) != 1 {
+		t.Errorf("cached Load = %d after %d batches, want 3 after 1", v, len(batches))
+	}
+}
+
+func TestLoaderSplitsBatchesAtMaxBatch(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		sizes []int
+	)
+	l := newLoader(func(_ context.Context, keys []string) (map[string]string, error) {
+		mu.Lock()
+		sizes = append(sizes, len(keys))
+		mu.Unlock()
+		return nil, nil
+	})
+	l.maxBatch = 2
+
+	var wg sync.WaitGroup
+	for _, k := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		go func(k string) {
+			defer wg.Done()
+			l.Load(context.Background(), k)
+		}(k)
+	}
+	wg.Wait()
+
+	for _, n := range sizes {
+		if n > 2 {
+			t.Errorf("batch sizes = %v, want at most 2 keys each", sizes)
+		}
+	}
+}
+
+func TestLoaderSharesFetchError(t *testing.T) {
+	errFetch := errors.New("db down")
+	l := newLoader(func(context.Context, []string) (map[string]int, error) {
+		return nil, errFetch
+	})
+	if _, err := l.Load(context.Background(), "a"); !errors.Is(err, errFetch) {
+		t.Fatalf("Load error = %v, want %v", err, errFetch)
+	}
+}
@@ -0,0 +1,124 @@
+package graphapi
+
+import (
+	"context"
+	"sync"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+)
+
+// request — состояние одного GraphQL-запроса: пользователь и загрузчики с их кешем.
+type request struct {
+	repo    *repository.PostgresRepository
+	viewer  viewer
+	loaders *loaders
+}
+
+// viewer — пользователь из параметра username и организации, за которые он отвечает.
+// Загружается при первом обращении, один раз на запрос.
+type viewer struct {
+	username string
+
+	once          sync.Once
+	employee      *domain.Employee
+	organizations map[string]bool
+	err           error
+}
+
+type requestKey struct{}
+
+func newRequest(repo *repository.PostgresRepository, username string) *request {
+	return &request{
+		repo:    repo,
+		viewer:  viewer{username: username},
+		loaders: newLoaders(repo),
+	}
+}
+
+func withRequest(ctx context.Context, req *request) context.Context {
+	return context.WithValue(ctx, requestKey{}, req)
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// me возвращает сотрудника, от имени которого выполняется запрос.
+func (q *request) me(ctx context.Context) (*domain.Employee, error) {
+	v := &q.viewer
+	v.once.Do(func() {
+		if v.username == "" {
+			v.err = errUsernameRequired
+			return
+		}
+		v.employee, v.err = q.loaders.employees.Load(ctx, v.username)
+		if v.err == nil && v.employee == nil {
+			v.err = service.ErrUnknownUser
+		}
+		if v.err != nil {
+			return
+		}
+		var organizations map[string]map[string]bool
+		organizations, v.err = q.repo.ResponsibleOrganizations(ctx, []string{v.username})
+		v.organizations = organizations[v.username]
+	})
+	return v.employee, v.err
+}
+
+func (q *request) isResponsible(ctx context.Context, organizationID string) (bool, error) {
+	if _, err := q.me(ctx); err != nil {
+		return false, err
+	}
+	return q.viewer.organizations[organizationID], nil
+}
+
+func (q *request) requireResponsible(ctx context.Context, organizationID string) error {
+	ok, err := q.isResponsible(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return service.ErrNotResponsible
+	}
+	return nil
+}
+
+// tenderVisible — правило GET /api/tenders/{tenderId}/status: опубликованный тендер
+// виден всем, остальные — только ответственным за организацию.
+func (q *request) tenderVisible(ctx context.Context, t *domain.Tender) (bool, error) {
+	if t.Status == domain.TenderStatusPublished {
+		return true, nil
+	}
+	if q.viewer.username == "" {
+		return false, nil
+	}
+	return q.isResponsible(ctx, t.OrganizationID)
+}
+
+type loaders struct {
+	organizations  *loader[string, *domain.Organization]
+	employees      *loader[string, *domain.Employee]
+	employeesByID  *loader[string, *domain.Employee]
+	responsibles   *loader[string, []*domain.Employee]
+	tenders        *loader[string, *domain.Tender]
+	orgTenders     *loader[string, []*domain.Tender]
+	tenderBids     *loader[string, []*domain.Bid]
+	tenderVersions *loader[string, []*domain.TenderVersion]
+	bidVersions    *loader[string, []*domain.BidVersion]
+}
+
+func newLoaders(repo *repository.PostgresRepository) *loaders {
+	return &loaders{
+		organizations: newLoader(byKey(repo.OrganizationsByIDs, func(o *domain.Organization) string { return o.ID })),
+		employees:     newLoader(byKey(repo.EmployeesByUsernames, func(e *domain.Employee) string { return e.Username })),
+		employeesByID: newLoader(byKey(repo.EmployeesByIDs, func(e *domain.Employee) string { return e.ID })),
+		responsibles:  newLoader(repo.ResponsiblesByOrganizationIDs),
+		tenders:       newLoader(byKey(repo.TendersByIDs, func(t *domain.Tender) string { return t.ID })),
+		orgTenders:    newLoader(groupBy(repo.TendersByOrganizationIDs, func(t *domain.Tender) string { return t.OrganizationID })),
+		tenderBids:    newLoader(groupBy(repo.BidsByTenderIDs, func(b *domain.Bid) string { return b.TenderID })),
+		tenderVersions: newLoader(groupBy(repo.TenderVersionsByTenderIDs,
+			func(v *domain.TenderVersion) string { return v.TenderID })),
+		bidVersions: newLoader(groupBy(repo.BidVersionsByBidIDs, func(v *domain.BidVersion) string { return v.BidID })),
+	}
+}
@@ -0,0 +1,288 @@
+package graphapi
+
+//go:generate go run github.com/99designs/gqlgen@v0.17.49 generate --config gqlgen.yml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+)
+
+// Resolver реализует схему поверх тех же сервисов, что и REST-обработчики. Связи между
+// объектами загружаются через загрузчики запроса, а не отдельными запросами к базе.
+type Resolver struct {
+	repo    *repository.PostgresRepository
+	tenders *service.TenderService
+	bids    *service.BidService
+	sealing *service.BidSealing
+}
+
+func NewResolver(repo *repository.PostgresRepository, tenders *service.TenderService, bids *service.BidService, sealing *service.BidSealing) *Resolver {
+	return &Resolver{repo: repo, tenders: tenders, bids: bids, sealing: sealing}
+}
+
+func (r *Resolver) Query() QueryResolver               { return &queryResolver{r} }
+func (r *Resolver) Tender() TenderResolver             { return &tenderResolver{r} }
+func (r *Resolver) Bid() BidResolver                   { return &bidResolver{r} }
+func (r *Resolver) BidVersion() BidVersionResolver     { return &bidVersionResolver{r} }
+func (r *Resolver) Organization() OrganizationResolver { return &organizationResolver{r} }
+
+type queryResolver struct{ *Resolver }
+
+func (r *queryResolver) Tenders(ctx context.Context, serviceType *string) ([]*domain.Tender, error) {
+	var code string
+	if serviceType != nil {
+		code = *serviceType
+	}
+	tenders, err := r.tenders.GetTenders(ctx, code)
+	return orEmpty(tenders), err
+}
+
+func (r *queryResolver) Tender(ctx context.Context, id string) (*domain.Tender, error) {
+	tender, err := requestFrom(ctx).loaders.tenders.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tender == nil {
+		return nil, repository.ErrTenderNotFound
+	}
+	visible, err := requestFrom(ctx).tenderVisible(ctx, tender)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, service.ErrNotResponsible
+	}
+	return tender, nil
+}
+
+func (r *queryResolver) MyTenders(ctx context.Context) ([]*domain.Tender, error) {
+	me, err := requestFrom(ctx).me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenders, err := r.tenders.GetTendersByUsername(ctx, me.Username)
+	return orEmpty(tenders), err
+}
+
+// Bid показывает автору предложение целиком, а ответственным за организацию тендера —
+// только после вскрытия, как GET /api/tenders/{tenderId}/bids.
+func (r *queryResolver) Bid(ctx context.Context, id string) (*domain.Bid, error) {
+	q := requestFrom(ctx)
+	me, err := q.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bid, err := r.repo.GetBidByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tender, err := q.loaders.tenders.Load(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if tender == nil {
+		// Предложения архивного тендера доступны только через архив
+		return nil, repository.ErrBidNotFound
+	}
+
+	if bid.AuthorID == me.ID {
+		return bid, r.sealing.Reveal(bid)
+	}
+	if err := q.requireResponsible(ctx, tender.OrganizationID); err != nil {
+		return nil, err
+	}
+	if bid.Sealed() {
+		return nil, service.ErrBidsSealed
+	}
+	return bid, nil
+}
+
+func (r *queryResolver) MyBids(ctx context.Context) ([]*domain.Bid, error) {
+	me, err := requestFrom(ctx).me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, err := r.bids.GetBidsByAuthorID(ctx, me.ID, service.BidPageRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return page.Bids, nil
+}
+
+func (r *queryResolver) Organization(ctx context.Context, id string) (*domain.Organization, error) {
+	organization, err := requestFrom(ctx).loaders.organizations.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return nil, errOrganizationNotFound
+	}
+	return organization, nil
+}
+
+func (r *queryResolver) Me(ctx context.Context) (*domain.Employee, error) {
+	return requestFrom(ctx).me(ctx)
+}
+
+type tenderResolver struct{ *Resolver }
+
+func (r *tenderResolver) Organization(ctx context.Context, obj *domain.Tender) (*domain.Organization, error) {
+	organization, err := requestFrom(ctx).loaders.organizations.Load(ctx, obj.OrganizationID)
+	if err == nil && organization == nil {
+		err = fmt.Errorf("organization %s of tender %s: %w", obj.OrganizationID, obj.ID, errOrganizationNotFound)
+	}
+	return organization, err
+}
+
+func (r *tenderResolver) Creator(ctx context.Context, obj *domain.Tender) (*domain.Employee, error) {
+	creator, err := requestFrom(ctx).loaders.employees.Load(ctx, obj.CreatorUsername)
+	if err == nil && creator == nil {
+		err = fmt.Errorf("creator %q of tender %s not found", obj.CreatorUsername, obj.ID)
+	}
+	return creator, err
+}
+
+func (r *tenderResolver) Bids(ctx context.Context, obj *domain.Tender) ([]*domain.Bid, error) {
+	q := requestFrom(ctx)
+	if err := q.requireResponsible(ctx, obj.OrganizationID); err != nil {
+		return nil, err
+	}
+	if obj.Sealed && obj.Status != domain.TenderStatusClosed {
+		return nil, service.ErrBidsSealed
+	}
+
+	bids, err := q.loaders.tenderBids.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bids {
+		if !b.Sealed() {
+			continue
+		}
+		// Тендер закрыт, но вскрытие при закрытии не удалось — вскрываем сейчас, как REST
+		if _, err := r.sealing.UnsealTender(ctx, obj.ID, q.viewer.username, service.UnsealOnAccessReason); err != nil {
+			return nil, err
+		}
+		bids, err = r.repo.GetBidsByTenderID(ctx, obj.ID, repository.BidFilter{})
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+	return orEmpty(bids), nil
+}
+
+func (r *tenderResolver) SealedBids(ctx context.Context, obj *domain.Tender) (*service.SealedBids, error) {
+	if err := requestFrom(ctx).requireResponsible(ctx, obj.OrganizationID); err != nil {
+		return nil, err
+	}
+	if !obj.Sealed || obj.Status == domain.TenderStatusClosed {
+		return nil, nil
+	}
+	submissions, err := r.repo.SealedBidSubmissions(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &service.SealedBids{Sealed: true, Count: len(submissions), SubmittedAt: orEmpty(submissions)}, nil
+}
+
+func (r *tenderResolver) Versions(ctx context.Context, obj *domain.Tender) ([]*domain.TenderVersion, error) {
+	q := requestFrom(ctx)
+	if err := q.requireResponsible(ctx, obj.OrganizationID); err != nil {
+		return nil, err
+	}
+	versions, err := q.loaders.tenderVersions.Load(ctx, obj.ID)
+	return orEmpty(versions), err
+}
+
+type bidResolver struct{ *Resolver }
+
+func (r *bidResolver) Tender(ctx context.Context, obj *domain.Bid) (*domain.Tender, error) {
+	tender, err := requestFrom(ctx).loaders.tenders.Load(ctx, obj.TenderID)
+	if err == nil && tender == nil {
+		err = fmt.Errorf("tender of bid %s: %w", obj.ID, repository.ErrTenderNotFound)
+	}
+	return tender, err
+}
+
+func (r *bidResolver) Author(ctx context.Context, obj *domain.Bid) (*domain.Employee, error) {
+	author, err := requestFrom(ctx).loaders.employeesByID.Load(ctx, obj.AuthorID)
+	if err == nil && author == nil {
+		err = fmt.Errorf("author %s of bid %s not found", obj.AuthorID, obj.ID)
+	}
+	return author, err
+}
+
+func (r *bidResolver) Versions(ctx context.Context, obj *domain.Bid) ([]*domain.BidVersion, error) {
+	versions, err := requestFrom(ctx).loaders.bidVersions.Load(ctx, obj.ID)
+	return orEmpty(versions), err
+}
+
+type bidVersionResolver struct{ *Resolver }
+
+func (r *bidVersionResolver) Name(ctx context.Context, obj *domain.BidVersion) (*string, error) {
+	if obj.Sealed() {
+		return nil, nil
+	}
+	return &obj.Name, nil
+}
+
+func (r *bidVersionResolver) Description(ctx context.Context, obj *domain.BidVersion) (*string, error) {
+	if obj.Sealed() {
+		return nil, nil
+	}
+	return &obj.Description, nil
+}
+
+type organizationResolver struct{ *Resolver }
+
+func (r *organizationResolver) Type(ctx context.Context, obj *domain.Organization) (*string, error) {
+	if obj.Type == "" {
+		return nil, nil
+	}
+	return &obj.Type, nil
+}
+
+func (r *organizationResolver) Responsibles(ctx context.Context, obj *domain.Organization) ([]*domain.Employee, error) {
+	q := requestFrom(ctx)
+	if err := q.requireResponsible(ctx, obj.ID); err != nil {
+		return nil, err
+	}
+	responsibles, err := q.loaders.responsibles.Load(ctx, obj.ID)
+	return orEmpty(responsibles), err
+}
+
+func (r *organizationResolver) Tenders(ctx context.Context, obj *domain.Organization) ([]*domain.Tender, error) {
+	q := requestFrom(ctx)
+	responsible := false
+	if q.viewer.username != "" {
+		var err error
+		if responsible, err = q.isResponsible(ctx, obj.ID); err != nil && !errors.Is(err, service.ErrUnknownUser) {
+			return nil, err
+		}
+	}
+
+	tenders, err := q.loaders.orgTenders.Load(ctx, obj.ID)
+	if err != nil || responsible {
+		return orEmpty(tenders), err
+	}
+	published := []*domain.Tender{}
+	for _, t := range tenders {
+		if t.Status == domain.TenderStatusPublished {
+			published = append(published, t)
+		}
+	}
+	return published, nil
+}
+
+// orEmpty не дает вернуть null в обязательное поле-список.
+func orEmpty[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
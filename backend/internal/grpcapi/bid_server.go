@@ -0,0 +1,209 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/grpcapi/tenderv1"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// BidServer — gRPC-вариант BidHandler.
+type BidServer struct {
+	tenderv1.UnimplementedBidServiceServer
+	service *service.BidService
+}
+
+func NewBidServer(service *service.BidService) *BidServer {
+	return &BidServer{service: service}
+}
+
+func (s *BidServer) CreateBid(ctx context.Context, req *tenderv1.CreateBidRequest) (*tenderv1.Bid, error) {
+	bid, err := s.service.CreateBid(ctx, service.CreateBidRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		TenderID:    req.TenderId,
+		AuthorType:  req.AuthorType,
+		AuthorID:    req.AuthorId,
+		BidTerms:    bidTerms(req.Terms),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return bidMessage(bid), nil
+}
+
+func (s *BidServer) ListMyBids(req *tenderv1.ListMyBidsRequest, stream tenderv1.BidService_ListMyBidsServer) error {
+	ctx := stream.Context()
+	if req.Username == "" {
+		return invalidArgument("username is required")
+	}
+	userID, err := s.service.Repo.GetUserIDByUsername(ctx, req.Username)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = service.ErrUnknownUser
+	}
+	if err != nil {
+		return statusError(ctx, err)
+	}
+	page, err := s.service.GetBidsByAuthorID(ctx, userID, service.BidPageRequest{})
+	if err != nil {
+		return statusError(ctx, err)
+	}
+	return sendBids(stream, page.Bids)
+}
+
+// ListTenderBids отдает предложения по одному, а у открытого запечатанного тендера —
+// одно сообщение со сводкой вместо них.
+func (s *BidServer) ListTenderBids(req *tenderv1.ListTenderBidsRequest, stream tenderv1.BidService_ListTenderBidsServer) error {
+	ctx := stream.Context()
+	if req.Username == "" {
+		return invalidArgument("username is required")
+	}
+	filter := repository.BidFilter{
+		Currency:  req.Currency,
+		MinAmount: req.MinAmount,
+		MaxAmount: req.MaxAmount,
+		Sort:      req.Sort,
+	}
+	switch filter.Sort {
+	case "", repository.BidSortCreated, repository.BidSortPriceAsc, repository.BidSortPriceDesc:
+	default:
+		return invalidArgument("unsupported sort %q", filter.Sort)
+	}
+
+	page, sealed, err := s.service.GetBidsByTenderID(ctx, req.TenderId, req.Username, filter, service.BidPageRequest{})
+	if err != nil {
+		return statusError(ctx, err)
+	}
+	if sealed != nil {
+		return stream.Send(&tenderv1.ListTenderBidsResponse{
+			Result: &tenderv1.ListTenderBidsResponse_Sealed{Sealed: sealedBidsMessage(sealed)},
+		})
+	}
+	for _, b := range page.Bids {
+		err := stream.Send(&tenderv1.ListTenderBidsResponse{
+			Result: &tenderv1.ListTenderBidsResponse_Bid{Bid: bidMessage(b)},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BidServer) GetBidStatus(ctx context.Context, req *tenderv1.BidActionRequest) (*tenderv1.BidStatusResponse, error) {
+	if req.Username == "" {
+		return nil, invalidArgument("username is required")
+	}
+	status, err := s.service.GetBidStatus(ctx, req.BidId, req.Username)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &tenderv1.BidStatusResponse{Status: status}, nil
+}
+
+func (s *BidServer) UpdateBidStatus(ctx context.Context, req *tenderv1.UpdateBidStatusRequest) (*emptypb.Empty, error) {
+	if req.Username == "" || req.Status == "" {
+		return nil, invalidArgument("username and status are required")
+	}
+	if err := s.service.UpdateBidStatus(ctx, req.BidId, req.Username, req.Status); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BidServer) EditBid(ctx context.Context, req *tenderv1.EditBidRequest) (*emptypb.Empty, error) {
+	if req.Username == "" {
+		return nil, invalidArgument("username is required")
+	}
+	err := s.service.EditBid(ctx, req.BidId, req.Username, req.Name, req.Description, bidTerms(req.Terms))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BidServer) WithdrawBid(ctx context.Context, req *tenderv1.BidReasonRequest) (*tenderv1.Bid, error) {
+	return s.authorAction(ctx, req, s.service.WithdrawBid)
+}
+
+func (s *BidServer) ResubmitBid(ctx context.Context, req *tenderv1.BidReasonRequest) (*tenderv1.Bid, error) {
+	return s.authorAction(ctx, req, s.service.ResubmitBid)
+}
+
+func (s *BidServer) authorAction(ctx context.Context, req *tenderv1.BidReasonRequest,
+	action func(ctx context.Context, bidID, username, reason string) (*domain.Bid, error)) (*tenderv1.Bid, error) {
+	if req.Username == "" {
+		return nil, invalidArgument("username is required")
+	}
+	bid, err := action(ctx, req.BidId, req.Username, req.Reason)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return bidMessage(bid), nil
+}
+
+func (s *BidServer) ListBidWithdrawals(req *tenderv1.ListBidWithdrawalsRequest, stream tenderv1.BidService_ListBidWithdrawalsServer) error {
+	ctx := stream.Context()
+	if req.Username == "" {
+		return invalidArgument("username is required")
+	}
+	withdrawals, err := s.service.BidWithdrawals(ctx, req.TenderId, req.Username)
+	if err != nil {
+		return statusError(ctx, err)
+	}
+	for _, w := range withdrawals {
+		if err := stream.Send(withdrawalMessage(w)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BidServer) ArchiveBid(ctx context.Context, req *tenderv1.BidActionRequest) (*tenderv1.Bid, error) {
+	return s.bidAction(ctx, req, s.service.ArchiveBid)
+}
+
+func (s *BidServer) RestoreBid(ctx context.Context, req *tenderv1.BidActionRequest) (*tenderv1.Bid, error) {
+	return s.bidAction(ctx, req, s.service.RestoreBid)
+}
+
+func (s *BidServer) bidAction(ctx context.Context, req *tenderv1.BidActionRequest,
+	action func(ctx context.Context, bidID, username string) (*domain.Bid, error)) (*tenderv1.Bid, error) {
+	if req.Username == "" {
+		return nil, invalidArgument("username is required")
+	}
+	bid, err := action(ctx, req.BidId, req.Username)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return bidMessage(bid), nil
+}
+
+func (s *BidServer) ListArchivedBids(req *tenderv1.ListArchivedBidsRequest, stream tenderv1.BidService_ListArchivedBidsServer) error {
+	if req.Username == "" {
+		return invalidArgument("username is required")
+	}
+	bids, err := s.service.ArchivedBids(stream.Context(), req.Username)
+	if err != nil {
+		return statusError(stream.Context(), err)
+	}
+	return sendBids(stream, bids)
+}
+
+type bidStream interface {
+	Send(*tenderv1.Bid) error
+}
+
+func sendBids(stream bidStream, bids []*domain.Bid) error {
+	for _, b := range bids {
+		if err := stream.Send(bidMessage(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
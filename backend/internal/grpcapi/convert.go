@@ -0,0 +1,105 @@
+package grpcapi
+
+import (
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/grpcapi/tenderv1"
+	"tender_srevice/internal/service"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeValue(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func tenderMessage(t *domain.Tender) *tenderv1.Tender {
+	return &tenderv1.Tender{
+		Id:                 t.ID,
+		Name:               t.Name,
+		Description:        t.Description,
+		ServiceType:        t.ServiceType,
+		Version:            int32(t.Version),
+		Status:             t.Status,
+		OrganizationId:     t.OrganizationID,
+		CreatorUsername:    t.CreatorUsername,
+		SubmissionDeadline: timestamp(t.SubmissionDeadline),
+		Sealed:             t.Sealed,
+		DeletedAt:          timestamp(t.DeletedAt),
+	}
+}
+
+func bidMessage(b *domain.Bid) *tenderv1.Bid {
+	terms := &tenderv1.BidTerms{
+		Amount:     b.Amount,
+		Currency:   b.Currency,
+		ValidFrom:  timestamp(b.ValidFrom),
+		ValidUntil: timestamp(b.ValidUntil),
+	}
+	if b.DeliveryDays != nil {
+		days := int32(*b.DeliveryDays)
+		terms.DeliveryDays = &days
+	}
+	return &tenderv1.Bid{
+		Id:          b.ID,
+		Name:        b.Name,
+		Description: b.Description,
+		Status:      b.Status,
+		TenderId:    b.TenderID,
+		AuthorType:  b.AuthorType,
+		AuthorId:    b.AuthorID,
+		Version:     int32(b.Version),
+		CreatedAt:   timestamppb.New(b.CreatedAt),
+		Terms:       terms,
+		DeletedAt:   timestamp(b.DeletedAt),
+	}
+}
+
+func bidTerms(m *tenderv1.BidTerms) service.BidTerms {
+	if m == nil {
+		return service.BidTerms{}
+	}
+	terms := service.BidTerms{
+		Amount:     m.Amount,
+		Currency:   m.Currency,
+		ValidFrom:  timeValue(m.ValidFrom),
+		ValidUntil: timeValue(m.ValidUntil),
+	}
+	if m.DeliveryDays != nil {
+		days := int(*m.DeliveryDays)
+		terms.DeliveryDays = &days
+	}
+	return terms
+}
+
+func sealedBidsMessage(s *service.SealedBids) *tenderv1.SealedBids {
+	msg := &tenderv1.SealedBids{Count: int32(s.Count)}
+	for _, t := range s.SubmittedAt {
+		msg.SubmittedAt = append(msg.SubmittedAt, timestamppb.New(t))
+	}
+	return msg
+}
+
+func withdrawalMessage(w domain.BidWithdrawal) *tenderv1.BidWithdrawal {
+	return &tenderv1.BidWithdrawal{
+		BidId:          w.BidID,
+		BidName:        w.BidName,
+		AuthorUsername: w.AuthorUsername,
+		Action:         w.Action,
+		Reason:         w.Reason,
+		Status:         w.Status,
+		Version:        int32(w.Version),
+		ChangedAt:      timestamppb.New(w.ChangedAt),
+	}
+}
@@ -0,0 +1,71 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// legacyForbidden — сообщения об отказе в доступе, которые часть сервисов пока возвращает
+// без сентинел-ошибок; HTTP-обработчики сравнивают их так же, по тексту.
+var legacyForbidden = map[string]bool{
+	"unauthorized": true,
+	"user is not authorized to view bids for this tender":               true,
+	"user is not authorized to view this bid status":                    true,
+	"доступ запрещен: пользователь не является сотрудником организации": true,
+	"пользователь не имеет прав на редактирование этой заявки":          true,
+}
+
+func invalidArgument(format string, args ...interface{}) error {
+	return status.Errorf(codes.InvalidArgument, format, args...)
+}
+
+// statusError переводит ошибку сервисного слоя в gRPC-статус по тем же правилам,
+// по которым HTTP-обработчики выбирают код ответа. Внутренние ошибки логируются,
+// а клиенту уходит только общее сообщение.
+func statusError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := errorCode(err)
+	if code == codes.Internal {
+		slog.ErrorContext(ctx, "grpc request failed", slog.Any("error", err))
+		return status.Error(codes.Internal, "internal server error")
+	}
+	return status.Error(code, err.Error())
+}
+
+func errorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, repository.ErrBidNotFound),
+		errors.Is(err, repository.ErrVersionNotFound), errors.Is(err, repository.ErrTemplateNotFound):
+		return codes.NotFound
+	case errors.Is(err, service.ErrDeadlineInPast), errors.Is(err, service.ErrUnknownServiceType),
+		errors.Is(err, service.ErrInvalidBidTerms), errors.Is(err, service.ErrInvalidWithdrawal),
+		errors.Is(err, service.ErrInvalidTemplate), errors.Is(err, service.ErrInvalidCriteria):
+		return codes.InvalidArgument
+	case errors.Is(err, service.ErrUnknownUser):
+		return codes.Unauthenticated
+	case errors.Is(err, service.ErrNotResponsible), errors.Is(err, service.ErrNotBidAuthor),
+		legacyForbidden[err.Error()]:
+		return codes.PermissionDenied
+	case errors.Is(err, service.ErrSealedTenderClosed), errors.Is(err, service.ErrSubmissionDeadlinePassed),
+		errors.Is(err, service.ErrBidsSealed), errors.Is(err, service.ErrBidAlreadyWithdrawn),
+		errors.Is(err, service.ErrBidNotWithdrawn), errors.Is(err, service.ErrBidTenderClosed),
+		errors.Is(err, repository.ErrAlreadyArchived), errors.Is(err, repository.ErrNotArchived),
+		errors.Is(err, service.ErrArchiveActive):
+		return codes.FailedPrecondition
+	case errors.Is(err, service.ErrSealingUnavailable):
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
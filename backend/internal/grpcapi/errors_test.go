@@ -0,0 +1,49 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorCode(t *testing.T) {
+	cases := []struct {
+		err  error
+		want codes.Code
+	}{
+		{&repository.Error{RequestID: "r1", Err: repository.ErrTenderNotFound}, codes.NotFound},
+		{fmt.Errorf("failed to get bid: %w", repository.ErrBidNotFound), codes.NotFound},
+		{fmt.Errorf("%w: amount must be positive", service.ErrInvalidBidTerms), codes.InvalidArgument},
+		{service.ErrUnknownUser, codes.Unauthenticated},
+		{service.ErrNotResponsible, codes.PermissionDenied},
+		{fmt.Errorf("unauthorized"), codes.PermissionDenied},
+		{fmt.Errorf("user is not authorized to view bids for this tender"), codes.PermissionDenied},
+		{service.ErrBidsSealed, codes.FailedPrecondition},
+		{repository.ErrAlreadyArchived, codes.FailedPrecondition},
+		{service.ErrSealingUnavailable, codes.Unavailable},
+		{errors.New("connection refused"), codes.Internal},
+	}
+	for _, c := range cases {
+		if got := errorCode(c.err); got != c.want {
+			t.Errorf("errorCode(%q) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+func TestStatusErrorHidesInternalErrors(t *testing.T) {
+	err := statusError(context.Background(), errors.New("pq: password authentication failed"))
+	if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != "internal server error" {
+		t.Fatalf("status = %v %q", st.Code(), st.Message())
+	}
+
+	invalid := invalidArgument("username is required")
+	if statusError(context.Background(), invalid) != invalid {
+		t.Fatal("status errors must pass through unchanged")
+	}
+}
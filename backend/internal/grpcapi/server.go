@@ -0,0 +1,91 @@
+// Package grpcapi — gRPC-обертка над TenderService и BidService для внутренних сервисов.
+// Контракт описан в proto/tender/v1, код в tenderv1 сгенерирован из него.
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"tender_srevice/internal/grpcapi/tenderv1"
+	"tender_srevice/internal/logger"
+	"tender_srevice/internal/middleware"
+	"tender_srevice/internal/service"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDKey — ключ метаданных с идентификатором запроса, аналог заголовка X-Request-ID.
+const requestIDKey = "x-request-id"
+
+// NewServer регистрирует TenderService и BidService на новом gRPC-сервере.
+func NewServer(tenders *service.TenderService, bids *service.BidService, withReflection bool) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptor),
+	)
+	tenderv1.RegisterTenderServiceServer(srv, NewTenderServer(tenders))
+	tenderv1.RegisterBidServiceServer(srv, NewBidServer(bids))
+	if withReflection {
+		reflection.Register(srv)
+	}
+	return srv
+}
+
+// unaryInterceptor и streamInterceptor делают то же, что middleware.RequestID и
+// middleware.AccessLog для HTTP.
+func unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx, id := withRequestID(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+	resp, err := handler(ctx, req)
+	logCall(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+func streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, id := withRequestID(ss.Context())
+	ss.SetHeader(metadata.Pairs(requestIDKey, id))
+	err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	logCall(ctx, info.FullMethod, start, err)
+	return err
+}
+
+func withRequestID(ctx context.Context) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	id = middleware.EnsureRequestID(id)
+	return logger.WithRequestID(ctx, id), id
+}
+
+// contextStream подменяет контекст потока, чтобы обработчик видел request ID.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		level = slog.LevelError
+	}
+	slog.Log(ctx, level, "grpc request",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
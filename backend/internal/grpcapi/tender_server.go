@@ -0,0 +1,182 @@
+package grpcapi
+
+import (
+	"context"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/grpcapi/tenderv1"
+	"tender_srevice/internal/service"
+)
+
+// TenderServer — gRPC-вариант TenderHandler: те же проверки запроса и те же сервисные вызовы.
+type TenderServer struct {
+	tenderv1.UnimplementedTenderServiceServer
+	service *service.TenderService
+}
+
+func NewTenderServer(service *service.TenderService) *TenderServer {
+	return &TenderServer{service: service}
+}
+
+func (s *TenderServer) CreateTender(ctx context.Context, req *tenderv1.CreateTenderRequest) (*tenderv1.Tender, error) {
+	createReq := service.CreateTenderRequest{
+		Name:               req.Name,
+		Description:        req.Description,
+		ServiceType:        req.ServiceType,
+		Status:             req.Status,
+		OrganizationID:     req.OrganizationId,
+		CreatorUsername:    req.CreatorUsername,
+		SubmissionDeadline: timeValue(req.SubmissionDeadline),
+		Sealed:             req.Sealed,
+	}
+
+	if req.TemplateId != "" {
+		if req.CreatorUsername == "" {
+			return nil, invalidArgument("creator_username is required")
+		}
+		tender, err := s.service.CreateTenderFromTemplate(ctx, service.TemplateTenderRequest{
+			CreateTenderRequest: createReq,
+			TemplateID:          req.TemplateId,
+			Placeholders:        req.Placeholders,
+		})
+		if err != nil {
+			return nil, statusError(ctx, err)
+		}
+		return tenderMessage(tender), nil
+	}
+
+	if req.Name == "" || req.ServiceType == "" || req.Status == "" || req.OrganizationId == "" || req.CreatorUsername == "" {
+		return nil, invalidArgument("name, service_type, status, organization_id and creator_username are required")
+	}
+	tender, err := s.service.CreateTender(ctx, createReq)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return tenderMessage(tender), nil
+}
+
+func (s *TenderServer) ListTenders(req *tenderv1.ListTendersRequest, stream tenderv1.TenderService_ListTendersServer) error {
+	tenders, err := s.service.GetTenders(stream.Context(), req.ServiceType)
+	if err != nil {
+		return statusError(stream.Context(), err)
+	}
+	return sendTenders(stream, tenders)
+}
+
+func (s *TenderServer) ListMyTenders(req *tenderv1.ListMyTendersRequest, stream tenderv1.TenderService_ListMyTendersServer) error {
+	if req.Username == "" {
+		return invalidArgument("username is required")
+	}
+	tenders, err := s.service.GetTendersByUsername(stream.Context(), req.Username)
+	if err != nil {
+		return statusError(stream.Context(), err)
+	}
+	return sendTenders(stream, tenders)
+}
+
+func (s *TenderServer) GetTenderStatus(ctx context.Context, req *tenderv1.GetTenderStatusRequest) (*tenderv1.TenderStatusResponse, error) {
+	if req.TenderId == "" {
+		return nil, invalidArgument("tender_id is required")
+	}
+	status, err := s.service.GetTenderStatus(ctx, req.TenderId, req.Username)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &tenderv1.TenderStatusResponse{Status: status}, nil
+}
+
+func (s *TenderServer) UpdateTenderStatus(ctx context.Context, req *tenderv1.UpdateTenderStatusRequest) (*tenderv1.Tender, error) {
+	switch req.Status {
+	case domain.TenderStatusCreated, domain.TenderStatusPublished, domain.TenderStatusClosed:
+	default:
+		return nil, invalidArgument("unsupported tender status %q", req.Status)
+	}
+	tender, err := s.service.UpdateTenderStatus(ctx, req.TenderId, req.Status, req.Username)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return tenderMessage(tender), nil
+}
+
+func (s *TenderServer) EditTender(ctx context.Context, req *tenderv1.EditTenderRequest) (*tenderv1.Tender, error) {
+	tender, err := s.service.UpdateTender(ctx, service.TenderUpdateRequest{
+		Username:           &req.Username,
+		TenderID:           &req.TenderId,
+		Name:               req.Name,
+		Description:        req.Description,
+		ServiceType:        req.ServiceType,
+		SubmissionDeadline: timeValue(req.SubmissionDeadline),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return tenderMessage(tender), nil
+}
+
+func (s *TenderServer) RollbackTender(ctx context.Context, req *tenderv1.RollbackTenderRequest) (*tenderv1.Tender, error) {
+	if req.TenderId == "" || req.Version < 1 || req.Username == "" {
+		return nil, invalidArgument("tender_id, version and username are required")
+	}
+	tender, err := s.service.RollbackTender(ctx, req.TenderId, int(req.Version), req.Username)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return tenderMessage(tender), nil
+}
+
+func (s *TenderServer) CloneTender(ctx context.Context, req *tenderv1.CloneTenderRequest) (*tenderv1.Tender, error) {
+	if req.Username == "" {
+		return nil, invalidArgument("username is required")
+	}
+	tender, err := s.service.CloneTender(ctx, req.TenderId, req.Username, service.CloneTenderRequest{
+		Name:               req.Name,
+		SubmissionDeadline: timeValue(req.SubmissionDeadline),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return tenderMessage(tender), nil
+}
+
+func (s *TenderServer) ArchiveTender(ctx context.Context, req *tenderv1.TenderActionRequest) (*tenderv1.Tender, error) {
+	return s.tenderAction(ctx, req, s.service.ArchiveTender)
+}
+
+func (s *TenderServer) RestoreTender(ctx context.Context, req *tenderv1.TenderActionRequest) (*tenderv1.Tender, error) {
+	return s.tenderAction(ctx, req, s.service.RestoreTender)
+}
+
+func (s *TenderServer) tenderAction(ctx context.Context, req *tenderv1.TenderActionRequest,
+	action func(ctx context.Context, tenderID, username string) (*domain.Tender, error)) (*tenderv1.Tender, error) {
+	if req.Username == "" {
+		return nil, invalidArgument("username is required")
+	}
+	tender, err := action(ctx, req.TenderId, req.Username)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return tenderMessage(tender), nil
+}
+
+func (s *TenderServer) ListArchivedTenders(req *tenderv1.ListArchivedTendersRequest, stream tenderv1.TenderService_ListArchivedTendersServer) error {
+	if req.Username == "" {
+		return invalidArgument("username is required")
+	}
+	tenders, err := s.service.ArchivedTenders(stream.Context(), req.Username)
+	if err != nil {
+		return statusError(stream.Context(), err)
+	}
+	return sendTenders(stream, tenders)
+}
+
+type tenderStream interface {
+	Send(*tenderv1.Tender) error
+}
+
+func sendTenders(stream tenderStream, tenders []*domain.Tender) error {
+	for _, t := range tenders {
+		if err := stream.Send(tenderMessage(t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
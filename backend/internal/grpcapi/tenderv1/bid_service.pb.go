@@ -0,0 +1,1111 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: tender/v1/bid_service.proto
+
+package tenderv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateBidRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string    `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	TenderId    string    `protobuf:"bytes,3,opt,name=tender_id,json=tenderId,proto3" json:"tender_id,omitempty"`
+	AuthorType  string    `protobuf:"bytes,4,opt,name=author_type,json=authorType,proto3" json:"author_type,omitempty"`
+	AuthorId    string    `protobuf:"bytes,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Terms       *BidTerms `protobuf:"bytes,6,opt,name=terms,proto3" json:"terms,omitempty"`
+}
+
+func (x *CreateBidRequest) Reset() {
+	*x = CreateBidRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateBidRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBidRequest) ProtoMessage() {}
+
+func (x *CreateBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBidRequest.ProtoReflect.Descriptor instead.
+func (*CreateBidRequest) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateBidRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateBidRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateBidRequest) GetTenderId() string {
+	if x != nil {
+		return x.TenderId
+	}
+	return ""
+}
+
+func (x *CreateBidRequest) GetAuthorType() string {
+	if x != nil {
+		return x.AuthorType
+	}
+	return ""
+}
+
+func (x *CreateBidRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateBidRequest) GetTerms() *BidTerms {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+type ListMyBidsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *ListMyBidsRequest) Reset() {
+	*x = ListMyBidsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMyBidsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyBidsRequest) ProtoMessage() {}
+
+func (x *ListMyBidsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyBidsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyBidsRequest) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListMyBidsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type ListTenderBidsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TenderId  string `protobuf:"bytes,1,opt,name=tender_id,json=tenderId,proto3" json:"tender_id,omitempty"`
+	Username  string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Currency  string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	MinAmount *int64 `protobuf:"varint,4,opt,name=min_amount,json=minAmount,proto3,oneof" json:"min_amount,omitempty"`
+	MaxAmount *int64 `protobuf:"varint,5,opt,name=max_amount,json=maxAmount,proto3,oneof" json:"max_amount,omitempty"`
+	// created (по умолчанию), price_asc или price_desc
+	Sort string `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+}
+
+func (x *ListTenderBidsRequest) Reset() {
+	*x = ListTenderBidsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTenderBidsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenderBidsRequest) ProtoMessage() {}
+
+func (x *ListTenderBidsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenderBidsRequest.ProtoReflect.Descriptor instead.
+func (*ListTenderBidsRequest) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTenderBidsRequest) GetTenderId() string {
+	if x != nil {
+		return x.TenderId
+	}
+	return ""
+}
+
+func (x *ListTenderBidsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ListTenderBidsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListTenderBidsRequest) GetMinAmount() int64 {
+	if x != nil && x.MinAmount != nil {
+		return *x.MinAmount
+	}
+	return 0
+}
+
+func (x *ListTenderBidsRequest) GetMaxAmount() int64 {
+	if x != nil && x.MaxAmount != nil {
+		return *x.MaxAmount
+	}
+	return 0
+}
+
+func (x *ListTenderBidsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// ListTenderBidsResponse — предложение или, у открытого запечатанного тендера,
+// единственное сообщение со сводкой.
+type ListTenderBidsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*ListTenderBidsResponse_Bid
+	//	*ListTenderBidsResponse_Sealed
+	Result isListTenderBidsResponse_Result `protobuf_oneof:"result"`
+}
+
+func (x *ListTenderBidsResponse) Reset() {
+	*x = ListTenderBidsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTenderBidsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenderBidsResponse) ProtoMessage() {}
+
+func (x *ListTenderBidsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenderBidsResponse.ProtoReflect.Descriptor instead.
+func (*ListTenderBidsResponse) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (m *ListTenderBidsResponse) GetResult() isListTenderBidsResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *ListTenderBidsResponse) GetBid() *Bid {
+	if x, ok := x.GetResult().(*ListTenderBidsResponse_Bid); ok {
+		return x.Bid
+	}
+	return nil
+}
+
+func (x *ListTenderBidsResponse) GetSealed() *SealedBids {
+	if x, ok := x.GetResult().(*ListTenderBidsResponse_Sealed); ok {
+		return x.Sealed
+	}
+	return nil
+}
+
+type isListTenderBidsResponse_Result interface {
+	isListTenderBidsResponse_Result()
+}
+
+type ListTenderBidsResponse_Bid struct {
+	Bid *Bid `protobuf:"bytes,1,opt,name=bid,proto3,oneof"`
+}
+
+type ListTenderBidsResponse_Sealed struct {
+	Sealed *SealedBids `protobuf:"bytes,2,opt,name=sealed,proto3,oneof"`
+}
+
+func (*ListTenderBidsResponse_Bid) isListTenderBidsResponse_Result() {}
+
+func (*ListTenderBidsResponse_Sealed) isListTenderBidsResponse_Result() {}
+
+type BidActionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BidId    string `protobuf:"bytes,1,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *BidActionRequest) Reset() {
+	*x = BidActionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BidActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BidActionRequest) ProtoMessage() {}
+
+func (x *BidActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BidActionRequest.ProtoReflect.Descriptor instead.
+func (*BidActionRequest) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BidActionRequest) GetBidId() string {
+	if x != nil {
+		return x.BidId
+	}
+	return ""
+}
+
+func (x *BidActionRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type BidStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *BidStatusResponse) Reset() {
+	*x = BidStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BidStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BidStatusResponse) ProtoMessage() {}
+
+func (x *BidStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BidStatusResponse.ProtoReflect.Descriptor instead.
+func (*BidStatusResponse) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BidStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type UpdateBidStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BidId    string `protobuf:"bytes,1,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Status   string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *UpdateBidStatusRequest) Reset() {
+	*x = UpdateBidStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBidStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBidStatusRequest) ProtoMessage() {}
+
+func (x *UpdateBidStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBidStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBidStatusRequest) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateBidStatusRequest) GetBidId() string {
+	if x != nil {
+		return x.BidId
+	}
+	return ""
+}
+
+func (x *UpdateBidStatusRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UpdateBidStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type EditBidRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BidId       string    `protobuf:"bytes,1,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"`
+	Username    string    `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Name        string    `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string    `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Terms       *BidTerms `protobuf:"bytes,5,opt,name=terms,proto3" json:"terms,omitempty"`
+}
+
+func (x *EditBidRequest) Reset() {
+	*x = EditBidRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditBidRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditBidRequest) ProtoMessage() {}
+
+func (x *EditBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditBidRequest.ProtoReflect.Descriptor instead.
+func (*EditBidRequest) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EditBidRequest) GetBidId() string {
+	if x != nil {
+		return x.BidId
+	}
+	return ""
+}
+
+func (x *EditBidRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *EditBidRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EditBidRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EditBidRequest) GetTerms() *BidTerms {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+type BidReasonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BidId    string `protobuf:"bytes,1,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Reason   string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *BidReasonRequest) Reset() {
+	*x = BidReasonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BidReasonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BidReasonRequest) ProtoMessage() {}
+
+func (x *BidReasonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BidReasonRequest.ProtoReflect.Descriptor instead.
+func (*BidReasonRequest) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BidReasonRequest) GetBidId() string {
+	if x != nil {
+		return x.BidId
+	}
+	return ""
+}
+
+func (x *BidReasonRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *BidReasonRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListBidWithdrawalsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TenderId string `protobuf:"bytes,1,opt,name=tender_id,json=tenderId,proto3" json:"tender_id,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *ListBidWithdrawalsRequest) Reset() {
+	*x = ListBidWithdrawalsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBidWithdrawalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBidWithdrawalsRequest) ProtoMessage() {}
+
+func (x *ListBidWithdrawalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBidWithdrawalsRequest.ProtoReflect.Descriptor instead.
+func (*ListBidWithdrawalsRequest) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListBidWithdrawalsRequest) GetTenderId() string {
+	if x != nil {
+		return x.TenderId
+	}
+	return ""
+}
+
+func (x *ListBidWithdrawalsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type ListArchivedBidsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *ListArchivedBidsRequest) Reset() {
+	*x = ListArchivedBidsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tender_v1_bid_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListArchivedBidsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedBidsRequest) ProtoMessage() {}
+
+func (x *ListArchivedBidsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tender_v1_bid_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedBidsRequest.ProtoReflect.Descriptor instead.
+func (*ListArchivedBidsRequest) Descriptor() ([]byte, []int) {
+	return file_tender_v1_bid_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListArchivedBidsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+var File_tender_v1_bid_service_proto protoreflect.FileDescriptor
+
+var file_tender_v1_bid_service_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x64, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x31,
+	0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xce, 0x01, 0x0a,
+	0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x69, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x49, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69,
+	0x64, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x22, 0x2f, 0x0a,
+	0x11, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x79, 0x42, 0x69, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xe6,
+	0x01, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x42, 0x69, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x22, 0x0a,
+	0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x48, 0x00, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01,
+	0x01, 0x12, 0x22, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x69,
+	0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x61, 0x78,
+	0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x77, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x42, 0x69, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x22, 0x0a, 0x03, 0x62, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x48, 0x00,
+	0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x42, 0x69, 0x64, 0x73, 0x48, 0x00, 0x52, 0x06,
+	0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0x45, 0x0a, 0x10, 0x42, 0x69, 0x64, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x69, 0x64, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2b, 0x0a, 0x11, 0x42, 0x69, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x63, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x69,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x62, 0x69, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x62, 0x69, 0x64, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x0e, 0x45, 0x64,
+	0x69, 0x74, 0x42, 0x69, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06,
+	0x62, 0x69, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x69,
+	0x64, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x69, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73,
+	0x22, 0x5d, 0x0a, 0x10, 0x42, 0x69, 0x64, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x69, 0x64, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x54, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x69, 0x64, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72,
+	0x61, 0x77, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x35, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x64, 0x42, 0x69, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x32, 0xc4, 0x06, 0x0a,
+	0x0a, 0x42, 0x69, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x69, 0x64, 0x12, 0x1b, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x69, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x69, 0x64, 0x12, 0x3c, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x79, 0x42,
+	0x69, 0x64, 0x73, 0x12, 0x1c, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x4d, 0x79, 0x42, 0x69, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69,
+	0x64, 0x30, 0x01, 0x12, 0x57, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x42, 0x69, 0x64, 0x73, 0x12, 0x20, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x42, 0x69, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x42, 0x69,
+	0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x49, 0x0a, 0x0c,
+	0x47, 0x65, 0x74, 0x42, 0x69, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x2e, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x42, 0x69, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x2e, 0x74, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x69, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x07, 0x45, 0x64, 0x69, 0x74, 0x42, 0x69, 0x64,
+	0x12, 0x19, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x64, 0x69,
+	0x74, 0x42, 0x69, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x3a, 0x0a, 0x0b, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x42,
+	0x69, 0x64, 0x12, 0x1b, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x69, 0x64, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0e, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x12,
+	0x3a, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x69, 0x64, 0x12, 0x1b,
+	0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x12, 0x56, 0x0a, 0x12, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x69, 0x64, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c,
+	0x73, 0x12, 0x24, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x42, 0x69, 0x64, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61,
+	0x6c, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x0a, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x42, 0x69,
+	0x64, 0x12, 0x1b, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69,
+	0x64, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e,
+	0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x12, 0x39,
+	0x0a, 0x0a, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x69, 0x64, 0x12, 0x1b, 0x2e, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x12, 0x48, 0x0a, 0x10, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x42, 0x69, 0x64, 0x73, 0x12, 0x22, 0x2e,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x42, 0x69, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69,
+	0x64, 0x30, 0x01, 0x42, 0x33, 0x5a, 0x31, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x73, 0x72,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67,
+	0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x2f, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x76, 0x31, 0x3b,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tender_v1_bid_service_proto_rawDescOnce sync.Once
+	file_tender_v1_bid_service_proto_rawDescData = file_tender_v1_bid_service_proto_rawDesc
+)
+
+func file_tender_v1_bid_service_proto_rawDescGZIP() []byte {
+	file_tender_v1_bid_service_proto_rawDescOnce.Do(func() {
+		file_tender_v1_bid_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_tender_v1_bid_service_proto_rawDescData)
+	})
+	return file_tender_v1_bid_service_proto_rawDescData
+}
+
+var file_tender_v1_bid_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_tender_v1_bid_service_proto_goTypes = []any{
+	(*CreateBidRequest)(nil),          // 0: tender.v1.CreateBidRequest
+	(*ListMyBidsRequest)(nil),         // 1: tender.v1.ListMyBidsRequest
+	(*ListTenderBidsRequest)(nil),     // 2: tender.v1.ListTenderBidsRequest
+	(*ListTenderBidsResponse)(nil),    // 3: tender.v1.ListTenderBidsResponse
+	(*BidActionRequest)(nil),          // 4: tender.v1.BidActionRequest
+	(*BidStatusResponse)(nil),         // 5: tender.v1.BidStatusResponse
+	(*UpdateBidStatusRequest)(nil),    // 6: tender.v1.UpdateBidStatusRequest
+	(*EditBidRequest)(nil),            // 7: tender.v1.EditBidRequest
+	(*BidReasonRequest)(nil),          // 8: tender.v1.BidReasonRequest
+	(*ListBidWithdrawalsRequest)(nil), // 9: tender.v1.ListBidWithdrawalsRequest
+	(*ListArchivedBidsRequest)(nil),   // 10: tender.v1.ListArchivedBidsRequest
+	(*BidTerms)(nil),                  // 11: tender.v1.BidTerms
+	(*Bid)(nil),                       // 12: tender.v1.Bid
+	(*SealedBids)(nil),                // 13: tender.v1.SealedBids
+	(*emptypb.Empty)(nil),             // 14: google.protobuf.Empty
+	(*BidWithdrawal)(nil),             // 15: tender.v1.BidWithdrawal
+}
+var file_tender_v1_bid_service_proto_depIdxs = []int32{
+	11, // 0: tender.v1.CreateBidRequest.terms:type_name -> tender.v1.BidTerms
+	12, // 1: tender.v1.ListTenderBidsResponse.bid:type_name -> tender.v1.Bid
+	13, // 2: tender.v1.ListTenderBidsResponse.sealed:type_name -> tender.v1.SealedBids
+	11, // 3: tender.v1.EditBidRequest.terms:type_name -> tender.v1.BidTerms
+	0,  // 4: tender.v1.BidService.CreateBid:input_type -> tender.v1.CreateBidRequest
+	1,  // 5: tender.v1.BidService.ListMyBids:input_type -> tender.v1.ListMyBidsRequest
+	2,  // 6: tender.v1.BidService.ListTenderBids:input_type -> tender.v1.ListTenderBidsRequest
+	4,  // 7: tender.v1.BidService.GetBidStatus:input_type -> tender.v1.BidActionRequest
+	6,  // 8: tender.v1.BidService.UpdateBidStatus:input_type -> tender.v1.UpdateBidStatusRequest
+	7,  // 9: tender.v1.BidService.EditBid:input_type -> tender.v1.EditBidRequest
+	8,  // 10: tender.v1.BidService.WithdrawBid:input_type -> tender.v1.BidReasonRequest
+	8,  // 11: tender.v1.BidService.ResubmitBid:input_type -> tender.v1.BidReasonRequest
+	9,  // 12: tender.v1.BidService.ListBidWithdrawals:input_type -> tender.v1.ListBidWithdrawalsRequest
+	4,  // 13: tender.v1.BidService.ArchiveBid:input_type -> tender.v1.BidActionRequest
+	4,  // 14: tender.v1.BidService.RestoreBid:input_type -> tender.v1.BidActionRequest
+	10, // 15: tender.v1.BidService.ListArchivedBids:input_type -> tender.v1.ListArchivedBidsRequest
+	12, // 16: tender.v1.BidService.CreateBid:output_type -> tender.v1.Bid
+	12, // 17: tender.v1.BidService.ListMyBids:output_type -> tender.v1.Bid
+	3,  // 18: tender.v1.BidService.ListTenderBids:output_type -> tender.v1.ListTenderBidsResponse
+	5,  // 19: tender.v1.BidService.GetBidStatus:output_type -> tender.v1.BidStatusResponse
+	14, // 20: tender.v1.BidService.UpdateBidStatus:output_type -> google.protobuf.Empty
+	14, // 21: tender.v1.BidService.EditBid:output_type -> google.protobuf.Empty
+	12, // 22: tender.v1.BidService.WithdrawBid:output_type -> tender.v1.Bid
+	12, // 23: tender.v1.BidService.ResubmitBid:output_type -> tender.v1.Bid
+	15, // 24: tender.v1.BidService.ListBidWithdrawals:output_type -> tender.v1.BidWithdrawal
+	12, // 25: tender.v1.BidService.ArchiveBid:output_type -> tender.v1.Bid
+	12, // 26: tender.v1.BidService.RestoreBid:output_type -> tender.v1.Bid
+	12, // 27: tender.v1.BidService.ListArchivedBids:output_type -> tender.v1.Bid
+	16, // [16:28] is the sub-list for method output_type
+	4,  // [4:16] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_tender_v1_bid_service_proto_init() }
+func file_tender_v1_bid_service_proto_init() {
+	if File_tender_v1_bid_service_proto != nil {
+		return
+	}
+	file_tender_v1_types_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_tender_v1_bid_service_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateBidRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListMyBidsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTenderBidsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTenderBidsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*BidActionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*BidStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateBidStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*EditBidRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*BidReasonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ListBidWithdrawalsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tender_v1_bid_service_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ListArchivedBidsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_tender_v1_bid_service_proto_msgTypes[2].OneofWrappers = []any{}
+	file_tender_v1_bid_service_proto_msgTypes[3].OneofWrappers = []any{
+		(*ListTenderBidsResponse_Bid)(nil),
+		(*ListTenderBidsResponse_Sealed)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tender_v1_bid_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tender_v1_bid_service_proto_goTypes,
+		DependencyIndexes: file_tender_v1_bid_service_proto_depIdxs,
+		MessageInfos:      file_tender_v1_bid_service_proto_msgTypes,
+	}.Build()
+	File_tender_v1_bid_service_proto = out.File
+	file_tender_v1_bid_service_proto_rawDesc = nil
+	file_tender_v1_bid_service_proto_goTypes = nil
+	file_tender_v1_bid_service_proto_depIdxs = nil
+}
@@ -0,0 +1,642 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: tender/v1/bid_service.proto
+
+package tenderv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	BidService_CreateBid_FullMethodName          = "/tender.v1.BidService/CreateBid"
+	BidService_ListMyBids_FullMethodName         = "/tender.v1.BidService/ListMyBids"
+	BidService_ListTenderBids_FullMethodName     = "/tender.v1.BidService/ListTenderBids"
+	BidService_GetBidStatus_FullMethodName       = "/tender.v1.BidService/GetBidStatus"
+	BidService_UpdateBidStatus_FullMethodName    = "/tender.v1.BidService/UpdateBidStatus"
+	BidService_EditBid_FullMethodName            = "/tender.v1.BidService/EditBid"
+	BidService_WithdrawBid_FullMethodName        = "/tender.v1.BidService/WithdrawBid"
+	BidService_ResubmitBid_FullMethodName        = "/tender.v1.BidService/ResubmitBid"
+	BidService_ListBidWithdrawals_FullMethodName = "/tender.v1.BidService/ListBidWithdrawals"
+	BidService_ArchiveBid_FullMethodName         = "/tender.v1.BidService/ArchiveBid"
+	BidService_RestoreBid_FullMethodName         = "/tender.v1.BidService/RestoreBid"
+	BidService_ListArchivedBids_FullMethodName   = "/tender.v1.BidService/ListArchivedBids"
+)
+
+// BidServiceClient is the client API for BidService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BidService — операции с предложениями, те же, что у /api/bids.
+type BidServiceClient interface {
+	CreateBid(ctx context.Context, in *CreateBidRequest, opts ...grpc.CallOption) (*Bid, error)
+	ListMyBids(ctx context.Context, in *ListMyBidsRequest, opts ...grpc.CallOption) (BidService_ListMyBidsClient, error)
+	ListTenderBids(ctx context.Context, in *ListTenderBidsRequest, opts ...grpc.CallOption) (BidService_ListTenderBidsClient, error)
+	GetBidStatus(ctx context.Context, in *BidActionRequest, opts ...grpc.CallOption) (*BidStatusResponse, error)
+	UpdateBidStatus(ctx context.Context, in *UpdateBidStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	EditBid(ctx context.Context, in *EditBidRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	WithdrawBid(ctx context.Context, in *BidReasonRequest, opts ...grpc.CallOption) (*Bid, error)
+	ResubmitBid(ctx context.Context, in *BidReasonRequest, opts ...grpc.CallOption) (*Bid, error)
+	ListBidWithdrawals(ctx context.Context, in *ListBidWithdrawalsRequest, opts ...grpc.CallOption) (BidService_ListBidWithdrawalsClient, error)
+	ArchiveBid(ctx context.Context, in *BidActionRequest, opts ...grpc.CallOption) (*Bid, error)
+	RestoreBid(ctx context.Context, in *BidActionRequest, opts ...grpc.CallOption) (*Bid, error)
+	ListArchivedBids(ctx context.Context, in *ListArchivedBidsRequest, opts ...grpc.CallOption) (BidService_ListArchivedBidsClient, error)
+}
+
+type bidServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBidServiceClient(cc grpc.ClientConnInterface) BidServiceClient {
+	return &bidServiceClient{cc}
+}
+
+func (c *bidServiceClient) CreateBid(ctx context.Context, in *CreateBidRequest, opts ...grpc.CallOption) (*Bid, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bid)
+	err := c.cc.Invoke(ctx, BidService_CreateBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) ListMyBids(ctx context.Context, in *ListMyBidsRequest, opts ...grpc.CallOption) (BidService_ListMyBidsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BidService_ServiceDesc.Streams[0], BidService_ListMyBids_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bidServiceListMyBidsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BidService_ListMyBidsClient interface {
+	Recv() (*Bid, error)
+	grpc.ClientStream
+}
+
+type bidServiceListMyBidsClient struct {
+	grpc.ClientStream
+}
+
+func (x *bidServiceListMyBidsClient) Recv() (*Bid, error) {
+	m := new(Bid)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bidServiceClient) ListTenderBids(ctx context.Context, in *ListTenderBidsRequest, opts ...grpc.CallOption) (BidService_ListTenderBidsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BidService_ServiceDesc.Streams[1], BidService_ListTenderBids_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bidServiceListTenderBidsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BidService_ListTenderBidsClient interface {
+	Recv() (*ListTenderBidsResponse, error)
+	grpc.ClientStream
+}
+
+type bidServiceListTenderBidsClient struct {
+	grpc.ClientStream
+}
+
+func (x *bidServiceListTenderBidsClient) Recv() (*ListTenderBidsResponse, error) {
+	m := new(ListTenderBidsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bidServiceClient) GetBidStatus(ctx context.Context, in *BidActionRequest, opts ...grpc.CallOption) (*BidStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BidStatusResponse)
+	err := c.cc.Invoke(ctx, BidService_GetBidStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) UpdateBidStatus(ctx context.Context, in *UpdateBidStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BidService_UpdateBidStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) EditBid(ctx context.Context, in *EditBidRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BidService_EditBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) WithdrawBid(ctx context.Context, in *BidReasonRequest, opts ...grpc.CallOption) (*Bid, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bid)
+	err := c.cc.Invoke(ctx, BidService_WithdrawBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) ResubmitBid(ctx context.Context, in *BidReasonRequest, opts ...grpc.CallOption) (*Bid, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bid)
+	err := c.cc.Invoke(ctx, BidService_ResubmitBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) ListBidWithdrawals(ctx context.Context, in *ListBidWithdrawalsRequest, opts ...grpc.CallOption) (BidService_ListBidWithdrawalsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BidService_ServiceDesc.Streams[2], BidService_ListBidWithdrawals_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bidServiceListBidWithdrawalsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BidService_ListBidWithdrawalsClient interface {
+	Recv() (*BidWithdrawal, error)
+	grpc.ClientStream
+}
+
+type bidServiceListBidWithdrawalsClient struct {
+	grpc.ClientStream
+}
+
+func (x *bidServiceListBidWithdrawalsClient) Recv() (*BidWithdrawal, error) {
+	m := new(BidWithdrawal)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bidServiceClient) ArchiveBid(ctx context.Context, in *BidActionRequest, opts ...grpc.CallOption) (*Bid, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bid)
+	err := c.cc.Invoke(ctx, BidService_ArchiveBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) RestoreBid(ctx context.Context, in *BidActionRequest, opts ...grpc.CallOption) (*Bid, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bid)
+	err := c.cc.Invoke(ctx, BidService_RestoreBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) ListArchivedBids(ctx context.Context, in *ListArchivedBidsRequest, opts ...grpc.CallOption) (BidService_ListArchivedBidsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BidService_ServiceDesc.Streams[3], BidService_ListArchivedBids_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bidServiceListArchivedBidsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BidService_ListArchivedBidsClient interface {
+	Recv() (*Bid, error)
+	grpc.ClientStream
+}
+
+type bidServiceListArchivedBidsClient struct {
+	grpc.ClientStream
+}
+
+func (x *bidServiceListArchivedBidsClient) Recv() (*Bid, error) {
+	m := new(Bid)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BidServiceServer is the server API for BidService service.
+// All implementations must embed UnimplementedBidServiceServer
+// for forward compatibility
+//
+// BidService — операции с предложениями, те же, что у /api/bids.
+type BidServiceServer interface {
+	CreateBid(context.Context, *CreateBidRequest) (*Bid, error)
+	ListMyBids(*ListMyBidsRequest, BidService_ListMyBidsServer) error
+	ListTenderBids(*ListTenderBidsRequest, BidService_ListTenderBidsServer) error
+	GetBidStatus(context.Context, *BidActionRequest) (*BidStatusResponse, error)
+	UpdateBidStatus(context.Context, *UpdateBidStatusRequest) (*emptypb.Empty, error)
+	EditBid(context.Context, *EditBidRequest) (*emptypb.Empty, error)
+	WithdrawBid(context.Context, *BidReasonRequest) (*Bid, error)
+	ResubmitBid(context.Context, *BidReasonRequest) (*Bid, error)
+	ListBidWithdrawals(*ListBidWithdrawalsRequest, BidService_ListBidWithdrawalsServer) error
+	ArchiveBid(context.Context, *BidActionRequest) (*Bid, error)
+	RestoreBid(context.Context, *BidActionRequest) (*Bid, error)
+	ListArchivedBids(*ListArchivedBidsRequest, BidService_ListArchivedBidsServer) error
+	mustEmbedUnimplementedBidServiceServer()
+}
+
+// UnimplementedBidServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBidServiceServer struct {
+}
+
+func (UnimplementedBidServiceServer) CreateBid(context.Context, *CreateBidRequest) (*Bid, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBid not implemented")
+}
+func (UnimplementedBidServiceServer) ListMyBids(*ListMyBidsRequest, BidService_ListMyBidsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListMyBids not implemented")
+}
+func (UnimplementedBidServiceServer) ListTenderBids(*ListTenderBidsRequest, BidService_ListTenderBidsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTenderBids not implemented")
+}
+func (UnimplementedBidServiceServer) GetBidStatus(context.Context, *BidActionRequest) (*BidStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBidStatus not implemented")
+}
+func (UnimplementedBidServiceServer) UpdateBidStatus(context.Context, *UpdateBidStatusRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBidStatus not implemented")
+}
+func (UnimplementedBidServiceServer) EditBid(context.Context, *EditBidRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditBid not implemented")
+}
+func (UnimplementedBidServiceServer) WithdrawBid(context.Context, *BidReasonRequest) (*Bid, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawBid not implemented")
+}
+func (UnimplementedBidServiceServer) ResubmitBid(context.Context, *BidReasonRequest) (*Bid, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResubmitBid not implemented")
+}
+func (UnimplementedBidServiceServer) ListBidWithdrawals(*ListBidWithdrawalsRequest, BidService_ListBidWithdrawalsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListBidWithdrawals not implemented")
+}
+func (UnimplementedBidServiceServer) ArchiveBid(context.Context, *BidActionRequest) (*Bid, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveBid not implemented")
+}
+func (UnimplementedBidServiceServer) RestoreBid(context.Context, *BidActionRequest) (*Bid, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreBid not implemented")
+}
+func (UnimplementedBidServiceServer) ListArchivedBids(*ListArchivedBidsRequest, BidService_ListArchivedBidsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListArchivedBids not implemented")
+}
+func (UnimplementedBidServiceServer) mustEmbedUnimplementedBidServiceServer() {}
+
+// UnsafeBidServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BidServiceServer will
+// result in compilation errors.
+type UnsafeBidServiceServer interface {
+	mustEmbedUnimplementedBidServiceServer()
+}
+
+func RegisterBidServiceServer(s grpc.ServiceRegistrar, srv BidServiceServer) {
+	s.RegisterService(&BidService_ServiceDesc, srv)
+}
+
+func _BidService_CreateBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).CreateBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_CreateBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).CreateBid(ctx, req.(*CreateBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_ListMyBids_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMyBidsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BidServiceServer).ListMyBids(m, &bidServiceListMyBidsServer{ServerStream: stream})
+}
+
+type BidService_ListMyBidsServer interface {
+	Send(*Bid) error
+	grpc.ServerStream
+}
+
+type bidServiceListMyBidsServer struct {
+	grpc.ServerStream
+}
+
+func (x *bidServiceListMyBidsServer) Send(m *Bid) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BidService_ListTenderBids_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTenderBidsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BidServiceServer).ListTenderBids(m, &bidServiceListTenderBidsServer{ServerStream: stream})
+}
+
+type BidService_ListTenderBidsServer interface {
+	Send(*ListTenderBidsResponse) error
+	grpc.ServerStream
+}
+
+type bidServiceListTenderBidsServer struct {
+	grpc.ServerStream
+}
+
+func (x *bidServiceListTenderBidsServer) Send(m *ListTenderBidsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BidService_GetBidStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BidActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).GetBidStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_GetBidStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).GetBidStatus(ctx, req.(*BidActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_UpdateBidStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBidStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).UpdateBidStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_UpdateBidStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).UpdateBidStatus(ctx, req.(*UpdateBidStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_EditBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).EditBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_EditBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).EditBid(ctx, req.(*EditBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_WithdrawBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BidReasonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).WithdrawBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_WithdrawBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).WithdrawBid(ctx, req.(*BidReasonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_ResubmitBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BidReasonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).ResubmitBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_ResubmitBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).ResubmitBid(ctx, req.(*BidReasonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_ListBidWithdrawals_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBidWithdrawalsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BidServiceServer).ListBidWithdrawals(m, &bidServiceListBidWithdrawalsServer{ServerStream: stream})
+}
+
+type BidService_ListBidWithdrawalsServer interface {
+	Send(*BidWithdrawal) error
+	grpc.ServerStream
+}
+
+type bidServiceListBidWithdrawalsServer struct {
+	grpc.ServerStream
+}
+
+func (x *bidServiceListBidWithdrawalsServer) Send(m *BidWithdrawal) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BidService_ArchiveBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BidActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).ArchiveBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_ArchiveBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).ArchiveBid(ctx, req.(*BidActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_RestoreBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BidActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).RestoreBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_RestoreBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).RestoreBid(ctx, req.(*BidActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_ListArchivedBids_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListArchivedBidsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BidServiceServer).ListArchivedBids(m, &bidServiceListArchivedBidsServer{ServerStream: stream})
+}
+
+type BidService_ListArchivedBidsServer interface {
+	Send(*Bid) error
+	grpc.ServerStream
+}
+
+type bidServiceListArchivedBidsServer struct {
+	grpc.ServerStream
+}
+
+func (x *bidServiceListArchivedBidsServer) Send(m *Bid) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// BidService_ServiceDesc is the grpc.ServiceDesc for BidService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BidService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tender.v1.BidService",
+	HandlerType: (*BidServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBid",
+			Handler:    _BidService_CreateBid_Handler,
+		},
+		{
+			MethodName: "GetBidStatus",
+			Handler:    _BidService_GetBidStatus_Handler,
+		},
+		{
+			MethodName: "UpdateBidStatus",
+			Handler:    _BidService_UpdateBidStatus_Handler,
+		},
+		{
+			MethodName: "EditBid",
+			Handler:    _BidService_EditBid_Handler,
+		},
+		{
+			MethodName: "WithdrawBid",
+			Handler:    _BidService_WithdrawBid_Handler,
+		},
+		{
+			MethodName: "ResubmitBid",
+			Handler:    _BidService_ResubmitBid_Handler,
+		},
+		{
+			MethodName: "ArchiveBid",
+			Handler:    _BidService_ArchiveBid_Handler,
+		},
+		{
+			MethodName: "RestoreBid",
+			Handler:    _BidService_RestoreBid_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListMyBids",
+			Handler:       _BidService_ListMyBids_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTenderBids",
+			Handler:       _BidService_ListTenderBids_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListBidWithdrawals",
+			Handler:       _BidService_ListBidWithdrawals_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListArchivedBids",
+			Handler:       _BidService_ListArchivedBids_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tender/v1/bid_service.proto",
+}
@@ -0,0 +1,578 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: tender/v1/tender_service.proto
+
+package tenderv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	TenderService_CreateTender_FullMethodName        = "/tender.v1.TenderService/CreateTender"
+	TenderService_ListTenders_FullMethodName         = "/tender.v1.TenderService/ListTenders"
+	TenderService_ListMyTenders_FullMethodName       = "/tender.v1.TenderService/ListMyTenders"
+	TenderService_GetTenderStatus_FullMethodName     = "/tender.v1.TenderService/GetTenderStatus"
+	TenderService_UpdateTenderStatus_FullMethodName  = "/tender.v1.TenderService/UpdateTenderStatus"
+	TenderService_EditTender_FullMethodName          = "/tender.v1.TenderService/EditTender"
+	TenderService_RollbackTender_FullMethodName      = "/tender.v1.TenderService/RollbackTender"
+	TenderService_CloneTender_FullMethodName         = "/tender.v1.TenderService/CloneTender"
+	TenderService_ArchiveTender_FullMethodName       = "/tender.v1.TenderService/ArchiveTender"
+	TenderService_RestoreTender_FullMethodName       = "/tender.v1.TenderService/RestoreTender"
+	TenderService_ListArchivedTenders_FullMethodName = "/tender.v1.TenderService/ListArchivedTenders"
+)
+
+// TenderServiceClient is the client API for TenderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TenderService — операции с тендерами, те же, что у /api/tenders. Как и в REST,
+// пользователь передается полем username; списки возвращаются потоком.
+type TenderServiceClient interface {
+	CreateTender(ctx context.Context, in *CreateTenderRequest, opts ...grpc.CallOption) (*Tender, error)
+	ListTenders(ctx context.Context, in *ListTendersRequest, opts ...grpc.CallOption) (TenderService_ListTendersClient, error)
+	ListMyTenders(ctx context.Context, in *ListMyTendersRequest, opts ...grpc.CallOption) (TenderService_ListMyTendersClient, error)
+	GetTenderStatus(ctx context.Context, in *GetTenderStatusRequest, opts ...grpc.CallOption) (*TenderStatusResponse, error)
+	UpdateTenderStatus(ctx context.Context, in *UpdateTenderStatusRequest, opts ...grpc.CallOption) (*Tender, error)
+	EditTender(ctx context.Context, in *EditTenderRequest, opts ...grpc.CallOption) (*Tender, error)
+	RollbackTender(ctx context.Context, in *RollbackTenderRequest, opts ...grpc.CallOption) (*Tender, error)
+	CloneTender(ctx context.Context, in *CloneTenderRequest, opts ...grpc.CallOption) (*Tender, error)
+	ArchiveTender(ctx context.Context, in *TenderActionRequest, opts ...grpc.CallOption) (*Tender, error)
+	RestoreTender(ctx context.Context, in *TenderActionRequest, opts ...grpc.CallOption) (*Tender, error)
+	ListArchivedTenders(ctx context.Context, in *ListArchivedTendersRequest, opts ...grpc.CallOption) (TenderService_ListArchivedTendersClient, error)
+}
+
+type tenderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTenderServiceClient(cc grpc.ClientConnInterface) TenderServiceClient {
+	return &tenderServiceClient{cc}
+}
+
+func (c *tenderServiceClient) CreateTender(ctx context.Context, in *CreateTenderRequest, opts ...grpc.CallOption) (*Tender, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tender)
+	err := c.cc.Invoke(ctx, TenderService_CreateTender_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenderServiceClient) ListTenders(ctx context.Context, in *ListTendersRequest, opts ...grpc.CallOption) (TenderService_ListTendersClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TenderService_ServiceDesc.Streams[0], TenderService_ListTenders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tenderServiceListTendersClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TenderService_ListTendersClient interface {
+	Recv() (*Tender, error)
+	grpc.ClientStream
+}
+
+type tenderServiceListTendersClient struct {
+	grpc.ClientStream
+}
+
+func (x *tenderServiceListTendersClient) Recv() (*Tender, error) {
+	m := new(Tender)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tenderServiceClient) ListMyTenders(ctx context.Context, in *ListMyTendersRequest, opts ...grpc.CallOption) (TenderService_ListMyTendersClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TenderService_ServiceDesc.Streams[1], TenderService_ListMyTenders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tenderServiceListMyTendersClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TenderService_ListMyTendersClient interface {
+	Recv() (*Tender, error)
+	grpc.ClientStream
+}
+
+type tenderServiceListMyTendersClient struct {
+	grpc.ClientStream
+}
+
+func (x *tenderServiceListMyTendersClient) Recv() (*Tender, error) {
+	m := new(Tender)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tenderServiceClient) GetTenderStatus(ctx context.Context, in *GetTenderStatusRequest, opts ...grpc.CallOption) (*TenderStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TenderStatusResponse)
+	err := c.cc.Invoke(ctx, TenderService_GetTenderStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenderServiceClient) UpdateTenderStatus(ctx context.Context, in *UpdateTenderStatusRequest, opts ...grpc.CallOption) (*Tender, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tender)
+	err := c.cc.Invoke(ctx, TenderService_UpdateTenderStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenderServiceClient) EditTender(ctx context.Context, in *EditTenderRequest, opts ...grpc.CallOption) (*Tender, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tender)
+	err := c.cc.Invoke(ctx, TenderService_EditTender_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenderServiceClient) RollbackTender(ctx context.Context, in *RollbackTenderRequest, opts ...grpc.CallOption) (*Tender, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tender)
+	err := c.cc.Invoke(ctx, TenderService_RollbackTender_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenderServiceClient) CloneTender(ctx context.Context, in *CloneTenderRequest, opts ...grpc.CallOption) (*Tender, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tender)
+	err := c.cc.Invoke(ctx, TenderService_CloneTender_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenderServiceClient) ArchiveTender(ctx context.Context, in *TenderActionRequest, opts ...grpc.CallOption) (*Tender, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tender)
+	err := c.cc.Invoke(ctx, TenderService_ArchiveTender_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenderServiceClient) RestoreTender(ctx context.Context, in *TenderActionRequest, opts ...grpc.CallOption) (*Tender, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tender)
+	err := c.cc.Invoke(ctx, TenderService_RestoreTender_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenderServiceClient) ListArchivedTenders(ctx context.Context, in *ListArchivedTendersRequest, opts ...grpc.CallOption) (TenderService_ListArchivedTendersClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TenderService_ServiceDesc.Streams[2], TenderService_ListArchivedTenders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tenderServiceListArchivedTendersClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TenderService_ListArchivedTendersClient interface {
+	Recv() (*Tender, error)
+	grpc.ClientStream
+}
+
+type tenderServiceListArchivedTendersClient struct {
+	grpc.ClientStream
+}
+
+func (x *tenderServiceListArchivedTendersClient) Recv() (*Tender, error) {
+	m := new(Tender)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TenderServiceServer is the server API for TenderService service.
+// All implementations must embed UnimplementedTenderServiceServer
+// for forward compatibility
+//
+// TenderService — операции с тендерами, те же, что у /api/tenders. Как и в REST,
+// пользователь передается полем username; списки возвращаются потоком.
+type TenderServiceServer interface {
+	CreateTender(context.Context, *CreateTenderRequest) (*Tender, error)
+	ListTenders(*ListTendersRequest, TenderService_ListTendersServer) error
+	ListMyTenders(*ListMyTendersRequest, TenderService_ListMyTendersServer) error
+	GetTenderStatus(context.Context, *GetTenderStatusRequest) (*TenderStatusResponse, error)
+	UpdateTenderStatus(context.Context, *UpdateTenderStatusRequest) (*Tender, error)
+	EditTender(context.Context, *EditTenderRequest) (*Tender, error)
+	RollbackTender(context.Context, *RollbackTenderRequest) (*Tender, error)
+	CloneTender(context.Context, *CloneTenderRequest) (*Tender, error)
+	ArchiveTender(context.Context, *TenderActionRequest) (*Tender, error)
+	RestoreTender(context.Context, *TenderActionRequest) (*Tender, error)
+	ListArchivedTenders(*ListArchivedTendersRequest, TenderService_ListArchivedTendersServer) error
+	mustEmbedUnimplementedTenderServiceServer()
+}
+
+// UnimplementedTenderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTenderServiceServer struct {
+}
+
+func (UnimplementedTenderServiceServer) CreateTender(context.Context, *CreateTenderRequest) (*Tender, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTender not implemented")
+}
+func (UnimplementedTenderServiceServer) ListTenders(*ListTendersRequest, TenderService_ListTendersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTenders not implemented")
+}
+func (UnimplementedTenderServiceServer) ListMyTenders(*ListMyTendersRequest, TenderService_ListMyTendersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListMyTenders not implemented")
+}
+func (UnimplementedTenderServiceServer) GetTenderStatus(context.Context, *GetTenderStatusRequest) (*TenderStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTenderStatus not implemented")
+}
+func (UnimplementedTenderServiceServer) UpdateTenderStatus(context.Context, *UpdateTenderStatusRequest) (*Tender, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTenderStatus not implemented")
+}
+func (UnimplementedTenderServiceServer) EditTender(context.Context, *EditTenderRequest) (*Tender, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditTender not implemented")
+}
+func (UnimplementedTenderServiceServer) RollbackTender(context.Context, *RollbackTenderRequest) (*Tender, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackTender not implemented")
+}
+func (UnimplementedTenderServiceServer) CloneTender(context.Context, *CloneTenderRequest) (*Tender, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneTender not implemented")
+}
+func (UnimplementedTenderServiceServer) ArchiveTender(context.Context, *TenderActionRequest) (*Tender, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveTender not implemented")
+}
+func (UnimplementedTenderServiceServer) RestoreTender(context.Context, *TenderActionRequest) (*Tender, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTender not implemented")
+}
+func (UnimplementedTenderServiceServer) ListArchivedTenders(*ListArchivedTendersRequest, TenderService_ListArchivedTendersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListArchivedTenders not implemented")
+}
+func (UnimplementedTenderServiceServer) mustEmbedUnimplementedTenderServiceServer() {}
+
+// UnsafeTenderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TenderServiceServer will
+// result in compilation errors.
+type UnsafeTenderServiceServer interface {
+	mustEmbedUnimplementedTenderServiceServer()
+}
+
+func RegisterTenderServiceServer(s grpc.ServiceRegistrar, srv TenderServiceServer) {
+	s.RegisterService(&TenderService_ServiceDesc, srv)
+}
+
+func _TenderService_CreateTender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenderServiceServer).CreateTender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenderService_CreateTender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenderServiceServer).CreateTender(ctx, req.(*CreateTenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenderService_ListTenders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTendersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TenderServiceServer).ListTenders(m, &tenderServiceListTendersServer{ServerStream: stream})
+}
+
+type TenderService_ListTendersServer interface {
+	Send(*Tender) error
+	grpc.ServerStream
+}
+
+type tenderServiceListTendersServer struct {
+	grpc.ServerStream
+}
+
+func (x *tenderServiceListTendersServer) Send(m *Tender) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TenderService_ListMyTenders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMyTendersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TenderServiceServer).ListMyTenders(m, &tenderServiceListMyTendersServer{ServerStream: stream})
+}
+
+type TenderService_ListMyTendersServer interface {
+	Send(*Tender) error
+	grpc.ServerStream
+}
+
+type tenderServiceListMyTendersServer struct {
+	grpc.ServerStream
+}
+
+func (x *tenderServiceListMyTendersServer) Send(m *Tender) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TenderService_GetTenderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenderServiceServer).GetTenderStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenderService_GetTenderStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenderServiceServer).GetTenderStatus(ctx, req.(*GetTenderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenderService_UpdateTenderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTenderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenderServiceServer).UpdateTenderStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenderService_UpdateTenderStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenderServiceServer).UpdateTenderStatus(ctx, req.(*UpdateTenderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenderService_EditTender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditTenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenderServiceServer).EditTender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenderService_EditTender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenderServiceServer).EditTender(ctx, req.(*EditTenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenderService_RollbackTender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackTenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenderServiceServer).RollbackTender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenderService_RollbackTender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenderServiceServer).RollbackTender(ctx, req.(*RollbackTenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenderService_CloneTender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneTenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenderServiceServer).CloneTender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenderService_CloneTender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenderServiceServer).CloneTender(ctx, req.(*CloneTenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenderService_ArchiveTender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TenderActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenderServiceServer).ArchiveTender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenderService_ArchiveTender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenderServiceServer).ArchiveTender(ctx, req.(*TenderActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenderService_RestoreTender_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TenderActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenderServiceServer).RestoreTender(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenderService_RestoreTender_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenderServiceServer).RestoreTender(ctx, req.(*TenderActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenderService_ListArchivedTenders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListArchivedTendersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TenderServiceServer).ListArchivedTenders(m, &tenderServiceListArchivedTendersServer{ServerStream: stream})
+}
+
+type TenderService_ListArchivedTendersServer interface {
+	Send(*Tender) error
+	grpc.ServerStream
+}
+
+type tenderServiceListArchivedTendersServer struct {
+	grpc.ServerStream
+}
+
+func (x *tenderServiceListArchivedTendersServer) Send(m *Tender) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// TenderService_ServiceDesc is the grpc.ServiceDesc for TenderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TenderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tender.v1.TenderService",
+	HandlerType: (*TenderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTender",
+			Handler:    _TenderService_CreateTender_Handler,
+		},
+		{
+			MethodName: "GetTenderStatus",
+			Handler:    _TenderService_GetTenderStatus_Handler,
+		},
+		{
+			MethodName: "UpdateTenderStatus",
+			Handler:    _TenderService_UpdateTenderStatus_Handler,
+		},
+		{
+			MethodName: "EditTender",
+			Handler:    _TenderService_EditTender_Handler,
+		},
+		{
+			MethodName: "RollbackTender",
+			Handler:    _TenderService_RollbackTender_Handler,
+		},
+		{
+			MethodName: "CloneTender",
+			Handler:    _TenderService_CloneTender_Handler,
+		},
+		{
+			MethodName: "ArchiveTender",
+			Handler:    _TenderService_ArchiveTender_Handler,
+		},
+		{
+			MethodName: "RestoreTender",
+			Handler:    _TenderService_RestoreTender_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListTenders",
+			Handler:       _TenderService_ListTenders_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListMyTenders",
+			Handler:       _TenderService_ListMyTenders_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListArchivedTenders",
+			Handler:       _TenderService_ListArchivedTenders_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tender/v1/tender_service.proto",
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"tender_srevice/internal/ratelimit"
+)
+
+type AdminHandler struct {
+	limiter *ratelimit.Limiter
+}
+
+func NewAdminHandler(limiter *ratelimit.Limiter) *AdminHandler {
+	return &AdminHandler{limiter: limiter}
+}
+
+func (h *AdminHandler) GetRateLimitStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if h.limiter == nil {
+		json.NewEncoder(w).Encode(map[string]bool{"enabled": false})
+		return
+	}
+	json.NewEncoder(w).Encode(struct {
+		Enabled bool `json:"enabled"`
+		ratelimit.Stats
+	}{true, h.limiter.Stats()})
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+func (h *TenderHandler) ArchiveTender(w http.ResponseWriter, r *http.Request) {
+	h.archiveAction(w, r, h.service.ArchiveTender)
+}
+
+func (h *TenderHandler) RestoreTender(w http.ResponseWriter, r *http.Request) {
+	h.archiveAction(w, r, h.service.RestoreTender)
+}
+
+func (h *TenderHandler) archiveAction(w http.ResponseWriter, r *http.Request,
+	action func(ctx context.Context, tenderID, username string) (*domain.Tender, error)) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	tender, err := action(r.Context(), mux.Vars(r)["tenderId"], username)
+	if err != nil {
+		writeArchiveError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tender)
+}
+
+func (h *TenderHandler) GetArchivedTenders(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	tenders, err := h.service.ArchivedTenders(r.Context(), username)
+	if err != nil {
+		writeArchiveError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tenders)
+}
+
+func (h *BidHandler) ArchiveBid(w http.ResponseWriter, r *http.Request) {
+	h.archiveAction(w, r, h.service.ArchiveBid)
+}
+
+func (h *BidHandler) RestoreBid(w http.ResponseWriter, r *http.Request) {
+	h.archiveAction(w, r, h.service.RestoreBid)
+}
+
+func (h *BidHandler) archiveAction(w http.ResponseWriter, r *http.Request,
+	action func(ctx context.Context, bidID, username string) (*domain.Bid, error)) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	bid, err := action(r.Context(), mux.Vars(r)["bidId"], username)
+	if err != nil {
+		writeArchiveError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bid)
+}
+
+func (h *BidHandler) GetArchivedBids(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	bids, err := h.service.ArchivedBids(r.Context(), username)
+	if err != nil {
+		writeArchiveError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bids)
+}
+
+type PurgeHandler struct {
+	service *service.PurgeService
+}
+
+func NewPurgeHandler(service *service.PurgeService) *PurgeHandler {
+	return &PurgeHandler{service: service}
+}
+
+func (h *PurgeHandler) PurgeTender(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.PurgeTender(r.Context(), mux.Vars(r)["tenderId"]); err != nil {
+		writeArchiveError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PurgeHandler) PurgeBid(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.PurgeBid(r.Context(), mux.Vars(r)["bidId"]); err != nil {
+		writeArchiveError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeArchiveError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, repository.ErrBidNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrUnknownUser):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrNotResponsible), errors.Is(err, service.ErrNotBidAuthor):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, repository.ErrAlreadyArchived), errors.Is(err, repository.ErrNotArchived),
+		errors.Is(err, service.ErrArchiveActive):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.ErrorContext(r.Context(), "archive request failed", slog.Any("error", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
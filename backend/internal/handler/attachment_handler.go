@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// maxFilesPerUpload — сколько файлов можно передать в одном multipart-запросе.
+const maxFilesPerUpload = 10
+
+type AttachmentHandler struct {
+	service *service.AttachmentService
+}
+
+func NewAttachmentHandler(service *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{service: service}
+}
+
+// attachmentOwner определяет владельца по переменной маршрута: tenderId или bidId.
+func attachmentOwner(r *http.Request) service.AttachmentOwner {
+	vars := mux.Vars(r)
+	if id, ok := vars["tenderId"]; ok {
+		return service.AttachmentOwner{Kind: domain.AttachmentOwnerTender, ID: id}
+	}
+	return service.AttachmentOwner{Kind: domain.AttachmentOwnerBid, ID: vars["bidId"]}
+}
+
+// UploadAttachments принимает multipart/form-data с одним или несколькими полями file.
+func (h *AttachmentHandler) UploadAttachments(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	// Большие файлы не должны упираться в общий ReadTimeout сервера
+	http.NewResponseController(w).SetReadDeadline(time.Time{})
+	r.Body = http.MaxBytesReader(w, r.Body, (h.service.MaxSize()+1<<20)*maxFilesPerUpload)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "Request must be multipart/form-data", http.StatusBadRequest)
+		return
+	}
+
+	uploaded := []*domain.Attachment{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var maxBytes *http.MaxBytesError
+			if errors.As(err, &maxBytes) {
+				writeAttachmentError(w, r, err)
+				return
+			}
+			http.Error(w, fmt.Sprintf("Invalid multipart body: %v", err), http.StatusBadRequest)
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+		if len(uploaded) == maxFilesPerUpload {
+			http.Error(w, fmt.Sprintf("At most %d files per request", maxFilesPerUpload), http.StatusBadRequest)
+			return
+		}
+
+		attachment, err := h.service.Upload(r.Context(), attachmentOwner(r), username, part.FileName(), part)
+		part.Close()
+		if err != nil {
+			writeAttachmentError(w, r, err)
+			return
+		}
+		uploaded = append(uploaded, attachment)
+	}
+	if len(uploaded) == 0 {
+		http.Error(w, "No file parts in request", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(uploaded)
+}
+
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	attachments, err := h.service.List(r.Context(), attachmentOwner(r), r.URL.Query().Get("username"))
+	if err != nil {
+		writeAttachmentError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attachments)
+}
+
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	attachmentID := mux.Vars(r)["attachmentId"]
+	attachment, body, err := h.service.Open(r.Context(), attachmentOwner(r), attachmentID, r.URL.Query().Get("username"))
+	if err != nil {
+		writeAttachmentError(w, r, err)
+		return
+	}
+	defer body.Close()
+
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("ETag", `"`+attachment.SHA256+`"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if _, err := io.Copy(w, body); err != nil {
+		slog.ErrorContext(r.Context(), "failed to send attachment", slog.String("attachment_id", attachmentID), slog.Any("error", err))
+	}
+}
+
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.service.Delete(r.Context(), attachmentOwner(r), mux.Vars(r)["attachmentId"], username)
+	if err != nil {
+		writeAttachmentError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeAttachmentError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytes *http.MaxBytesError
+	switch {
+	case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, repository.ErrBidNotFound), errors.Is(err, repository.ErrAttachmentNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrAttachmentTooLarge), errors.As(err, &maxBytes):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, service.ErrAttachmentType):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, service.ErrUnknownUser):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrAttachmentForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrBidsSealed), errors.Is(err, service.ErrSubmissionDeadlinePassed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.ErrorContext(r.Context(), "attachment request failed", slog.Any("error", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
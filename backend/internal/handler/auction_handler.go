@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+type AuctionHandler struct {
+	service *service.AuctionService
+}
+
+func NewAuctionHandler(service *service.AuctionService) *AuctionHandler {
+	return &AuctionHandler{service: service}
+}
+
+func (h *AuctionHandler) ConfigureAuction(w http.ResponseWriter, r *http.Request) {
+	tenderID := mux.Vars(r)["tenderId"]
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.AuctionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	auction, err := h.service.ConfigureAuction(r.Context(), tenderID, username, req)
+	if err != nil {
+		writeAuctionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(auction)
+}
+
+func (h *AuctionHandler) GetAuction(w http.ResponseWriter, r *http.Request) {
+	tenderID := mux.Vars(r)["tenderId"]
+
+	auction, err := h.service.GetAuction(r.Context(), tenderID)
+	if err != nil {
+		writeAuctionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(auction)
+}
+
+func (h *AuctionHandler) PlaceBid(w http.ResponseWriter, r *http.Request) {
+	tenderID := mux.Vars(r)["tenderId"]
+
+	var req struct {
+		Username string `json:"username"`
+		Amount   int64  `json:"amount"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.PlaceBid(r.Context(), tenderID, req.Username, req.Amount)
+	if err != nil {
+		writeAuctionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *AuctionHandler) GetRanking(w http.ResponseWriter, r *http.Request) {
+	tenderID := mux.Vars(r)["tenderId"]
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	standing, err := h.service.Standing(r.Context(), tenderID, username)
+	if err != nil {
+		writeAuctionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(standing)
+}
+
+func writeAuctionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, repository.ErrAuctionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidAuction), errors.Is(err, service.ErrAuctionBidRejected):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrUnknownUser):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrNotResponsible), errors.Is(err, service.ErrAuctionForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrAuctionStarted), errors.Is(err, service.ErrAuctionNotRunning), errors.Is(err, service.ErrAuctionSealed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.ErrorContext(r.Context(), "auction request failed", slog.Any("error", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
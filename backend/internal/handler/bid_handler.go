@@ -1,11 +1,18 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
 	"tender_srevice/internal/service"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -27,6 +34,22 @@ func (h *BidHandler) CreateBid(w http.ResponseWriter, r *http.Request) {
 
 	bid, err := h.service.CreateBid(r.Context(), req)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidBidTerms) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service.ErrSubmissionDeadlinePassed) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, service.ErrSealingUnavailable) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if errors.Is(err, repository.ErrTenderNotFound) {
+			http.Error(w, "Tender not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, "Failed to create bid", http.StatusInternalServerError)
 		return
 	}
@@ -58,6 +81,12 @@ func (h *BidHandler) GetBidStatus(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *BidHandler) GetMyBids(w http.ResponseWriter, r *http.Request) {
+	page, err := bidPageFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var req struct {
 		Username string `json:"username"`
 	}
@@ -79,8 +108,12 @@ func (h *BidHandler) GetMyBids(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bids, err := h.service.GetBidsByAuthorID(r.Context(), userID)
+	bids, err := h.service.GetBidsByAuthorID(r.Context(), userID, page)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidBidPage) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Failed to get bids", http.StatusInternalServerError)
 		return
 	}
@@ -93,6 +126,17 @@ func (h *BidHandler) GetBidsByTenderID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	tenderID := vars["tenderId"]
 
+	filter, err := bidFilterFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, err := bidPageFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var req struct {
 		Username string `json:"username"`
 	}
@@ -107,18 +151,26 @@ func (h *BidHandler) GetBidsByTenderID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bids, err := h.service.GetBidsByTenderID(r.Context(), tenderID, req.Username)
+	bids, sealed, err := h.service.GetBidsByTenderID(r.Context(), tenderID, req.Username, filter, page)
 	if err != nil {
-		if err.Error() == "user is not authorized to view bids for this tender" {
+		if errors.Is(err, service.ErrInvalidBidPage) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else if err.Error() == "user is not authorized to view bids for this tender" {
 			http.Error(w, err.Error(), http.StatusForbidden)
+		} else if errors.Is(err, repository.ErrTenderNotFound) {
+			http.Error(w, "Tender not found", http.StatusNotFound)
 		} else {
-			log.Printf("Error getting bids: %v", err) // Добавьте эту строку для логирования
+			slog.ErrorContext(r.Context(), "failed to get bids", slog.String("tender_id", tenderID), slog.Any("error", err))
 			http.Error(w, fmt.Sprintf("Failed to get bids: %v", err), http.StatusInternalServerError)
 		}
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if sealed != nil {
+		json.NewEncoder(w).Encode(sealed)
+		return
+	}
 	json.NewEncoder(w).Encode(bids)
 }
 
@@ -143,6 +195,8 @@ func (h *BidHandler) GetBidStatusByID(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if err.Error() == "user is not authorized to view this bid status" {
 			http.Error(w, err.Error(), http.StatusForbidden)
+		} else if errors.Is(err, repository.ErrBidNotFound) {
+			http.Error(w, "Bid not found", http.StatusNotFound)
 		} else {
 			http.Error(w, fmt.Sprintf("Failed to get bid status: %v", err), http.StatusInternalServerError)
 		}
@@ -174,8 +228,10 @@ func (h *BidHandler) UpdateBidStatus(w http.ResponseWriter, r *http.Request) {
 	err := h.service.UpdateBidStatus(r.Context(), bidID, req.Username, req.NewStatus)
 	if err != nil {
 		if err != nil {
-			if err.Error() == "user is not authorized to view this bid status" {
+			if err.Error() == "user is not authorized to view bids for this tender" {
 				http.Error(w, err.Error(), http.StatusForbidden)
+			} else if errors.Is(err, repository.ErrBidNotFound) {
+				http.Error(w, "Bid not found", http.StatusNotFound)
 			} else {
 				http.Error(w, fmt.Sprintf("Failed to get bid status: %v", err), http.StatusInternalServerError)
 			}
@@ -191,6 +247,7 @@ func (h *BidHandler) UpdateBidStatus(w http.ResponseWriter, r *http.Request) {
 type EditBidRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	service.BidTerms
 }
 
 func (h *BidHandler) EditBid(w http.ResponseWriter, r *http.Request) {
@@ -209,12 +266,205 @@ func (h *BidHandler) EditBid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.service.EditBid(r.Context(), bidID, username, req.Name, req.Description)
+	err := h.service.EditBid(r.Context(), bidID, username, req.Name, req.Description, req.BidTerms)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidBidTerms) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service.ErrSubmissionDeadlinePassed) || errors.Is(err, service.ErrBidsSealed) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, service.ErrSealingUnavailable) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if errors.Is(err, repository.ErrBidNotFound) {
+			http.Error(w, "Bid not found", http.StatusNotFound)
+			return
+		}
+		if err.Error() == "пользователь не имеет прав на редактирование этой заявки" {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Bid updated successfully"})
-}
\ No newline at end of file
+}
+var bidExportHeader = []string{"id", "name", "description", "status", "tenderId", "authorType", "authorId", "version", "createdAt",
+	"amount", "currency", "deliveryDays", "validFrom", "validUntil"}
+
+func (h *BidHandler) ExportBidsByTenderID(w http.ResponseWriter, r *http.Request) {
+	tenderID := mux.Vars(r)["tenderId"]
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	filter, err := bidFilterFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	out, err := newExportWriter(w, r.URL.Query().Get("format"), "bids-"+tenderID, bidExportHeader)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.ExportBidsByTenderID(r.Context(), tenderID, username, filter, func(b *domain.Bid) error {
+		return out.Write([]string{
+			b.ID, b.Name, b.Description, b.Status, b.TenderID, b.AuthorType, b.AuthorID,
+			strconv.Itoa(b.Version), b.CreatedAt.Format(time.RFC3339),
+			formatOptionalInt64(b.Amount), formatOptionalString(b.Currency), formatOptionalInt(b.DeliveryDays),
+			formatOptionalTime(b.ValidFrom), formatOptionalTime(b.ValidUntil),
+		}, b)
+	})
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		if err.Error() == "user is not authorized to view bids for this tender" {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, repository.ErrTenderNotFound) {
+			http.Error(w, "Tender not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, service.ErrBidsSealed) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		slog.ErrorContext(r.Context(), "failed to export bids", slog.String("tender_id", tenderID), slog.Int("rows", out.rows), slog.Any("error", err))
+		if !out.started {
+			http.Error(w, "Failed to export bids", http.StatusInternalServerError)
+		}
+	}
+}
+
+// bidFilterFromQuery разбирает параметры sort, currency, minAmount и maxAmount списка предложений.
+func bidFilterFromQuery(q url.Values) (repository.BidFilter, error) {
+	filter := repository.BidFilter{Currency: q.Get("currency"), Sort: q.Get("sort")}
+	switch filter.Sort {
+	case "", repository.BidSortCreated, repository.BidSortPriceAsc, repository.BidSortPriceDesc:
+	default:
+		return filter, fmt.Errorf("unsupported sort %q", filter.Sort)
+	}
+	bounds := []struct {
+		name string
+		dst  **int64
+	}{
+		{"minAmount", &filter.MinAmount},
+		{"maxAmount", &filter.MaxAmount},
+	}
+	for _, b := range bounds {
+		name, dst := b.name, b.dst
+		raw := q.Get(name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: %q", name, raw)
+		}
+		*dst = &v
+	}
+	return filter, nil
+}
+
+// bidPageFromQuery читает limit и cursor; без limit отдается DefaultBidPageLimit предложений.
+func bidPageFromQuery(q url.Values) (service.BidPageRequest, error) {
+	page := service.BidPageRequest{Cursor: q.Get("cursor"), Limit: service.DefaultBidPageLimit}
+	if raw := q.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > service.MaxBidPageLimit {
+			return page, fmt.Errorf("limit must be an integer from 1 to %d", service.MaxBidPageLimit)
+		}
+		page.Limit = n
+	}
+	return page, nil
+}
+
+type bidReasonRequest struct {
+	Reason string `json:"reason"`
+}
+
+// WithdrawBid отзывает предложение; доступно только его автору.
+func (h *BidHandler) WithdrawBid(w http.ResponseWriter, r *http.Request) {
+	h.authorAction(w, r, h.service.WithdrawBid)
+}
+
+// ResubmitBid подает отозванное предложение повторно; доступно только его автору.
+func (h *BidHandler) ResubmitBid(w http.ResponseWriter, r *http.Request) {
+	h.authorAction(w, r, h.service.ResubmitBid)
+}
+
+func (h *BidHandler) authorAction(w http.ResponseWriter, r *http.Request,
+	action func(ctx context.Context, bidID, username, reason string) (*domain.Bid, error)) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	var req bidReasonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	bid, err := action(r.Context(), mux.Vars(r)["bidId"], username, req.Reason)
+	if err != nil {
+		writeBidWithdrawalError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bid)
+}
+
+func (h *BidHandler) GetBidWithdrawals(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.service.BidWithdrawals(r.Context(), mux.Vars(r)["tenderId"], username)
+	if err != nil {
+		writeBidWithdrawalError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+func writeBidWithdrawalError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, repository.ErrBidNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidWithdrawal):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrUnknownUser):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrNotBidAuthor), errors.Is(err, service.ErrNotResponsible):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrBidAlreadyWithdrawn), errors.Is(err, service.ErrBidNotWithdrawn),
+		errors.Is(err, service.ErrBidTenderClosed), errors.Is(err, service.ErrSubmissionDeadlinePassed),
+		errors.Is(err, service.ErrBidsSealed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrSealingUnavailable):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		slog.ErrorContext(r.Context(), "bid withdrawal request failed", slog.Any("error", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
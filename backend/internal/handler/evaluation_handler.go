@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+type EvaluationHandler struct {
+	service *service.EvaluationService
+}
+
+func NewEvaluationHandler(service *service.EvaluationService) *EvaluationHandler {
+	return &EvaluationHandler{service: service}
+}
+
+func (h *EvaluationHandler) GetCriteria(w http.ResponseWriter, r *http.Request) {
+	criteria, err := h.service.Criteria(r.Context(), mux.Vars(r)["tenderId"])
+	if err != nil {
+		writeEvaluationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(criteria)
+}
+
+// SetCriteria заменяет критерии тендера списком из тела запроса.
+func (h *EvaluationHandler) SetCriteria(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	var req []service.CriterionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	criteria, err := h.service.SetCriteria(r.Context(), mux.Vars(r)["tenderId"], username, req)
+	if err != nil {
+		writeEvaluationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(criteria)
+}
+
+func (h *EvaluationHandler) GetBidScores(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	scores, err := h.service.BidScores(r.Context(), mux.Vars(r)["bidId"], username)
+	if err != nil {
+		writeEvaluationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scores)
+}
+
+func (h *EvaluationHandler) ScoreBid(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	var req []service.ScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	scores, err := h.service.ScoreBid(r.Context(), mux.Vars(r)["bidId"], username, req)
+	if err != nil {
+		writeEvaluationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scores)
+}
+
+func (h *EvaluationHandler) GetRanking(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	ranking, err := h.service.Ranking(r.Context(), mux.Vars(r)["tenderId"], username)
+	if err != nil {
+		writeEvaluationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ranking)
+}
+
+func writeEvaluationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, repository.ErrBidNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidCriteria), errors.Is(err, service.ErrInvalidScore):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrUnknownUser):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrNotResponsible), errors.Is(err, service.ErrRankingForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrCriteriaLocked), errors.Is(err, service.ErrBidNotPublished), errors.Is(err, service.ErrBidsSealed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.ErrorContext(r.Context(), "evaluation request failed", slog.Any("error", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	exportFormatCSV   = "csv"
+	exportFormatJSONL = "jsonl"
+
+	// exportFlushEvery — через сколько строк буфер ответа отправляется клиенту.
+	exportFlushEvery = 500
+)
+
+// exportWriter пишет строки экспорта в ответ в формате CSV или JSON Lines.
+type exportWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	csv     *csv.Writer
+	json    *json.Encoder
+	rows    int
+	started bool
+	name    string
+	format  string
+	header  []string
+}
+
+func newExportWriter(w http.ResponseWriter, format, name string, header []string) (*exportWriter, error) {
+	if format == "" {
+		format = exportFormatCSV
+	}
+	if format != exportFormatCSV && format != exportFormatJSONL {
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	return &exportWriter{
+		w:      w,
+		rc:     http.NewResponseController(w),
+		name:   name,
+		format: format,
+		header: header,
+	}, nil
+}
+
+// start отправляет заголовки ответа. Вызывается при первой строке, чтобы
+// ошибка проверки доступа еще могла вернуть обычный код ошибки.
+func (e *exportWriter) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	// Большой экспорт может писаться дольше server.write_timeout
+	e.rc.SetWriteDeadline(time.Time{})
+
+	h := e.w.Header()
+	h.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, e.name, e.format))
+	if e.format == exportFormatCSV {
+		h.Set("Content-Type", "text/csv; charset=utf-8")
+		e.csv = csv.NewWriter(e.w)
+		return e.csv.Write(e.header)
+	}
+	h.Set("Content-Type", "application/x-ndjson")
+	e.json = json.NewEncoder(e.w)
+	return nil
+}
+
+// Write добавляет строку: record — для CSV, v — для JSON Lines.
+func (e *exportWriter) Write(record []string, v interface{}) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	var err error
+	if e.csv != nil {
+		err = e.csv.Write(record)
+	} else {
+		err = e.json.Encode(v)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushEvery == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// Close дописывает заголовок CSV для пустого экспорта и сбрасывает буфер.
+func (e *exportWriter) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.flush()
+}
+
+func (e *exportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := e.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// Необязательные поля в CSV выгружаются пустой строкой.
+
+func formatOptionalString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func formatOptionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatOptionalInt64(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func formatOptionalTime(v *time.Time) string {
+	if v == nil {
+		return ""
+	}
+	return v.Format(time.RFC3339)
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type QuestionHandler struct {
+	service *service.QuestionService
+}
+
+func NewQuestionHandler(service *service.QuestionService) *QuestionHandler {
+	return &QuestionHandler{service: service}
+}
+
+// ListQuestions не требует username: без него возвращаются только отвеченные вопросы.
+func (h *QuestionHandler) ListQuestions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	questions, err := h.service.List(r.Context(), mux.Vars(r)["tenderId"], query.Get("username"), query.Get("status"))
+	if err != nil {
+		writeQuestionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(questions)
+}
+
+func (h *QuestionHandler) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username string `json:"username"`
+		Text     string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	question, err := h.service.Ask(r.Context(), mux.Vars(r)["tenderId"], req.Username, req.Text)
+	if err != nil {
+		writeQuestionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(question)
+}
+
+func (h *QuestionHandler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.AnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	question, err := h.service.Answer(r.Context(), mux.Vars(r)["questionId"], username, req)
+	if err != nil {
+		writeQuestionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(question)
+}
+
+func (h *QuestionHandler) RejectQuestion(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	question, err := h.service.Reject(r.Context(), mux.Vars(r)["questionId"], username, req.Reason)
+	if err != nil {
+		writeQuestionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(question)
+}
+
+func (h *QuestionHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.service.Settings(r.Context(), mux.Vars(r)["tenderId"])
+	if err != nil {
+		writeQuestionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// UpdateSettings принимает {"deadline": "<RFC 3339>"}; null возвращает срок по умолчанию.
+func (h *QuestionHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Deadline *time.Time `json:"deadline"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.service.SetDeadline(r.Context(), mux.Vars(r)["tenderId"], username, req.Deadline)
+	if err != nil {
+		writeQuestionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+func writeQuestionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTenderNotFound), errors.Is(err, repository.ErrQuestionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidQuestion), errors.Is(err, service.ErrDeadlineInPast):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrUnknownUser):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrNotResponsible), errors.Is(err, service.ErrQuestionForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrQuestionsClosed), errors.Is(err, service.ErrQuestionDeadlinePassed),
+		errors.Is(err, repository.ErrTenderClosed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.ErrorContext(r.Context(), "question request failed", slog.Any("error", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+type ServiceTypeHandler struct {
+	service *service.ServiceTypeService
+}
+
+func NewServiceTypeHandler(service *service.ServiceTypeService) *ServiceTypeHandler {
+	return &ServiceTypeHandler{service: service}
+}
+
+func (h *ServiceTypeHandler) ListServiceTypes(w http.ResponseWriter, r *http.Request) {
+	includeInactive, _ := strconv.ParseBool(r.URL.Query().Get("includeInactive"))
+
+	types, err := h.service.List(r.Context(), requestLanguage(r), includeInactive)
+	if err != nil {
+		writeServiceTypeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(types)
+}
+
+func (h *ServiceTypeHandler) GetServiceType(w http.ResponseWriter, r *http.Request) {
+	st, err := h.service.Get(r.Context(), mux.Vars(r)["code"], requestLanguage(r))
+	if err != nil {
+		writeServiceTypeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
+
+func (h *ServiceTypeHandler) SaveServiceType(w http.ResponseWriter, r *http.Request) {
+	var req service.ServiceTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	st, created, err := h.service.Save(r.Context(), mux.Vars(r)["code"], req)
+	if err != nil {
+		writeServiceTypeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(st)
+}
+
+func (h *ServiceTypeHandler) DeleteServiceType(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), mux.Vars(r)["code"]); err != nil {
+		writeServiceTypeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requestLanguage берет язык из параметра lang, затем из первого тега Accept-Language.
+func requestLanguage(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		return strings.ToLower(lang)
+	}
+	header := r.Header.Get("Accept-Language")
+	if header == "" {
+		return domain.DefaultLanguage
+	}
+	tag := strings.TrimSpace(strings.SplitN(header, ",", 2)[0])
+	tag = strings.SplitN(tag, ";", 2)[0]
+	tag = strings.SplitN(tag, "-", 2)[0]
+	if tag == "" || tag == "*" {
+		return domain.DefaultLanguage
+	}
+	return strings.ToLower(tag)
+}
+
+func writeServiceTypeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrServiceTypeNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidServiceType), errors.Is(err, repository.ErrServiceTypeParentNotFound),
+		errors.Is(err, repository.ErrServiceTypeCycle):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, repository.ErrServiceTypeAliasTaken), errors.Is(err, repository.ErrServiceTypeInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.ErrorContext(r.Context(), "service type request failed", slog.Any("error", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
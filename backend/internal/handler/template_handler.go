@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+type TemplateHandler struct {
+	service *service.TemplateService
+}
+
+func NewTemplateHandler(service *service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{service: service}
+}
+
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	templates, err := h.service.ListTemplates(r.Context(), mux.Vars(r)["organizationId"], username)
+	if err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templates)
+}
+
+func (h *TemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	var req service.TemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.service.CreateTemplate(r.Context(), mux.Vars(r)["organizationId"], username, req)
+	if err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(template)
+}
+
+func (h *TemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.service.GetTemplate(r.Context(), mux.Vars(r)["templateId"], username)
+	if err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+func (h *TemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteTemplate(r.Context(), mux.Vars(r)["templateId"], username); err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTemplateError обслуживает и шаблоны, и создание тендеров из шаблона или копированием.
+func writeTemplateError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTemplateNotFound), errors.Is(err, repository.ErrTenderNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidTemplate), errors.Is(err, service.ErrInvalidCriteria),
+		errors.Is(err, service.ErrDeadlineInPast), errors.Is(err, service.ErrUnknownServiceType):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrUnknownUser):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrNotResponsible):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, repository.ErrTemplateTitleTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrSealingUnavailable):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		slog.ErrorContext(r.Context(), "tender template request failed", slog.Any("error", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
@@ -2,15 +2,19 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"strconv"
-
-	"log"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
 	"tender_srevice/internal/service"
 )
 
@@ -35,23 +39,63 @@ func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 	// 	CreatorUsername string `json:"creatorUsername"`
 	// }
 
-	var req domain.Tender
+	var req struct {
+		domain.Tender
+		Placeholders map[string]string `json:"placeholders"`
+	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	tender, err := h.service.CreateTender(r.Context(), service.CreateTenderRequest{
+	createReq := service.CreateTenderRequest{
 		Name:             req.Name,
 		Description:      req.Description,
 		ServiceType:      req.ServiceType,
 		Status:           req.Status,
 		OrganizationID:   req.OrganizationID,
 		CreatorUsername:  req.CreatorUsername,
-	})
+		SubmissionDeadline: req.SubmissionDeadline,
+		Sealed:           req.Sealed,
+	}
+
+	// По шаблону недостающие поля берутся из него
+	if templateID := r.URL.Query().Get("templateId"); templateID != "" {
+		if req.CreatorUsername == "" {
+			http.Error(w, "creatorUsername is required", http.StatusBadRequest)
+			return
+		}
+		tender, err := h.service.CreateTenderFromTemplate(r.Context(), service.TemplateTenderRequest{
+			CreateTenderRequest: createReq,
+			TemplateID:          templateID,
+			Placeholders:        req.Placeholders,
+		})
+		if err != nil {
+			writeTemplateError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tender)
+		return
+	}
+
+	if req.Name == "" || req.ServiceType == "" || req.Status == "" || req.OrganizationID == "" || req.CreatorUsername == "" {
+		http.Error(w, "name, serviceType, status, organizationId and creatorUsername are required", http.StatusBadRequest)
+		return
+	}
+
+	tender, err := h.service.CreateTender(r.Context(), createReq)
 
 	if err != nil {
+		if errors.Is(err, service.ErrDeadlineInPast) || errors.Is(err, service.ErrUnknownServiceType) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service.ErrSealingUnavailable) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
 		http.Error(w, "Failed to create tender", http.StatusInternalServerError)
 		return
 	}
@@ -64,8 +108,12 @@ func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 func (h *TenderHandler) GetTenders(w http.ResponseWriter, r *http.Request) {
 
 	
-	tenders, err := h.service.GetTenders(r.Context())
+	tenders, err := h.service.GetTenders(r.Context(), r.URL.Query().Get("serviceType"))
 	if err != nil {
+		if errors.Is(err, service.ErrUnknownServiceType) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Failed to get tenders", http.StatusInternalServerError)
 		return
 	}
@@ -103,8 +151,10 @@ func (h *TenderHandler) GetTenderStatus(w http.ResponseWriter, r *http.Request)
 
 	status, err := h.service.GetTenderStatus(r.Context(), tenderID, username)
 	if err != nil {
-		if err.Error() == "tender not found" {
+		if errors.Is(err, repository.ErrTenderNotFound) {
 			http.Error(w, "Tender not found", http.StatusNotFound)
+		} else if err.Error() == "доступ запрещен: пользователь не является сотрудником организации" {
+			http.Error(w, err.Error(), http.StatusForbidden)
 		} else {
 			http.Error(w, "Failed to get tender status", http.StatusInternalServerError)
 		}
@@ -125,7 +175,7 @@ func (h *TenderHandler) UpdateTenderStatus(w http.ResponseWriter, r *http.Reques
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Printf("Ошибка при декодировании запроса: %v", err)
+		slog.WarnContext(r.Context(), "failed to decode request body", slog.Any("error", err))
 		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
 		return
 	}
@@ -137,13 +187,14 @@ func (h *TenderHandler) UpdateTenderStatus(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	fmt.Println(tenderID, req.Status, req.Username)
 	updatedTender, err := h.service.UpdateTenderStatus(r.Context(), tenderID, req.Status, req.Username)
 	if err != nil {
-		if err.Error() == "tender not found" {
+		if errors.Is(err, repository.ErrTenderNotFound) {
 			http.Error(w, "Тендер не найден", http.StatusNotFound)
 		} else if err.Error() == "unauthorized" {
 			http.Error(w, "Нет прав для изменения статуса тендера", http.StatusForbidden)
+		} else if errors.Is(err, service.ErrSealedTenderClosed) {
+			http.Error(w, err.Error(), http.StatusConflict)
 		} else {
 			http.Error(w, "Ошибка при обновлении статуса", http.StatusInternalServerError)
 		}
@@ -161,6 +212,7 @@ type TenderUpdateRequest struct {
 	Name        *string `json:"name"`
 	Description *string `json:"description"`
 	ServiceType *string `json:"serviceType"`
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
 }
 
 func (h *TenderHandler) UpdateTender(w http.ResponseWriter, r *http.Request) {
@@ -181,13 +233,16 @@ func (h *TenderHandler) UpdateTender(w http.ResponseWriter, r *http.Request) {
 		Name: req.Name,
 		Description: req.Description,
 		ServiceType: req.ServiceType,
+		SubmissionDeadline: req.SubmissionDeadline,
 	})
 	if err != nil {
-		log.Printf("Ошибка при обновлении тендера: %v", err)
-		if err.Error() == "tender not found" {
+		slog.ErrorContext(r.Context(), "failed to update tender", slog.String("tender_id", tenderID), slog.Any("error", err))
+		if errors.Is(err, repository.ErrTenderNotFound) {
 			http.Error(w, "Тендер не найден", http.StatusNotFound)
 		} else if err.Error() == "unauthorized" {
 			http.Error(w, "Нет прав для изменения тендера", http.StatusForbidden)
+		} else if errors.Is(err, service.ErrDeadlineInPast) || errors.Is(err, service.ErrUnknownServiceType) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 		} else {
 			http.Error(w, fmt.Sprintf("Ошибка при обновлении тендера: %v", err), http.StatusInternalServerError)
 		}
@@ -225,13 +280,15 @@ func (h *TenderHandler) RollbackTender(w http.ResponseWriter, r *http.Request) {
 
 	updatedTender, err := h.service.RollbackTender(r.Context(), tenderID, version, req.Username)
 	if err != nil {
-		switch err.Error() {
-		case "tender not found":
+		switch {
+		case errors.Is(err, repository.ErrTenderNotFound):
 			http.Error(w, "Тендер не найден", http.StatusNotFound)
-		case "unauthorized":
+		case err.Error() == "unauthorized":
 			http.Error(w, "Нет прав для отката тендера", http.StatusForbidden)
-		case "version not found":
+		case errors.Is(err, repository.ErrVersionNotFound):
 			http.Error(w, "Указанная версия не найдена", http.StatusNotFound)
+		case errors.Is(err, service.ErrSealedTenderClosed):
+			http.Error(w, err.Error(), http.StatusConflict)
 		default:
 			http.Error(w, fmt.Sprintf("Ошибка при откате тендера: %v", err), http.StatusInternalServerError)
 		}
@@ -243,3 +300,106 @@ func (h *TenderHandler) RollbackTender(w http.ResponseWriter, r *http.Request) {
 }
 
 
+// CloneTender создает новый тендер в статусе CREATED по образцу существующего.
+func (h *TenderHandler) CloneTender(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	// Тело необязательно: без него копия получает название исходного тендера
+	var req service.CloneTenderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	tender, err := h.service.CloneTender(r.Context(), mux.Vars(r)["tenderId"], username, req)
+	if err != nil {
+		writeTemplateError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tender)
+}
+
+var tenderExportHeader = []string{"id", "name", "description", "serviceType", "status", "version", "organizationId", "creatorUsername"}
+
+func (h *TenderHandler) ExportTenders(w http.ResponseWriter, r *http.Request) {
+	out, err := newExportWriter(w, r.URL.Query().Get("format"), "tenders", tenderExportHeader)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.ExportTenders(r.Context(), r.URL.Query().Get("serviceType"), func(t *domain.Tender) error {
+		return out.Write([]string{
+			t.ID, t.Name, t.Description, t.ServiceType, t.Status,
+			strconv.Itoa(t.Version), t.OrganizationID, t.CreatorUsername,
+		}, t)
+	})
+	if err == nil {
+		err = out.Close()
+	}
+	if errors.Is(err, service.ErrUnknownServiceType) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to export tenders", slog.Int("rows", out.rows), slog.Any("error", err))
+		if !out.started {
+			http.Error(w, "Failed to export tenders", http.StatusInternalServerError)
+		}
+	}
+}
+
+// maxImportBodySize ограничивает размер загружаемого файла импорта.
+const maxImportBodySize = 10 << 20
+
+func (h *TenderHandler) ImportTenders(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = importFormatFromContentType(r.Header.Get("Content-Type"))
+	}
+
+	dryRun := false
+	if v := r.URL.Query().Get("dryRun"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "dryRun must be true or false", http.StatusBadRequest)
+			return
+		}
+		dryRun = parsed
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportBodySize)
+	rows, parseErrors, err := service.ParseTenderImport(body, format)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to parse import file: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.ImportTenders(r.Context(), rows, parseErrors, dryRun)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to import tenders", slog.Int("rows", len(rows)), slog.Any("error", err))
+		http.Error(w, "Failed to import tenders", http.StatusInternalServerError)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "tenders imported", slog.Bool("dry_run", dryRun),
+		slog.Int("total", report.Total), slog.Int("imported", report.Imported), slog.Int("errors", len(report.Errors)))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func importFormatFromContentType(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return service.ImportFormatCSV
+	case strings.HasPrefix(contentType, "application/x-ndjson"), strings.HasPrefix(contentType, "application/jsonl"):
+		return service.ImportFormatJSONL
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+	"tender_srevice/internal/domain"
+	"time"
+)
+
+// maxBackoff ограничивает паузу между повторными попытками.
+const maxBackoff = 6 * time.Hour
+
+// Outbox — очередь писем. ClaimEmails выдает готовые к отправке письма и откладывает
+// их на lease, чтобы параллельные обработчики не взяли их повторно.
+type Outbox interface {
+	ClaimEmails(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEmail, error)
+	MarkEmailSent(ctx context.Context, id string) error
+	RetryEmail(ctx context.Context, id string, next time.Time, lastErr string) error
+	FailEmail(ctx context.Context, id, lastErr string) error
+}
+
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// QueueConfig — MaxAttempts включает первую попытку; пауза перед n-й повторной
+// попыткой равна RetryBackoff * 2^(n-1), но не больше maxBackoff.
+type QueueConfig struct {
+	Interval     time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	Lease        time.Duration
+}
+
+// Dispatcher периодически отправляет письма из очереди.
+type Dispatcher struct {
+	outbox Outbox
+	sender Sender
+	cfg    QueueConfig
+}
+
+func NewDispatcher(outbox Outbox, sender Sender, cfg QueueConfig) *Dispatcher {
+	return &Dispatcher{outbox: outbox, sender: sender, cfg: cfg}
+}
+
+// Run отправляет письма до отмены ctx.
+func (d *Dispatcher) Run(ctx context.Context) {
+	slog.Info("mail dispatcher started", slog.Duration("interval", d.cfg.Interval))
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		d.drain(ctx)
+		select {
+		case <-ctx.Done():
+			slog.Info("mail dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain отправляет пачки, пока очередь отдает их целиком.
+func (d *Dispatcher) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		if d.Flush(ctx) < d.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// Flush отправляет одну пачку писем и возвращает ее размер.
+func (d *Dispatcher) Flush(ctx context.Context) int {
+	emails, err := d.outbox.ClaimEmails(ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("failed to claim emails", slog.Any("error", err))
+		}
+		return 0
+	}
+	for _, e := range emails {
+		d.deliver(ctx, e)
+	}
+	return len(emails)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, e *domain.OutboxEmail) {
+	err := d.sender.Send(ctx, Message{To: e.To, Subject: e.Subject, HTML: e.HTMLBody})
+	if err == nil {
+		if err := d.outbox.MarkEmailSent(ctx, e.ID); err != nil {
+			slog.Error("failed to mark email sent", slog.String("email_id", e.ID), slog.Any("error", err))
+		}
+		return
+	}
+	if ctx.Err() != nil {
+		// Письмо вернется в очередь по истечении lease
+		return
+	}
+
+	log := slog.With(slog.String("email_id", e.ID), slog.Int("attempt", e.Attempts), slog.Any("error", err))
+	if IsPermanent(err) || e.Attempts >= d.cfg.MaxAttempts {
+		log.Error("email delivery failed")
+		if err := d.outbox.FailEmail(ctx, e.ID, err.Error()); err != nil {
+			slog.Error("failed to mark email failed", slog.String("email_id", e.ID), slog.Any("error", err))
+		}
+		return
+	}
+	log.Warn("email delivery will be retried")
+	if err := d.outbox.RetryEmail(ctx, e.ID, time.Now().Add(d.backoff(e.Attempts)), err.Error()); err != nil {
+		slog.Error("failed to reschedule email", slog.String("email_id", e.ID), slog.Any("error", err))
+	}
+}
+
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.cfg.RetryBackoff
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
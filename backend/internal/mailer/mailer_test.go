@@ -0,0 +1,286 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"tender_srevice/internal/domain"
+)
+
+func TestRenderTemplates(t *testing.T) {
+	templates, err := LoadTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := struct {
+		FirstName, TenderID, BidID string
+		Data                       map[string]string
+	}{
+		FirstName: "Анна",
+		TenderID:  "t-1",
+		BidID:     "b-1",
+		Data:      map[string]string{"tenderName": `Ремонт "А&Б"`, "bidName": "<script>", "status": "Published"},
+	}
+
+	for _, lang := range Languages {
+		for _, name := range domain.NotificationTypes {
+			subject, body, err := templates.Render(lang, name, data)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", lang, name, err)
+			}
+			if !strings.Contains(subject, `Ремонт "А&Б"`) && !strings.Contains(subject, "Published") || strings.Contains(subject, "\n") {
+				t.Errorf("%s/%s: subject = %q", lang, name, subject)
+			}
+			if !strings.Contains(body, "<html lang=\""+lang+"\">") || !strings.Contains(body, "Анна") {
+				t.Errorf("%s/%s: body is not wrapped in the %s layout", lang, name, lang)
+			}
+			if strings.Contains(body, "<script>") {
+				t.Errorf("%s/%s: body is not escaped", lang, name)
+			}
+		}
+	}
+
+	if _, _, err := templates.Render("de", domain.NotificationBidCreated, data); err != nil {
+		t.Errorf("unknown language should fall back to %s: %v", DefaultLanguage, err)
+	}
+	if templates.Has("unknown") {
+		t.Error("Has(unknown) = true")
+	}
+}
+
+// captureServer — минимальный SMTP-сервер, принимающий письма без шифрования, как MailHog.
+type captureServer struct {
+	ln       net.Listener
+	rejectTo string
+
+	mu       sync.Mutex
+	messages []string
+}
+
+func newCaptureServer(t *testing.T) *captureServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &captureServer{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *captureServer) port() int { return s.ln.Addr().(*net.TCPAddr).Port }
+
+func (s *captureServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 capture ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 capture")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			if s.rejectTo != "" && strings.Contains(cmd, strings.ToUpper(s.rejectTo)) {
+				reply("550 no such user")
+				continue
+			}
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 end with .")
+			var msg strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				msg.WriteString(strings.TrimPrefix(l, "."))
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, msg.String())
+			s.mu.Unlock()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func (s *captureServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+func newTestSender(t *testing.T, srv *captureServer) *SMTPSender {
+	sender, err := NewSMTPSender(SMTPConfig{
+		Host:    "127.0.0.1",
+		Port:    srv.port(),
+		TLS:     TLSNone,
+		From:    "Тендеры <noreply@example.com>",
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sender
+}
+
+func TestSMTPSenderDeliversHTML(t *testing.T) {
+	srv := newCaptureServer(t)
+	sender := newTestSender(t, srv)
+
+	err := sender.Send(context.Background(), Message{To: "anna@example.com", Subject: "Новая заявка", HTML: "<p>Привет</p>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := srv.received()
+	if len(got) != 1 {
+		t.Fatalf("received %d messages, want 1", len(got))
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(got[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "Новая заявка" {
+		t.Errorf("Subject = %q", subject)
+	}
+	if to := msg.Header.Get("To"); to != "<anna@example.com>" {
+		t.Errorf("To = %q", to)
+	}
+	if ct := msg.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body, _ := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if strings.TrimSpace(string(body)) != "<p>Привет</p>" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestSMTPSenderPermanentErrors(t *testing.T) {
+	srv := newCaptureServer(t)
+	srv.rejectTo = "ghost@example.com"
+	sender := newTestSender(t, srv)
+
+	err := sender.Send(context.Background(), Message{To: "ghost@example.com", Subject: "x", HTML: "x"})
+	if !IsPermanent(err) {
+		t.Errorf("550 reply: err = %v, want permanent", err)
+	}
+	err = sender.Send(context.Background(), Message{To: "not an address", Subject: "x", HTML: "x"})
+	if !IsPermanent(err) {
+		t.Errorf("bad address: err = %v, want permanent", err)
+	}
+}
+
+type fakeOutbox struct {
+	emails  []*domain.OutboxEmail
+	sent    []string
+	retried map[string]time.Time
+	failed  []string
+}
+
+func (o *fakeOutbox) ClaimEmails(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEmail, error) {
+	if limit > len(o.emails) {
+		limit = len(o.emails)
+	}
+	claimed := o.emails[:limit]
+	o.emails = o.emails[limit:]
+	for _, e := range claimed {
+		e.Attempts++
+	}
+	return claimed, nil
+}
+
+func (o *fakeOutbox) MarkEmailSent(ctx context.Context, id string) error {
+	o.sent = append(o.sent, id)
+	return nil
+}
+
+func (o *fakeOutbox) RetryEmail(ctx context.Context, id string, next time.Time, lastErr string) error {
+	o.retried[id] = next
+	return nil
+}
+
+func (o *fakeOutbox) FailEmail(ctx context.Context, id, lastErr string) error {
+	o.failed = append(o.failed, id)
+	return nil
+}
+
+type fakeSender map[string]error
+
+func (s fakeSender) Send(ctx context.Context, msg Message) error { return s[msg.To] }
+
+func TestDispatcherRetriesAndFails(t *testing.T) {
+	outbox := &fakeOutbox{
+		retried: map[string]time.Time{},
+		emails: []*domain.OutboxEmail{
+			{ID: "ok", To: "ok@example.com"},
+			{ID: "temp", To: "temp@example.com"},
+			{ID: "perm", To: "perm@example.com"},
+			{ID: "last", To: "temp@example.com", Attempts: 2},
+		},
+	}
+	sender := fakeSender{
+		"temp@example.com": errors.New("connection refused"),
+		"perm@example.com": &PermanentError{Err: errors.New("550 no such user")},
+	}
+	d := NewDispatcher(outbox, sender, QueueConfig{BatchSize: 3, MaxAttempts: 3, RetryBackoff: time.Minute})
+
+	d.drain(context.Background())
+
+	if len(outbox.emails) != 0 {
+		t.Errorf("%d emails left in the queue", len(outbox.emails))
+	}
+	if strings.Join(outbox.sent, ",") != "ok" {
+		t.Errorf("sent = %v", outbox.sent)
+	}
+	if strings.Join(outbox.failed, ",") != "perm,last" {
+		t.Errorf("failed = %v", outbox.failed)
+	}
+	next, ok := outbox.retried["temp"]
+	if !ok || len(outbox.retried) != 1 {
+		t.Fatalf("retried = %v", outbox.retried)
+	}
+	if wait := time.Until(next); wait < 50*time.Second || wait > time.Minute {
+		t.Errorf("first retry in %v, want about a minute", wait)
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	d := NewDispatcher(nil, nil, QueueConfig{RetryBackoff: time.Minute})
+	for attempt, want := range map[int]time.Duration{1: time.Minute, 2: 2 * time.Minute, 4: 8 * time.Minute, 40: maxBackoff} {
+		if got := d.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Режимы шифрования соединения с SMTP-сервером.
+const (
+	TLSNone     = "none"     // без шифрования, например для локального перехватчика писем
+	TLSStartTLS = "starttls" // обязательный STARTTLS, обычно порт 587
+	TLSImplicit = "tls"      // TLS с первого байта, обычно порт 465
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	TLS      string
+	// From — адрес отправителя, допускается вид "Имя <addr@example.com>"
+	From    string
+	Timeout time.Duration
+}
+
+// Message — письмо одному получателю.
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+}
+
+type SMTPSender struct {
+	cfg  SMTPConfig
+	from *mail.Address
+}
+
+func NewSMTPSender(cfg SMTPConfig) (*SMTPSender, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", cfg.From, err)
+	}
+	switch cfg.TLS {
+	case TLSNone, TLSStartTLS, TLSImplicit:
+	default:
+		return nil, fmt.Errorf("unknown SMTP TLS mode %q", cfg.TLS)
+	}
+	return &SMTPSender{cfg: cfg, from: from}, nil
+}
+
+// Send доставляет письмо за одну SMTP-сессию.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return &PermanentError{Err: fmt.Errorf("invalid recipient address %q: %w", msg.To, err)}
+	}
+	body, err := s.build(to, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if s.cfg.TLS == TLSImplicit {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.cfg.Host})
+	}
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer c.Close()
+
+	if s.cfg.TLS == TLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP server does not support STARTTLS")
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return classify("SMTP authentication failed", err)
+		}
+	}
+	if err := c.Mail(s.from.Address); err != nil {
+		return classify("MAIL FROM rejected", err)
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return classify("RCPT TO rejected", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return classify("DATA rejected", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return classify("message rejected", err)
+	}
+	return c.Quit()
+}
+
+// build собирает письмо text/html в UTF-8 с quoted-printable телом.
+func (s *SMTPSender) build(to *mail.Address, msg Message, now time.Time) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	domain := s.from.Address[strings.LastIndex(s.from.Address, "@")+1:]
+
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", s.from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", "<"+hex.EncodeToString(id)+"@"+domain+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/html; charset="utf-8"`)
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(msg.HTML)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// PermanentError — отказ, который не исчезнет при повторной отправке (ответ 5xx, неверный адрес).
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// IsPermanent сообщает, что повторять отправку бессмысленно.
+func IsPermanent(err error) bool {
+	var p *PermanentError
+	return errors.As(err, &p)
+}
+
+func classify(msg string, err error) error {
+	wrapped := fmt.Errorf("%s: %w", msg, err)
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return &PermanentError{Err: wrapped}
+	}
+	return wrapped
+}
@@ -0,0 +1,86 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// DefaultLanguage — язык писем, если шаблона на языке сотрудника нет.
+const DefaultLanguage = "ru"
+
+// Languages — языки, на которых есть шаблоны писем.
+var Languages = []string{"ru", "en"}
+
+//go:embed templates
+var templateFS embed.FS
+
+// Templates — шаблоны писем templates/<язык>/<имя>.html. Каждый шаблон определяет
+// блоки "subject" и "body"; "body" выводится внутри общего "layout" своего языка.
+type Templates struct {
+	byLang map[string]map[string]*template.Template
+}
+
+// LoadTemplates разбирает встроенные шаблоны.
+func LoadTemplates() (*Templates, error) {
+	t := &Templates{byLang: map[string]map[string]*template.Template{}}
+	langs, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, lang := range langs {
+		dir := path.Join("templates", lang.Name())
+		files, err := fs.Glob(templateFS, dir+"/*.html")
+		if err != nil {
+			return nil, err
+		}
+		t.byLang[lang.Name()] = map[string]*template.Template{}
+		for _, file := range files {
+			name := strings.TrimSuffix(path.Base(file), ".html")
+			if name == "layout" {
+				continue
+			}
+			tmpl, err := template.ParseFS(templateFS, path.Join(dir, "layout.html"), file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse mail template %s: %w", file, err)
+			}
+			t.byLang[lang.Name()][name] = tmpl.Option("missingkey=zero")
+		}
+	}
+	return t, nil
+}
+
+// Has сообщает, есть ли шаблон name хотя бы на языке по умолчанию.
+func (t *Templates) Has(name string) bool {
+	_, ok := t.byLang[DefaultLanguage][name]
+	return ok
+}
+
+// Render формирует тему и HTML письма name на языке lang, при отсутствии — на DefaultLanguage.
+func (t *Templates) Render(lang, name string, data interface{}) (subject, body string, err error) {
+	tmpl, ok := t.byLang[lang][name]
+	if !ok {
+		tmpl, ok = t.byLang[DefaultLanguage][name]
+	}
+	if !ok {
+		return "", "", fmt.Errorf("mail template %q not found", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject of %q: %w", name, err)
+	}
+	// Тема — обычный текст: экранирование html/template в ней не нужно
+	subject = strings.Join(strings.Fields(html.UnescapeString(buf.String())), " ")
+
+	buf.Reset()
+	if err := tmpl.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return "", "", fmt.Errorf("failed to render body of %q: %w", name, err)
+	}
+	return subject, buf.String(), nil
+}
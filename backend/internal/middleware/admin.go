@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// AdminOnly пропускает только запросы с заголовком "Authorization: Bearer <token>".
+// При пустом токене административные эндпоинты недоступны.
+func AdminOnly(token string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				http.Error(w, "Admin API is disabled", http.StatusForbidden)
+				return
+			}
+			got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				http.Error(w, "Admin token is required", http.StatusUnauthorized)
+				return
+			}
+			next(w, r)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"tender_srevice/internal/openapi"
+
+	"github.com/gorilla/mux"
+)
+
+// maxValidatedBodySize ограничивает размер тела, которое валидатор читает в память.
+const maxValidatedBodySize = 1 << 20
+
+// ValidateRequest проверяет параметры пути, строки запроса и JSON-тело
+// по описанию операции в спецификации OpenAPI. Маршруты без описания пропускаются.
+func ValidateRequest(spec *openapi.Spec) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := mux.CurrentRoute(r)
+			if route == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			tpl, err := route.GetPathTemplate()
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			op := spec.Operation(tpl, r.Method)
+			if op == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err := validateRequest(r, op); err != nil {
+				slog.InfoContext(r.Context(), "request rejected by openapi validation",
+					slog.String("operation", op.OperationID), slog.String("reason", err.Error()))
+				http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func validateRequest(r *http.Request, op *openapi.Operation) error {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+	for _, p := range op.Parameters {
+		var (
+			value   string
+			present bool
+		)
+		switch p.In {
+		case "path":
+			value, present = vars[p.Name]
+		case "query":
+			present = query.Has(p.Name)
+			value = query.Get(p.Name)
+		default:
+			continue
+		}
+		if !present || value == "" {
+			if p.Required {
+				return fmt.Errorf("%s parameter %q is required", p.In, p.Name)
+			}
+			continue
+		}
+		if err := p.Schema.ValidateString(p.Name, value); err != nil {
+			return err
+		}
+	}
+
+	if op.RequestBody == nil {
+		return nil
+	}
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok {
+		return nil
+	}
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if mt, _, err := mime.ParseMediaType(ct); err != nil || mt != "application/json" {
+			return fmt.Errorf("content type must be application/json")
+		}
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(r.Body, maxValidatedBodySize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(raw) > maxValidatedBodySize {
+		return fmt.Errorf("request body is too large")
+	}
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+
+	if len(bytes.TrimSpace(raw)) == 0 {
+		if op.RequestBody.Required {
+			return fmt.Errorf("request body is required")
+		}
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var body interface{}
+	if err := dec.Decode(&body); err != nil {
+		return fmt.Errorf("request body is not valid JSON")
+	}
+	return media.Schema.Validate("body", body)
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"tender_srevice/internal/ratelimit"
+
+	"github.com/gorilla/mux"
+)
+
+// maxIdentityBodySize — сколько байт тела читается, чтобы найти имя пользователя.
+const maxIdentityBodySize = 64 << 10
+
+// RateLimit ограничивает частоту запросов по группе маршрутов ("tenders:read",
+// "bids:write" и т.п.). Каждый запрос списывает токен из корзины IP-адреса и,
+// если запрос подписан сотрудником, из корзины этого сотрудника.
+func RateLimit(limiter *ratelimit.Limiter, trustForwardedFor bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutation := isMutation(r.Method)
+			group := routeGroup(r, mutation)
+
+			keys := []string{"ip:" + clientIP(r, trustForwardedFor)}
+			if id := employeeIdentity(r); id != "" {
+				keys = append(keys, "employee:"+id)
+			}
+
+			decision, err := limiter.Allow(r.Context(), group, mutation, keys)
+			if err != nil {
+				slog.WarnContext(r.Context(), "rate limiter store failed, request allowed", slog.Any("error", err))
+			}
+			if !decision.Allowed {
+				seconds := int(math.Ceil(decision.RetryAfter.Seconds()))
+				if seconds < 1 {
+					seconds = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				slog.InfoContext(r.Context(), "request rate limited", slog.String("group", group), slog.Int("retry_after", seconds))
+				http.Error(w, "Too many requests", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// routeGroup строит группу из ресурса в шаблоне пути: /api/bids/{bidId}/edit -> bids:write.
+func routeGroup(r *http.Request, mutation bool) string {
+	resource := "other"
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			parts := strings.Split(strings.Trim(tpl, "/"), "/")
+			if len(parts) > 1 && parts[0] == "api" {
+				resource = parts[1]
+			}
+		}
+	}
+	if mutation {
+		return resource + ":write"
+	}
+	return resource + ":read"
+}
+
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			return strings.TrimSpace(strings.Split(fwd, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// employeeIdentity ищет сотрудника в строке запроса или в JSON-теле
+// (username, creatorUsername, authorId) и возвращает тело обратно в запрос.
+func employeeIdentity(r *http.Request) string {
+	if username := r.URL.Query().Get("username"); username != "" {
+		return username
+	}
+	if r.Body == nil || r.ContentLength > maxIdentityBodySize {
+		return ""
+	}
+	raw, err := io.ReadAll(io.LimitReader(r.Body, maxIdentityBodySize+1))
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(raw), r.Body))
+	if err != nil || len(raw) > maxIdentityBodySize {
+		return ""
+	}
+
+	var body struct {
+		Username        string `json:"username"`
+		CreatorUsername string `json:"creatorUsername"`
+		AuthorID        string `json:"authorId"`
+	}
+	if json.Unmarshal(raw, &body) != nil {
+		return ""
+	}
+	switch {
+	case body.Username != "":
+		return body.Username
+	case body.CreatorUsername != "":
+		return body.CreatorUsername
+	case body.AuthorID != "":
+		return body.AuthorID
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"tender_srevice/internal/logger"
+)
+
+// RequestIDHeader — заголовок, через который идентификатор запроса передается клиенту и обратно.
+const RequestIDHeader = "X-Request-ID"
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID берет идентификатор из заголовка X-Request-ID или генерирует новый,
+// кладет его в контекст запроса и возвращает в ответе.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := EnsureRequestID(r.Header.Get(RequestIDHeader))
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logger.WithRequestID(r.Context(), id)))
+	})
+}
+
+// EnsureRequestID возвращает id, если он годится в идентификатор запроса, иначе новый.
+func EnsureRequestID(id string) string {
+	if !validRequestID.MatchString(id) {
+		return newRequestID()
+	}
+	return id
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
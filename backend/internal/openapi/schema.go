@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Schema — поддерживаемое подмножество JSON Schema из OpenAPI 3.0.
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Nullable             bool               `json:"nullable"`
+	Enum                 []interface{}      `json:"enum"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+
+	resolved bool
+	target   *Schema
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Validate проверяет значение, полученное json.Decoder с UseNumber.
+func (s *Schema) Validate(path string, value interface{}) error {
+	if s == nil {
+		return nil
+	}
+	if s.target != nil {
+		return s.target.Validate(path, value)
+	}
+	if value == nil {
+		if s.Nullable {
+			return nil
+		}
+		return fmt.Errorf("%s: must not be null", path)
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: must be an object", path)
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s: is required", join(path, name))
+			}
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			prop, ok := s.Properties[k]
+			if !ok {
+				if string(s.AdditionalProperties) == "false" {
+					return fmt.Errorf("%s: unknown field", join(path, k))
+				}
+				continue
+			}
+			if err := prop.Validate(join(path, k), obj[k]); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: must be an array", path)
+		}
+		for i, item := range arr {
+			if err := s.Items.Validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: must be a string", path)
+		}
+		n := utf8.RuneCountInString(str)
+		if s.MinLength != nil && n < *s.MinLength {
+			return fmt.Errorf("%s: must be at least %d characters", path, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			return fmt.Errorf("%s: must be at most %d characters", path, *s.MaxLength)
+		}
+		if s.Format == "uuid" && !uuidRe.MatchString(str) {
+			return fmt.Errorf("%s: must be a UUID", path)
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				return fmt.Errorf("%s: must be an RFC 3339 date-time", path)
+			}
+		}
+	case "integer", "number":
+		num, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: must be a %s", path, s.Type)
+		}
+		f, err := num.Float64()
+		if err != nil {
+			return fmt.Errorf("%s: must be a %s", path, s.Type)
+		}
+		if s.Type == "integer" {
+			if _, err := num.Int64(); err != nil {
+				return fmt.Errorf("%s: must be an integer", path)
+			}
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			return fmt.Errorf("%s: must be >= %v", path, *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			return fmt.Errorf("%s: must be <= %v", path, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: must be a boolean", path)
+		}
+	}
+
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		return fmt.Errorf("%s: must be one of %v", path, s.Enum)
+	}
+	return nil
+}
+
+// ValidateString проверяет параметр пути или строки запроса,
+// предварительно приводя его к типу из схемы.
+func (s *Schema) ValidateString(path, raw string) error {
+	if s == nil {
+		return nil
+	}
+	if s.target != nil {
+		return s.target.ValidateString(path, raw)
+	}
+	switch s.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return fmt.Errorf("%s: must be a %s", path, s.Type)
+		}
+		return s.Validate(path, json.Number(raw))
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: must be a boolean", path)
+		}
+		return s.Validate(path, b)
+	default:
+		return s.Validate(path, raw)
+	}
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//go:embed openapi.json
+var specJSON []byte
+
+//go:embed ui.html
+var uiHTML []byte
+
+// Spec — подмножество документа OpenAPI 3, которое нужно для валидации запросов.
+type Spec struct {
+	OpenAPI    string                           `json:"openapi"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type Operation struct {
+	OperationID string       `json:"operationId"`
+	Parameters  []*Parameter `json:"parameters"`
+	RequestBody *RequestBody `json:"requestBody"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Load разбирает встроенный в бинарник документ OpenAPI.
+func Load() (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(specJSON, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi spec: %w", err)
+	}
+	for path, item := range spec.Paths {
+		for method, op := range item {
+			if err := spec.resolveOperation(op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+		}
+	}
+	return &spec, nil
+}
+
+// MustLoad — Load, который паникует на некорректном встроенном документе.
+func MustLoad() *Spec {
+	spec, err := Load()
+	if err != nil {
+		panic(err)
+	}
+	return spec
+}
+
+// Operation ищет операцию по шаблону пути роутера и HTTP-методу.
+func (s *Spec) Operation(pathTemplate, method string) *Operation {
+	item, ok := s.Paths[pathTemplate]
+	if !ok {
+		return nil
+	}
+	return item[strings.ToLower(method)]
+}
+
+func (s *Spec) resolveOperation(op *Operation) error {
+	for _, p := range op.Parameters {
+		if err := s.resolve(p.Schema); err != nil {
+			return err
+		}
+	}
+	if op.RequestBody != nil {
+		for _, mt := range op.RequestBody.Content {
+			if err := s.resolve(mt.Schema); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve подставляет схемы из components вместо ссылок $ref.
+func (s *Spec) resolve(schema *Schema) error {
+	if schema == nil || schema.resolved {
+		return nil
+	}
+	schema.resolved = true
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		target, ok := s.Components.Schemas[name]
+		if !ok {
+			return fmt.Errorf("unknown schema reference %q", schema.Ref)
+		}
+		if err := s.resolve(target); err != nil {
+			return err
+		}
+		schema.target = target
+		return nil
+	}
+	for _, prop := range schema.Properties {
+		if err := s.resolve(prop); err != nil {
+			return err
+		}
+	}
+	return s.resolve(schema.Items)
+}
+
+// SpecHandler отдает документ OpenAPI в формате JSON.
+func SpecHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(specJSON)
+}
+
+// UIHandler отдает страницу для просмотра документации и отправки запросов.
+func UIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(uiHTML)
+}
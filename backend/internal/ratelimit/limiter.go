@@ -0,0 +1,151 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Rule — параметры token bucket: скорость пополнения в токенах в секунду и емкость.
+type Rule struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// Store хранит состояние корзин. Take атомарно пытается забрать один токен
+// и возвращает, сколько токенов было доступно до списания. Refund возвращает
+// в корзину токен, списанный Take, не превышая емкость.
+type Store interface {
+	Take(ctx context.Context, key string, rule Rule) (available float64, err error)
+	Refund(ctx context.Context, key string, rule Rule) error
+}
+
+// Decision — результат проверки запроса.
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+// GroupStats — счетчики по группе маршрутов.
+type GroupStats struct {
+	Allowed uint64 `json:"allowed"`
+	Denied  uint64 `json:"denied"`
+}
+
+// Stats — снимок состояния лимитера для админского эндпоинта.
+type Stats struct {
+	Store  string                `json:"store"`
+	Read   Rule                  `json:"read"`
+	Write  Rule                  `json:"write"`
+	Groups map[string]GroupStats `json:"groups"`
+	Errors uint64                `json:"storeErrors"`
+	Extra  map[string]int        `json:"extra,omitempty"`
+}
+
+// Limiter применяет отдельные правила к чтению и к изменяющим запросам.
+type Limiter struct {
+	store     Store
+	storeName string
+	read      Rule
+	write     Rule
+
+	mu     sync.Mutex
+	groups map[string]*GroupStats
+	errors uint64
+}
+
+func New(store Store, storeName string, read, write Rule) *Limiter {
+	return &Limiter{
+		store:     store,
+		storeName: storeName,
+		read:      read,
+		write:     write,
+		groups:    map[string]*GroupStats{},
+	}
+}
+
+// Allow списывает по токену из каждой корзины keys в пределах группы.
+// Запрос пропускается, только если токен нашелся во всех корзинах; иначе
+// списанные токены возвращаются, чтобы отклоненный запрос не расходовал
+// общие корзины (например, корзину IP).
+// Ошибки хранилища не блокируют запросы: лимитер деградирует до пропуска.
+func (l *Limiter) Allow(ctx context.Context, group string, mutation bool, keys []string) (Decision, error) {
+	rule := l.read
+	if mutation {
+		rule = l.write
+	}
+
+	decision := Decision{Allowed: true}
+	var storeErr error
+	var taken []string
+	for _, key := range keys {
+		bucketKey := group + "|" + key
+		available, err := l.store.Take(ctx, bucketKey, rule)
+		if err != nil {
+			storeErr = err
+			continue
+		}
+		if available >= 1 {
+			taken = append(taken, bucketKey)
+		} else {
+			decision.Allowed = false
+			wait := time.Duration(math.Ceil((1 - available) / rule.Rate * float64(time.Second)))
+			if wait > decision.RetryAfter {
+				decision.RetryAfter = wait
+			}
+		}
+	}
+	if !decision.Allowed {
+		for _, bucketKey := range taken {
+			if err := l.store.Refund(ctx, bucketKey, rule); err != nil {
+				storeErr = err
+			}
+		}
+	}
+
+	l.mu.Lock()
+	s, ok := l.groups[group]
+	if !ok {
+		s = &GroupStats{}
+		l.groups[group] = s
+	}
+	if decision.Allowed {
+		s.Allowed++
+	} else {
+		s.Denied++
+	}
+	if storeErr != nil {
+		l.errors++
+	}
+	l.mu.Unlock()
+
+	return decision, storeErr
+}
+
+// Stats возвращает копию счетчиков.
+func (l *Limiter) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := Stats{
+		Store:  l.storeName,
+		Read:   l.read,
+		Write:  l.write,
+		Groups: make(map[string]GroupStats, len(l.groups)),
+		Errors: l.errors,
+	}
+	names := make([]string, 0, len(l.groups))
+	for name := range l.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		stats.Groups[name] = *l.groups[name]
+	}
+	if m, ok := l.store.(*MemoryStore); ok {
+		stats.Extra = map[string]int{"buckets": m.Len()}
+	}
+	return stats
+}
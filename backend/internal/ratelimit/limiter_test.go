@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAllowDeniedRequestKeepsOtherBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	rule := Rule{Rate: 1, Burst: 2}
+	l := New(store, "memory", rule, rule)
+	ctx := context.Background()
+
+	// alice исчерпывает свою корзину с одного адреса
+	for i := 0; i < 2; i++ {
+		if d, _ := l.Allow(ctx, "tenders", false, []string{"ip:10.0.0.1", "user:alice"}); !d.Allowed {
+			t.Fatalf("request %d denied", i+1)
+		}
+	}
+	if d, _ := l.Allow(ctx, "tenders", false, []string{"ip:10.0.0.2", "user:alice"}); d.Allowed || d.RetryAfter <= 0 {
+		t.Fatalf("decision = %+v, want denied with RetryAfter", d)
+	}
+
+	// Отклоненный запрос alice не расходует токен второго адреса: bob проходит дважды
+	for i := 0; i < 2; i++ {
+		if d, _ := l.Allow(ctx, "tenders", false, []string{"ip:10.0.0.2", "user:bob"}); !d.Allowed {
+			t.Fatalf("bob's request %d denied: the denied request drained the shared IP bucket", i+1)
+		}
+	}
+	if stats := l.Stats().Groups["tenders"]; stats.Allowed != 4 || stats.Denied != 1 {
+		t.Errorf("stats = %+v, want 4 allowed and 1 denied", stats)
+	}
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// idleBucketTTL — через сколько неиспользуемая корзина удаляется из памяти.
+const idleBucketTTL = 10 * time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryStore хранит корзины в памяти процесса; подходит для одного экземпляра сервиса.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, rule Rule) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(rule.Burst), b.tokens+now.Sub(b.updated).Seconds()*rule.Rate)
+	b.updated = now
+
+	available := b.tokens
+	if available >= 1 {
+		b.tokens--
+	}
+	return available, nil
+}
+
+func (s *MemoryStore) Refund(_ context.Context, key string, rule Rule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok := s.buckets[key]; ok {
+		b.tokens = math.Min(float64(rule.Burst), b.tokens+1)
+	}
+	return nil
+}
+
+// Len возвращает количество корзин в памяти.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets)
+}
+
+// sweep раз в idleBucketTTL удаляет давно не использованные корзины.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < idleBucketTTL {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) > idleBucketTTL {
+			delete(s.buckets, key)
+		}
+	}
+}
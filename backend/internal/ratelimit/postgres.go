@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"tender_srevice/internal/repository"
+	"time"
+)
+
+// PostgresStore хранит корзины в таблице rate_limit_buckets,
+// чтобы несколько реплик сервиса делили общие лимиты.
+type PostgresStore struct {
+	repo      *repository.PostgresRepository
+	lastSweep atomic.Int64
+}
+
+func NewPostgresStore(repo *repository.PostgresRepository) *PostgresStore {
+	s := &PostgresStore{repo: repo}
+	s.lastSweep.Store(time.Now().UnixNano())
+	return s
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, rule Rule) (float64, error) {
+	s.maybeSweep()
+	return s.repo.TakeRateLimitToken(ctx, key, rule.Rate, rule.Burst)
+}
+
+func (s *PostgresStore) Refund(ctx context.Context, key string, rule Rule) error {
+	return s.repo.RefundRateLimitToken(ctx, key, rule.Burst)
+}
+
+// maybeSweep раз в idleBucketTTL удаляет старые корзины в фоне.
+func (s *PostgresStore) maybeSweep() {
+	last := s.lastSweep.Load()
+	now := time.Now().UnixNano()
+	if time.Duration(now-last) < idleBucketTTL || !s.lastSweep.CompareAndSwap(last, now) {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if _, err := s.repo.DeleteIdleRateLimitBuckets(ctx, int(idleBucketTTL.Seconds())); err != nil {
+			slog.Warn("failed to delete idle rate limit buckets", slog.Any("error", err))
+		}
+	}()
+}
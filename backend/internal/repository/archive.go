@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+)
+
+// archivedTenderColumns — колонки тендера вместе с deleted_at для archivedTenderScanDest.
+const archivedTenderColumns = `id, name, description, status, service_type, organization_id, creator_username, version,
+		submission_deadline, sealed, deleted_at`
+
+func archivedTenderScanDest(t *domain.Tender) []interface{} {
+	return []interface{}{
+		&t.ID, &t.Name, &t.Description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername, &t.Version,
+		&t.SubmissionDeadline, &t.Sealed, &t.DeletedAt,
+	}
+}
+
+// GetTenderIncludingArchived — GetTenderByID, который находит и архивные тендеры.
+func (r *PostgresRepository) GetTenderIncludingArchived(ctx context.Context, tenderID string) (*domain.Tender, error) {
+	query := `SELECT ` + archivedTenderColumns + ` FROM tenders WHERE id = $1`
+	var t domain.Tender
+	err := r.DB.QueryRowContext(ctx, query, tenderID).Scan(archivedTenderScanDest(&t)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, ErrTenderNotFound)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to get tender: %w", err))
+	}
+	return &t, nil
+}
+
+// ArchiveTender помечает тендер удаленным. Новой версии тендера при этом не появляется.
+func (r *PostgresRepository) ArchiveTender(ctx context.Context, tenderID, username string) (*domain.Tender, error) {
+	query := `UPDATE tenders SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $2
+			  WHERE id = $1 AND deleted_at IS NULL
+			  RETURNING ` + archivedTenderColumns
+	return r.setTenderArchived(ctx, query, ErrAlreadyArchived, tenderID, username)
+}
+
+func (r *PostgresRepository) RestoreTender(ctx context.Context, tenderID string) (*domain.Tender, error) {
+	query := `UPDATE tenders SET deleted_at = NULL, deleted_by = NULL
+			  WHERE id = $1 AND deleted_at IS NOT NULL
+			  RETURNING ` + archivedTenderColumns
+	return r.setTenderArchived(ctx, query, ErrNotArchived, tenderID)
+}
+
+func (r *PostgresRepository) setTenderArchived(ctx context.Context, query string, conflict error, args ...interface{}) (*domain.Tender, error) {
+	var t domain.Tender
+	err := r.DB.QueryRowContext(ctx, query, args...).Scan(archivedTenderScanDest(&t)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, conflict)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to archive tender: %w", err))
+	}
+	return &t, nil
+}
+
+// ArchivedTenders возвращает архивные тендеры организаций, за которые отвечает пользователь,
+// от недавно архивированных к давним.
+func (r *PostgresRepository) ArchivedTenders(ctx context.Context, username string) ([]*domain.Tender, error) {
+	query := `SELECT ` + archivedTenderColumns + `
+			  FROM tenders
+			  WHERE deleted_at IS NOT NULL AND organization_id IN (
+			  	SELECT org_resp.organization_id
+			  	FROM organization_responsible org_resp
+			  	JOIN employee e ON e.id = org_resp.user_id
+			  	WHERE e.username = $1)
+			  ORDER BY deleted_at DESC, id`
+	rows, err := r.DB.QueryContext(ctx, query, username)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query archived tenders: %w", err))
+	}
+	defer rows.Close()
+
+	tenders := []*domain.Tender{}
+	for rows.Next() {
+		var t domain.Tender
+		if err := rows.Scan(archivedTenderScanDest(&t)...); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan tender: %w", err))
+		}
+		tenders = append(tenders, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate archived tenders: %w", err))
+	}
+	return tenders, nil
+}
+
+func archivedBidScanDest(b *domain.Bid) []interface{} {
+	return append(bidScanDest(b), &b.DeletedAt)
+}
+
+// GetBidIncludingArchived — GetBidByID, который находит и архивные предложения.
+func (r *PostgresRepository) GetBidIncludingArchived(ctx context.Context, bidID string) (*domain.Bid, error) {
+	query := `SELECT ` + bidColumns + `, deleted_at FROM bid WHERE id = $1`
+	var b domain.Bid
+	err := r.DB.QueryRowContext(ctx, query, bidID).Scan(archivedBidScanDest(&b)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, ErrBidNotFound)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to get bid: %w", err))
+	}
+	return &b, nil
+}
+
+// ArchiveBid помечает предложение удаленным. Новой версии предложения при этом не появляется.
+func (r *PostgresRepository) ArchiveBid(ctx context.Context, bidID, username string) (*domain.Bid, error) {
+	query := `UPDATE bid SET deleted_at = CURRENT_TIMESTAMP, deleted_by = $2
+			  WHERE id = $1 AND deleted_at IS NULL
+			  RETURNING ` + bidColumns + `, deleted_at`
+	return r.setBidArchived(ctx, query, ErrAlreadyArchived, bidID, username)
+}
+
+func (r *PostgresRepository) RestoreBid(ctx context.Context, bidID string) (*domain.Bid, error) {
+	query := `UPDATE bid SET deleted_at = NULL, deleted_by = NULL
+			  WHERE id = $1 AND deleted_at IS NOT NULL
+			  RETURNING ` + bidColumns + `, deleted_at`
+	return r.setBidArchived(ctx, query, ErrNotArchived, bidID)
+}
+
+func (r *PostgresRepository) setBidArchived(ctx context.Context, query string, conflict error, args ...interface{}) (*domain.Bid, error) {
+	var b domain.Bid
+	err := r.DB.QueryRowContext(ctx, query, args...).Scan(archivedBidScanDest(&b)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, conflict)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to archive bid: %w", err))
+	}
+	return &b, nil
+}
+
+// ArchivedBidsByAuthorID возвращает архивные предложения автора, от недавно архивированных к давним.
+func (r *PostgresRepository) ArchivedBidsByAuthorID(ctx context.Context, authorID string) ([]*domain.Bid, error) {
+	query := `SELECT ` + bidColumns + `, deleted_at
+			  FROM bid
+			  WHERE author_id = $1 AND deleted_at IS NOT NULL
+			  ORDER BY deleted_at DESC, id`
+	rows, err := r.DB.QueryContext(ctx, query, authorID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query archived bids: %w", err))
+	}
+	defer rows.Close()
+
+	bids := []*domain.Bid{}
+	for rows.Next() {
+		var b domain.Bid
+		if err := rows.Scan(archivedBidScanDest(&b)...); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan bid: %w", err))
+		}
+		bids = append(bids, &b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate archived bids: %w", err))
+	}
+	return bids, nil
+}
+
+// PurgeTender безвозвратно удаляет архивный тендер вместе с его версиями, предложениями
+// и всем, что к ним относится. Возвращает ключи вложений, которые нужно удалить из хранилища.
+func (r *PostgresRepository) PurgeTender(ctx context.Context, tenderID string) ([]string, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	if err := lockArchived(ctx, tx, `SELECT deleted_at IS NOT NULL FROM tenders WHERE id = $1 FOR UPDATE`, tenderID, ErrTenderNotFound); err != nil {
+		return nil, err
+	}
+	keys, err := deleteAttachments(ctx, tx,
+		`DELETE FROM attachments
+		 WHERE tender_id = $1 OR bid_id IN (SELECT id FROM bid WHERE tender_id = $1)
+		 RETURNING storage_key`, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	// Версии и предложения больше не удаляются каскадом, поэтому идут первыми
+	for _, query := range []string{
+		`DELETE FROM bid_versions WHERE bid_id IN (SELECT id FROM bid WHERE tender_id = $1)`,
+		`DELETE FROM bid WHERE tender_id = $1`,
+		`DELETE FROM tender_versions WHERE tender_id = $1`,
+		`DELETE FROM service_type_legacy_values WHERE tender_id = $1`,
+		`DELETE FROM tenders WHERE id = $1`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, tenderID); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to purge tender: %w", err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to commit tender purge: %w", err))
+	}
+	return keys, nil
+}
+
+// PurgeBid безвозвратно удаляет архивное предложение вместе с версиями.
+// Возвращает ключи вложений, которые нужно удалить из хранилища.
+func (r *PostgresRepository) PurgeBid(ctx context.Context, bidID string) ([]string, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	if err := lockArchived(ctx, tx, `SELECT deleted_at IS NOT NULL FROM bid WHERE id = $1 FOR UPDATE`, bidID, ErrBidNotFound); err != nil {
+		return nil, err
+	}
+	keys, err := deleteAttachments(ctx, tx, `DELETE FROM attachments WHERE bid_id = $1 RETURNING storage_key`, bidID)
+	if err != nil {
+		return nil, err
+	}
+	for _, query := range []string{
+		`DELETE FROM bid_versions WHERE bid_id = $1`,
+		`DELETE FROM bid WHERE id = $1`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, bidID); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to purge bid: %w", err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to commit bid purge: %w", err))
+	}
+	return keys, nil
+}
+
+// lockArchived блокирует строку и проверяет, что она в архиве.
+func lockArchived(ctx context.Context, tx *sql.Tx, query, id string, notFound error) error {
+	var archived bool
+	err := tx.QueryRowContext(ctx, query, id).Scan(&archived)
+	if errors.Is(err, sql.ErrNoRows) {
+		return wrapError(ctx, notFound)
+	}
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to lock row for purge: %w", err))
+	}
+	if !archived {
+		return wrapError(ctx, ErrNotArchived)
+	}
+	return nil
+}
+
+func deleteAttachments(ctx context.Context, tx *sql.Tx, query, id string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to delete attachments: %w", err))
+	}
+	defer rows.Close()
+
+	keys := []string{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan attachment key: %w", err))
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to delete attachments: %w", err))
+	}
+	return keys, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+)
+
+const attachmentColumns = `id, tender_id, bid_id, file_name, content_type, size_bytes, sha256, storage_key, uploaded_by, created_at`
+
+func attachmentScanDest(a *domain.Attachment) []interface{} {
+	return []interface{}{
+		&a.ID, &a.TenderID, &a.BidID, &a.FileName, &a.ContentType, &a.Size, &a.SHA256, &a.StorageKey, &a.UploadedBy, &a.CreatedAt,
+	}
+}
+
+// attachmentOwnerColumn возвращает колонку владельца для kind из domain.AttachmentOwner*.
+func attachmentOwnerColumn(kind string) (string, error) {
+	switch kind {
+	case domain.AttachmentOwnerTender:
+		return "tender_id", nil
+	case domain.AttachmentOwnerBid:
+		return "bid_id", nil
+	}
+	return "", fmt.Errorf("unknown attachment owner %q", kind)
+}
+
+func (r *PostgresRepository) InsertAttachment(ctx context.Context, a *domain.Attachment) error {
+	query := `INSERT INTO attachments (tender_id, bid_id, file_name, content_type, size_bytes, sha256, storage_key, uploaded_by)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING id, created_at`
+	err := r.DB.QueryRowContext(ctx, query,
+		a.TenderID, a.BidID, a.FileName, a.ContentType, a.Size, a.SHA256, a.StorageKey, a.UploadedBy).
+		Scan(&a.ID, &a.CreatedAt)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to insert attachment: %w", err))
+	}
+	return nil
+}
+
+func (r *PostgresRepository) ListAttachments(ctx context.Context, kind, ownerID string) ([]*domain.Attachment, error) {
+	column, err := attachmentOwnerColumn(kind)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE ` + column + ` = $1 ORDER BY created_at ASC, id ASC`
+	rows, err := r.DB.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query attachments: %w", err))
+	}
+	defer rows.Close()
+
+	attachments := []*domain.Attachment{}
+	for rows.Next() {
+		var a domain.Attachment
+		if err := rows.Scan(attachmentScanDest(&a)...); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan attachment: %w", err))
+		}
+		attachments = append(attachments, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate attachments: %w", err))
+	}
+	return attachments, nil
+}
+
+// GetAttachment ищет вложение только среди вложений указанного владельца.
+func (r *PostgresRepository) GetAttachment(ctx context.Context, kind, ownerID, attachmentID string) (*domain.Attachment, error) {
+	column, err := attachmentOwnerColumn(kind)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1 AND ` + column + ` = $2`
+	var a domain.Attachment
+	err = r.DB.QueryRowContext(ctx, query, attachmentID, ownerID).Scan(attachmentScanDest(&a)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, ErrAttachmentNotFound)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to get attachment: %w", err))
+	}
+	return &a, nil
+}
+
+func (r *PostgresRepository) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM attachments WHERE id = $1`, attachmentID)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to delete attachment: %w", err))
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return wrapError(ctx, ErrAttachmentNotFound)
+	}
+	return nil
+}
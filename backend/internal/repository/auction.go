@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+	"time"
+)
+
+const auctionColumns = `tender_id, starts_at, duration_seconds, ends_at, currency, start_price, min_decrement,
+		extension_window_seconds, extension_seconds, extensions, best_amount`
+
+func auctionScanDest(a *domain.Auction) []interface{} {
+	return []interface{}{
+		&a.TenderID, &a.StartsAt, &a.DurationSeconds, &a.EndsAt, &a.Currency, &a.StartPrice, &a.MinDecrement,
+		&a.ExtensionWindowSeconds, &a.ExtensionSeconds, &a.Extensions, &a.BestAmount,
+	}
+}
+
+// UpsertAuction создает или меняет настройки аукциона. Начавшийся аукцион не меняется:
+// в этом случае возвращается false. EndsAt пересчитывается из StartsAt и длительности.
+func (r *PostgresRepository) UpsertAuction(ctx context.Context, a *domain.Auction) (bool, error) {
+	query := `INSERT INTO tender_auctions (tender_id, starts_at, duration_seconds, ends_at, currency, start_price,
+			  	min_decrement, extension_window_seconds, extension_seconds)
+			  VALUES ($1, $2::timestamptz, $3::integer, $2::timestamptz + $3::integer * interval '1 second', $4, $5, $6, $7, $8)
+			  ON CONFLICT (tender_id) DO UPDATE
+			  SET starts_at = EXCLUDED.starts_at,
+			      duration_seconds = EXCLUDED.duration_seconds,
+			      ends_at = EXCLUDED.ends_at,
+			      currency = EXCLUDED.currency,
+			      start_price = EXCLUDED.start_price,
+			      min_decrement = EXCLUDED.min_decrement,
+			      extension_window_seconds = EXCLUDED.extension_window_seconds,
+			      extension_seconds = EXCLUDED.extension_seconds,
+			      updated_at = now()
+			  WHERE tender_auctions.starts_at > now()
+			  RETURNING ends_at, extensions, best_amount`
+	err := r.DB.QueryRowContext(ctx, query,
+		a.TenderID, a.StartsAt, a.DurationSeconds, a.Currency, a.StartPrice,
+		a.MinDecrement, a.ExtensionWindowSeconds, a.ExtensionSeconds).
+		Scan(&a.EndsAt, &a.Extensions, &a.BestAmount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to save auction: %w", err))
+	}
+	return true, nil
+}
+
+// GetAuction возвращает аукцион тендера со статусом на момент по часам базы данных.
+func (r *PostgresRepository) GetAuction(ctx context.Context, tenderID string) (*domain.Auction, error) {
+	query := `SELECT ` + auctionColumns + `, now() FROM tender_auctions WHERE tender_id = $1`
+	var a domain.Auction
+	var now time.Time
+	err := r.DB.QueryRowContext(ctx, query, tenderID).Scan(append(auctionScanDest(&a), &now)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, ErrAuctionNotFound)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to get auction: %w", err))
+	}
+	a.Status = a.StatusAt(now)
+	return &a, nil
+}
+
+// PlaceAuctionBid принимает ставку под блокировкой строки аукциона: apply проверяет
+// ставку по текущему состоянию и часам базы и может изменить EndsAt, Extensions и
+// BestAmount — изменения сохраняются вместе со ставкой в одной транзакции.
+func (r *PostgresRepository) PlaceAuctionBid(ctx context.Context, tenderID, authorID string, amount int64, apply func(a *domain.Auction, now time.Time) error) (*domain.Auction, *domain.AuctionBid, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	var a domain.Auction
+	var now time.Time
+	query := `SELECT ` + auctionColumns + `, clock_timestamp() FROM tender_auctions WHERE tender_id = $1 FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, tenderID).Scan(append(auctionScanDest(&a), &now)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, wrapError(ctx, ErrAuctionNotFound)
+	}
+	if err != nil {
+		return nil, nil, wrapError(ctx, fmt.Errorf("failed to lock auction: %w", err))
+	}
+
+	if err := apply(&a, now); err != nil {
+		return nil, nil, err
+	}
+
+	bid := &domain.AuctionBid{TenderID: tenderID, AuthorID: authorID, Amount: amount, PlacedAt: now}
+	err = tx.QueryRowContext(ctx, `INSERT INTO auction_bids (tender_id, author_id, amount, placed_at)
+								   VALUES ($1, $2, $3, $4) RETURNING id`,
+		tenderID, authorID, amount, now).Scan(&bid.ID)
+	if err != nil {
+		return nil, nil, wrapError(ctx, fmt.Errorf("failed to insert auction bid: %w", err))
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE tender_auctions
+								  SET ends_at = $2, extensions = $3, best_amount = $4, updated_at = $5
+								  WHERE tender_id = $1`,
+		tenderID, a.EndsAt, a.Extensions, a.BestAmount, now)
+	if err != nil {
+		return nil, nil, wrapError(ctx, fmt.Errorf("failed to update auction: %w", err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, wrapError(ctx, fmt.Errorf("failed to commit auction bid: %w", err))
+	}
+	a.Status = a.StatusAt(now)
+	return &a, bid, nil
+}
+
+// AuctionRanking ранжирует участников по их лучшей ставке.
+func (r *PostgresRepository) AuctionRanking(ctx context.Context, tenderID string) ([]domain.AuctionRank, error) {
+	query := `SELECT ROW_NUMBER() OVER (ORDER BY b.amount ASC, b.placed_at ASC), b.author_id, e.username, b.amount, b.placed_at
+			  FROM (
+			  	SELECT DISTINCT ON (author_id) author_id, amount, placed_at
+			  	FROM auction_bids
+			  	WHERE tender_id = $1
+			  	ORDER BY author_id, amount ASC, placed_at ASC
+			  ) b
+			  JOIN employee e ON e.id = b.author_id
+			  ORDER BY 1`
+	rows, err := r.DB.QueryContext(ctx, query, tenderID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query auction ranking: %w", err))
+	}
+	defer rows.Close()
+
+	ranking := []domain.AuctionRank{}
+	for rows.Next() {
+		var rank domain.AuctionRank
+		if err := rows.Scan(&rank.Rank, &rank.AuthorID, &rank.Username, &rank.BestAmount, &rank.PlacedAt); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan auction rank: %w", err))
+		}
+		ranking = append(ranking, rank)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate auction ranking: %w", err))
+	}
+	return ranking, nil
+}
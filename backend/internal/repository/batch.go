@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"tender_srevice/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// Пакетные выборки по множеству ключей для загрузчиков GraphQL: один запрос на
+// всех родителей вместо запроса на каждого. Ключи сравниваются как текст, чтобы
+// некорректный UUID просто ничего не находил.
+
+func (r *PostgresRepository) OrganizationsByIDs(ctx context.Context, ids []string) ([]*domain.Organization, error) {
+	query := `SELECT id, name, COALESCE(description, ''), COALESCE(type::text, '')
+			  FROM organization
+			  WHERE id::text = ANY($1)`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query organizations: %w", err))
+	}
+	defer rows.Close()
+
+	var organizations []*domain.Organization
+	for rows.Next() {
+		var o domain.Organization
+		if err := rows.Scan(&o.ID, &o.Name, &o.Description, &o.Type); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan organization: %w", err))
+		}
+		organizations = append(organizations, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate organizations: %w", err))
+	}
+	return organizations, nil
+}
+
+func (r *PostgresRepository) EmployeesByUsernames(ctx context.Context, usernames []string) ([]*domain.Employee, error) {
+	return r.queryEmployees(ctx, `SELECT `+employeeColumns+` FROM employee WHERE username = ANY($1)`, pq.Array(usernames))
+}
+
+func (r *PostgresRepository) EmployeesByIDs(ctx context.Context, ids []string) ([]*domain.Employee, error) {
+	return r.queryEmployees(ctx, `SELECT `+employeeColumns+` FROM employee WHERE id::text = ANY($1)`, pq.Array(ids))
+}
+
+// ResponsiblesByOrganizationIDs возвращает ответственных каждой организации из ids.
+func (r *PostgresRepository) ResponsiblesByOrganizationIDs(ctx context.Context, ids []string) (map[string][]*domain.Employee, error) {
+	query := `SELECT org_resp.organization_id, e.id, e.username, COALESCE(e.first_name, ''), COALESCE(e.last_name, ''), e.email
+			  FROM organization_responsible org_resp
+			  JOIN employee e ON e.id = org_resp.user_id
+			  WHERE org_resp.organization_id::text = ANY($1)
+			  ORDER BY e.username`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query responsibles: %w", err))
+	}
+	defer rows.Close()
+
+	result := map[string][]*domain.Employee{}
+	for rows.Next() {
+		var (
+			organizationID string
+			e              domain.Employee
+		)
+		if err := rows.Scan(&organizationID, &e.ID, &e.Username, &e.FirstName, &e.LastName, &e.Email); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan responsible: %w", err))
+		}
+		result[organizationID] = append(result[organizationID], &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate responsibles: %w", err))
+	}
+	return result, nil
+}
+
+// TendersByIDs не возвращает архивные тендеры, как и GetTenderByID.
+func (r *PostgresRepository) TendersByIDs(ctx context.Context, ids []string) ([]*domain.Tender, error) {
+	query := `SELECT ` + archivedTenderColumns + `
+			  FROM tenders
+			  WHERE id::text = ANY($1) AND deleted_at IS NULL`
+	return r.queryTenders(ctx, query, pq.Array(ids))
+}
+
+func (r *PostgresRepository) TendersByOrganizationIDs(ctx context.Context, ids []string) ([]*domain.Tender, error) {
+	query := `SELECT ` + archivedTenderColumns + `
+			  FROM tenders
+			  WHERE organization_id::text = ANY($1) AND deleted_at IS NULL
+			  ORDER BY name ASC`
+	return r.queryTenders(ctx, query, pq.Array(ids))
+}
+
+// BidsByTenderIDs возвращает неархивные предложения тендеров в порядке GetBidsByTenderID
+// без фильтров.
+func (r *PostgresRepository) BidsByTenderIDs(ctx context.Context, ids []string) ([]*domain.Bid, error) {
+	query := `SELECT ` + bidColumns + `
+			  FROM bid
+			  WHERE tender_id::text = ANY($1) AND deleted_at IS NULL
+			  ORDER BY created_at DESC, id DESC`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query bids: %w", err))
+	}
+	defer rows.Close()
+
+	var bids []*domain.Bid
+	for rows.Next() {
+		var b domain.Bid
+		if err := rows.Scan(bidScanDest(&b)...); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan bid: %w", err))
+		}
+		bids = append(bids, &b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate bids: %w", err))
+	}
+	return bids, nil
+}
+
+// TenderVersionsByTenderIDs возвращает сохраненные версии тендеров, от старых к новым.
+func (r *PostgresRepository) TenderVersionsByTenderIDs(ctx context.Context, ids []string) ([]*domain.TenderVersion, error) {
+	query := `SELECT tender_id, version, name, COALESCE(description, ''), service_type, status, submission_deadline, change_reason
+			  FROM tender_versions
+			  WHERE tender_id::text = ANY($1)
+			  ORDER BY tender_id, version`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query tender versions: %w", err))
+	}
+	defer rows.Close()
+
+	var versions []*domain.TenderVersion
+	for rows.Next() {
+		var v domain.TenderVersion
+		if err := rows.Scan(&v.TenderID, &v.Version, &v.Name, &v.Description, &v.ServiceType, &v.Status,
+			&v.SubmissionDeadline, &v.ChangeReason); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan tender version: %w", err))
+		}
+		versions = append(versions, &v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate tender versions: %w", err))
+	}
+	return versions, nil
+}
+
+// BidVersionsByBidIDs возвращает сохраненные версии предложений, от старых к новым.
+func (r *PostgresRepository) BidVersionsByBidIDs(ctx context.Context, ids []string) ([]*domain.BidVersion, error) {
+	query := `SELECT bid_id, version, name, description, status, amount, currency, delivery_days, valid_from, valid_until,
+			  	change_action, change_reason, changed_at, sealed_payload
+			  FROM bid_versions
+			  WHERE bid_id::text = ANY($1)
+			  ORDER BY bid_id, version`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query bid versions: %w", err))
+	}
+	defer rows.Close()
+
+	var versions []*domain.BidVersion
+	for rows.Next() {
+		var v domain.BidVersion
+		if err := rows.Scan(&v.BidID, &v.Version, &v.Name, &v.Description, &v.Status, &v.Amount, &v.Currency,
+			&v.DeliveryDays, &v.ValidFrom, &v.ValidUntil, &v.ChangeAction, &v.ChangeReason, &v.ChangedAt,
+			&v.SealedPayload); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan bid version: %w", err))
+		}
+		versions = append(versions, &v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate bid versions: %w", err))
+	}
+	return versions, nil
+}
+
+const employeeColumns = `id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), email`
+
+func (r *PostgresRepository) queryEmployees(ctx context.Context, query string, args ...interface{}) ([]*domain.Employee, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query employees: %w", err))
+	}
+	defer rows.Close()
+
+	var employees []*domain.Employee
+	for rows.Next() {
+		var e domain.Employee
+		if err := rows.Scan(&e.ID, &e.Username, &e.FirstName, &e.LastName, &e.Email); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan employee: %w", err))
+		}
+		employees = append(employees, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate employees: %w", err))
+	}
+	return employees, nil
+}
+
+func (r *PostgresRepository) queryTenders(ctx context.Context, query string, args ...interface{}) ([]*domain.Tender, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query tenders: %w", err))
+	}
+	defer rows.Close()
+
+	var tenders []*domain.Tender
+	for rows.Next() {
+		var t domain.Tender
+		if err := rows.Scan(archivedTenderScanDest(&t)...); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan tender: %w", err))
+		}
+		tenders = append(tenders, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate tenders: %w", err))
+	}
+	return tenders, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"tender_srevice/internal/domain"
+	"time"
+)
+
+// bidColumns — порядок колонок, который ожидает bidScanDest.
+const bidColumns = `id, name, description, status, tender_id, author_type, author_id, version, created_at,
+		amount, currency, delivery_days, valid_from, valid_until, sealed_payload`
+
+func bidScanDest(b *domain.Bid) []interface{} {
+	return []interface{}{
+		&b.ID, &b.Name, &b.Description, &b.Status, &b.TenderID, &b.AuthorType, &b.AuthorID, &b.Version, &b.CreatedAt,
+		&b.Amount, &b.Currency, &b.DeliveryDays, &b.ValidFrom, &b.ValidUntil, &b.SealedPayload,
+	}
+}
+
+const (
+	BidSortCreated   = "created"
+	BidSortPriceAsc  = "price_asc"
+	BidSortPriceDesc = "price_desc"
+)
+
+// BidFilter — условия отбора и порядок предложений по тендеру.
+// Предложения без цены при сортировке по цене идут последними.
+type BidFilter struct {
+	Currency  string
+	MinAmount *int64
+	MaxAmount *int64
+	Sort      string
+	// After и Limit задают страницу: не больше Limit предложений строго после
+	// After в порядке Sort. При Limit 0 возвращаются все предложения.
+	After *BidCursor
+	Limit int
+}
+
+// BidCursor — ключ сортировки последнего предложения страницы. Amount нужен
+// только при сортировке по цене.
+type BidCursor struct {
+	Amount    *int64
+	CreatedAt time.Time
+	ID        string
+}
+
+func tenderBidsQuery(tenderID string, f BidFilter) (string, []interface{}) {
+	conditions := []string{"tender_id = $1", "deleted_at IS NULL"}
+	args := []interface{}{tenderID}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+	if f.Currency != "" {
+		add("currency = $%d", f.Currency)
+	}
+	if f.MinAmount != nil {
+		add("amount >= $%d", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		add("amount <= $%d", *f.MaxAmount)
+	}
+
+	order := "created_at DESC, id DESC"
+	switch f.Sort {
+	case BidSortPriceAsc:
+		order = "amount ASC NULLS LAST, created_at DESC, id DESC"
+	case BidSortPriceDesc:
+		order = "amount DESC NULLS LAST, created_at DESC, id DESC"
+	}
+	if f.After != nil {
+		args = append(args, f.After.CreatedAt, f.After.ID)
+		older := fmt.Sprintf("(created_at, id) < ($%d::timestamptz, $%d::uuid)", len(args)-1, len(args))
+		switch {
+		case f.Sort != BidSortPriceAsc && f.Sort != BidSortPriceDesc:
+			conditions = append(conditions, older)
+		case f.After.Amount == nil:
+			// Предложения без цены идут последними, после курсора — только они
+			conditions = append(conditions, "amount IS NULL AND "+older)
+		default:
+			cmp := ">"
+			if f.Sort == BidSortPriceDesc {
+				cmp = "<"
+			}
+			args = append(args, *f.After.Amount)
+			n := len(args)
+			conditions = append(conditions, fmt.Sprintf("(amount %s $%d OR amount IS NULL OR (amount = $%d AND %s))", cmp, n, n, older))
+		}
+	}
+
+	query := `SELECT ` + bidColumns + `
+			  FROM bid
+			  WHERE ` + strings.Join(conditions, " AND ") + `
+			  ORDER BY ` + order
+	if f.Limit > 0 {
+		args = append(args, f.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	return query, args
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+)
+
+// WithdrawBid переводит предложение в Canceled от имени автора. Возвращает false,
+// если предложение уже отменено.
+func (r *PostgresRepository) WithdrawBid(ctx context.Context, bid *domain.Bid, reason string) (bool, error) {
+	query := `UPDATE bid
+			  SET status = $2, change_action = 'withdraw', change_reason = $3, changed_at = CURRENT_TIMESTAMP
+			  WHERE id = $1 AND status <> $2
+			  RETURNING status, version`
+	err := r.DB.QueryRowContext(ctx, query, bid.ID, domain.BidStatusRejected, reason).Scan(&bid.Status, &bid.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to withdraw bid: %w", err))
+	}
+	return true, nil
+}
+
+// ResubmitBid возвращает отозванному автором предложению статус, который был до отзыва.
+// Возвращает false, если последнее изменение предложения — не отзыв автором
+// (например, предложение отменила организация).
+func (r *PostgresRepository) ResubmitBid(ctx context.Context, bid *domain.Bid, reason string) (bool, error) {
+	query := `UPDATE bid b
+			  SET status = (SELECT v.status FROM bid_versions v WHERE v.bid_id = b.id ORDER BY v.version DESC LIMIT 1),
+			      change_action = 'resubmit', change_reason = $2, changed_at = CURRENT_TIMESTAMP
+			  WHERE b.id = $1 AND b.status = $3 AND b.change_action = 'withdraw'
+			  RETURNING b.status, b.version`
+	err := r.DB.QueryRowContext(ctx, query, bid.ID, reason, domain.BidStatusRejected).Scan(&bid.Status, &bid.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to resubmit bid: %w", err))
+	}
+	return true, nil
+}
+
+// BidWithdrawals возвращает историю отзывов и повторных подач предложений тендера
+// по текущим строкам и версиям предложений, от старых к новым.
+func (r *PostgresRepository) BidWithdrawals(ctx context.Context, tenderID string) ([]domain.BidWithdrawal, error) {
+	query := `SELECT h.bid_id, b.name, e.username, h.change_action, h.change_reason, h.status, h.version, h.changed_at
+			  FROM (
+			  	SELECT id AS bid_id, change_action, change_reason, status, version, changed_at
+			  	FROM bid
+			  	WHERE tender_id = $1 AND change_action IS NOT NULL
+			  	UNION ALL
+			  	SELECT bid_id, change_action, change_reason, status, version, changed_at
+			  	FROM bid_versions
+			  	WHERE tender_id = $1 AND change_action IS NOT NULL
+			  ) h
+			  JOIN bid b ON b.id = h.bid_id
+			  JOIN employee e ON e.id = b.author_id
+			  ORDER BY h.changed_at, h.version`
+	rows, err := r.DB.QueryContext(ctx, query, tenderID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query bid withdrawals: %w", err))
+	}
+	defer rows.Close()
+
+	history := []domain.BidWithdrawal{}
+	for rows.Next() {
+		var w domain.BidWithdrawal
+		if err := rows.Scan(&w.BidID, &w.BidName, &w.AuthorUsername, &w.Action, &w.Reason, &w.Status, &w.Version, &w.ChangedAt); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan bid withdrawal: %w", err))
+		}
+		history = append(history, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate bid withdrawals: %w", err))
+	}
+	return history, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"tender_srevice/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// EmailRecipient — сотрудник, которому можно отправить письмо.
+type EmailRecipient struct {
+	EmployeeID string
+	Email      string
+	Language   string
+	FirstName  string
+}
+
+// EmailRecipients отбирает из ids сотрудников с адресом, не отказавшихся от писем.
+func (r *PostgresRepository) EmailRecipients(ctx context.Context, ids []string) ([]EmailRecipient, error) {
+	query := `SELECT id, email, language, COALESCE(first_name, '')
+			  FROM employee
+			  WHERE id = ANY($1::uuid[]) AND email IS NOT NULL AND email_notifications`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query email recipients: %w", err))
+	}
+	defer rows.Close()
+
+	var recipients []EmailRecipient
+	for rows.Next() {
+		var rcpt EmailRecipient
+		if err := rows.Scan(&rcpt.EmployeeID, &rcpt.Email, &rcpt.Language, &rcpt.FirstName); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan email recipient: %w", err))
+		}
+		recipients = append(recipients, rcpt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate email recipients: %w", err))
+	}
+	return recipients, nil
+}
+
+// EnqueueEmails ставит письма в очередь одной транзакцией.
+func (r *PostgresRepository) EnqueueEmails(ctx context.Context, emails []*domain.OutboxEmail) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO email_outbox (notification_id, recipient_id, to_address, subject, html_body)
+			  VALUES ($1, $2, $3, $4, $5)
+			  RETURNING id`
+	for _, e := range emails {
+		if err := tx.QueryRowContext(ctx, query, e.NotificationID, e.RecipientID, e.To, e.Subject, e.HTMLBody).Scan(&e.ID); err != nil {
+			return wrapError(ctx, fmt.Errorf("failed to enqueue email: %w", err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to commit emails: %w", err))
+	}
+	return nil
+}
+
+// ClaimEmails выдает до limit писем, срок попытки которых наступил, увеличивает им
+// счетчик попыток и откладывает на lease. SKIP LOCKED позволяет нескольким репликам
+// разбирать очередь одновременно.
+func (r *PostgresRepository) ClaimEmails(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEmail, error) {
+	query := `UPDATE email_outbox
+			  SET attempts = attempts + 1,
+			      next_attempt_at = clock_timestamp() + $2::bigint * interval '1 millisecond'
+			  WHERE id IN (
+			      SELECT id FROM email_outbox
+			      WHERE status = 'pending' AND next_attempt_at <= clock_timestamp()
+			      ORDER BY next_attempt_at
+			      LIMIT $1
+			      FOR UPDATE SKIP LOCKED)
+			  RETURNING id, notification_id, recipient_id, to_address, subject, html_body, attempts`
+	rows, err := r.DB.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to claim emails: %w", err))
+	}
+	defer rows.Close()
+
+	var emails []*domain.OutboxEmail
+	for rows.Next() {
+		var e domain.OutboxEmail
+		if err := rows.Scan(&e.ID, &e.NotificationID, &e.RecipientID, &e.To, &e.Subject, &e.HTMLBody, &e.Attempts); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan email: %w", err))
+		}
+		emails = append(emails, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate emails: %w", err))
+	}
+	return emails, nil
+}
+
+func (r *PostgresRepository) MarkEmailSent(ctx context.Context, id string) error {
+	_, err := r.DB.ExecContext(ctx,
+		`UPDATE email_outbox SET status = 'sent', sent_at = CURRENT_TIMESTAMP, last_error = NULL WHERE id = $1`, id)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to mark email sent: %w", err))
+	}
+	return nil
+}
+
+func (r *PostgresRepository) RetryEmail(ctx context.Context, id string, next time.Time, lastErr string) error {
+	_, err := r.DB.ExecContext(ctx,
+		`UPDATE email_outbox SET next_attempt_at = $2, last_error = $3 WHERE id = $1`, id, next, lastErr)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to reschedule email: %w", err))
+	}
+	return nil
+}
+
+func (r *PostgresRepository) FailEmail(ctx context.Context, id, lastErr string) error {
+	_, err := r.DB.ExecContext(ctx,
+		`UPDATE email_outbox SET status = 'failed', last_error = $2 WHERE id = $1`, id, lastErr)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to mark email failed: %w", err))
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetEmailSettings(ctx context.Context, employeeID string) (*domain.EmailSettings, error) {
+	var s domain.EmailSettings
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT email, language, email_notifications FROM employee WHERE id = $1`, employeeID).
+		Scan(&s.Email, &s.Language, &s.Enabled)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to get email settings: %w", err))
+	}
+	return &s, nil
+}
+
+func (r *PostgresRepository) UpdateEmailSettings(ctx context.Context, employeeID string, s *domain.EmailSettings) error {
+	_, err := r.DB.ExecContext(ctx,
+		`UPDATE employee SET email = $2, language = $3, email_notifications = $4, updated_at = CURRENT_TIMESTAMP WHERE id = $1`,
+		employeeID, s.Email, s.Language, s.Enabled)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to update email settings: %w", err))
+	}
+	return nil
+}
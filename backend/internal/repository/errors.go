@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/logger"
+)
+
+var (
+	ErrTenderNotFound     = errors.New("tender not found")
+	ErrBidNotFound        = errors.New("bid not found")
+	ErrVersionNotFound    = errors.New("version not found")
+	ErrAuctionNotFound    = errors.New("auction not found")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	ErrQuestionNotFound = errors.New("question not found")
+	ErrTenderClosed     = errors.New("tender is closed")
+
+	ErrTemplateNotFound   = errors.New("tender template not found")
+	ErrTemplateTitleTaken = errors.New("organization already has a template with this title")
+
+	ErrAlreadyArchived = errors.New("already archived")
+	ErrNotArchived     = errors.New("not archived")
+
+	ErrServiceTypeNotFound       = errors.New("service type not found")
+	ErrServiceTypeParentNotFound = errors.New("parent service type not found")
+	ErrServiceTypeCycle          = errors.New("service type cannot be its own ancestor")
+	ErrServiceTypeAliasTaken     = errors.New("alias already belongs to another service type")
+	ErrServiceTypeInUse          = errors.New("service type is used by subtypes or tenders")
+
+	ErrEmployeeNotFound        = errors.New("employee not found")
+	ErrUsernameTaken           = errors.New("username is already taken")
+	ErrOrganizationNotFound    = errors.New("organization not found")
+	ErrInvalidOrganizationType = errors.New("organization type must be IE, LLC or JSC")
+)
+
+// Error — ошибка репозитория с идентификатором запроса, в рамках которого она возникла.
+type Error struct {
+	RequestID string
+	Err       error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v (request_id=%s)", e.Err, e.RequestID)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// wrapError привязывает ошибку к request ID из контекста, если он есть.
+func wrapError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	id := logger.RequestID(ctx)
+	if id == "" {
+		return err
+	}
+	var repoErr *Error
+	if errors.As(err, &repoErr) {
+		return err
+	}
+	return &Error{RequestID: id, Err: err}
+}
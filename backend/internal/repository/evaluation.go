@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+)
+
+func (r *PostgresRepository) ListCriteria(ctx context.Context, tenderID string) ([]domain.Criterion, error) {
+	query := `SELECT id, tender_id, name, description, weight, scale_min, scale_max
+			  FROM tender_criteria
+			  WHERE tender_id = $1
+			  ORDER BY position`
+	rows, err := r.DB.QueryContext(ctx, query, tenderID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query criteria: %w", err))
+	}
+	defer rows.Close()
+
+	criteria := []domain.Criterion{}
+	for rows.Next() {
+		var c domain.Criterion
+		if err := rows.Scan(&c.ID, &c.TenderID, &c.Name, &c.Description, &c.Weight, &c.ScaleMin, &c.ScaleMax); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan criterion: %w", err))
+		}
+		criteria = append(criteria, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate criteria: %w", err))
+	}
+	return criteria, nil
+}
+
+// ReplaceCriteria заменяет критерии тендера и заполняет их ID. Если предложения уже
+// оценивались, критерии не меняются и возвращается false. Строка тендера блокируется,
+// чтобы замена не разошлась с одновременно сохраняемыми оценками (см. SaveBidScores).
+func (r *PostgresRepository) ReplaceCriteria(ctx context.Context, tenderID string, criteria []domain.Criterion) (bool, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	if err := lockTender(ctx, tx, tenderID, "FOR UPDATE"); err != nil {
+		return false, err
+	}
+	var scored bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (
+									  SELECT 1 FROM bid_scores s
+									  JOIN tender_criteria c ON c.id = s.criterion_id
+									  WHERE c.tender_id = $1)`, tenderID).Scan(&scored)
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to check bid scores: %w", err))
+	}
+	if scored {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tender_criteria WHERE tender_id = $1`, tenderID); err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to delete criteria: %w", err))
+	}
+	query := `INSERT INTO tender_criteria (tender_id, position, name, description, weight, scale_min, scale_max)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  RETURNING id`
+	for i := range criteria {
+		c := &criteria[i]
+		c.TenderID = tenderID
+		err := tx.QueryRowContext(ctx, query, tenderID, i, c.Name, c.Description, c.Weight, c.ScaleMin, c.ScaleMax).Scan(&c.ID)
+		if err != nil {
+			return false, wrapError(ctx, fmt.Errorf("failed to insert criterion: %w", err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to commit criteria: %w", err))
+	}
+	return true, nil
+}
+
+// SaveBidScores создает или заменяет оценки. Блокировка тендера FOR SHARE не дает
+// заменить критерии, пока оценки сохраняются.
+func (r *PostgresRepository) SaveBidScores(ctx context.Context, tenderID string, scores []domain.BidScore) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	if err := lockTender(ctx, tx, tenderID, "FOR SHARE"); err != nil {
+		return err
+	}
+	query := `INSERT INTO bid_scores (bid_id, criterion_id, evaluator_id, score, comment)
+			  VALUES ($1, $2, $3, $4, $5)
+			  ON CONFLICT (bid_id, criterion_id, evaluator_id) DO UPDATE
+			  SET score = EXCLUDED.score, comment = EXCLUDED.comment, updated_at = CURRENT_TIMESTAMP
+			  RETURNING updated_at`
+	for i := range scores {
+		s := &scores[i]
+		if err := tx.QueryRowContext(ctx, query, s.BidID, s.CriterionID, s.EvaluatorID, s.Score, s.Comment).Scan(&s.UpdatedAt); err != nil {
+			return wrapError(ctx, fmt.Errorf("failed to save bid score: %w", err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to commit bid scores: %w", err))
+	}
+	return nil
+}
+
+// TenderBidScores возвращает все оценки предложений тендера; пустой bidID — по всем предложениям.
+func (r *PostgresRepository) TenderBidScores(ctx context.Context, tenderID, bidID string) ([]domain.BidScore, error) {
+	query := `SELECT s.bid_id, s.criterion_id, s.evaluator_id, e.username, s.score, s.comment, s.updated_at
+			  FROM bid_scores s
+			  JOIN tender_criteria c ON c.id = s.criterion_id
+			  JOIN employee e ON e.id = s.evaluator_id
+			  WHERE c.tender_id = $1 AND ($2 = '' OR s.bid_id::text = $2)
+			  ORDER BY e.username, c.position`
+	rows, err := r.DB.QueryContext(ctx, query, tenderID, bidID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query bid scores: %w", err))
+	}
+	defer rows.Close()
+
+	scores := []domain.BidScore{}
+	for rows.Next() {
+		var s domain.BidScore
+		if err := rows.Scan(&s.BidID, &s.CriterionID, &s.EvaluatorID, &s.Evaluator, &s.Score, &s.Comment, &s.UpdatedAt); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan bid score: %w", err))
+		}
+		scores = append(scores, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate bid scores: %w", err))
+	}
+	return scores, nil
+}
+
+func lockTender(ctx context.Context, tx *sql.Tx, tenderID, mode string) error {
+	var id string
+	err := tx.QueryRowContext(ctx, `SELECT id FROM tenders WHERE id = $1 `+mode, tenderID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return wrapError(ctx, ErrTenderNotFound)
+	}
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to lock tender: %w", err))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"tender_srevice/internal/domain"
+)
+
+// StreamTenders обходит тендеры курсором и передает их в fn по одному,
+// не накапливая результат в памяти. Непустой serviceType оставляет тендеры
+// этого вида услуг и его подтипов, как GetAllTenders. Ошибка из fn прерывает обход.
+func (r *PostgresRepository) StreamTenders(ctx context.Context, serviceType string, fn func(*domain.Tender) error) error {
+	query := serviceTypeSubtypes + `
+			  SELECT id, name, description, status, service_type, organization_id, creator_username, version, submission_deadline, sealed
+			  FROM tenders
+			  WHERE deleted_at IS NULL AND ($1 = '' OR service_type IN (SELECT code FROM subtypes))
+			  ORDER BY name ASC, id ASC`
+	rows, err := r.DB.QueryContext(ctx, query, serviceType)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to query tenders: %w", err))
+	}
+	defer rows.Close()
+
+	var t domain.Tender
+	for rows.Next() {
+		var description *string
+		if err := rows.Scan(&t.ID, &t.Name, &description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername, &t.Version, &t.SubmissionDeadline, &t.Sealed); err != nil {
+			return wrapError(ctx, fmt.Errorf("failed to scan tender: %w", err))
+		}
+		t.Description = ""
+		if description != nil {
+			t.Description = *description
+		}
+		if err := fn(&t); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to iterate tenders: %w", err))
+	}
+	return nil
+}
+
+// StreamBidsByTenderID обходит предложения по тендеру в том же порядке, что и GetBidsByTenderID.
+func (r *PostgresRepository) StreamBidsByTenderID(ctx context.Context, tenderID string, filter BidFilter, fn func(*domain.Bid) error) error {
+	query, args := tenderBidsQuery(tenderID, filter)
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to query bids: %w", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		// Новая структура на каждую строку: nullable-поля сканируются в указатели
+		var b domain.Bid
+		if err := rows.Scan(bidScanDest(&b)...); err != nil {
+			return wrapError(ctx, fmt.Errorf("failed to scan bid: %w", err))
+		}
+		if err := fn(&b); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to iterate bids: %w", err))
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"tender_srevice/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// CreateNotifications сохраняет уведомление для каждого из recipientIDs, кроме сотрудника
+// actorUsername (автора события) и тех, кто отключил этот тип. Возвращает ID созданных
+// уведомлений по ID получателей.
+func (r *PostgresRepository) CreateNotifications(ctx context.Context, n *domain.Notification, recipientIDs []string, actorUsername string) (map[string]string, error) {
+	data, err := json.Marshal(n.Data)
+	if err != nil {
+		return nil, err
+	}
+	query := `INSERT INTO notifications (recipient_id, type, tender_id, bid_id, data)
+			  SELECT e.id, $2, $3::uuid, $4::uuid, $5::jsonb
+			  FROM employee e
+			  WHERE e.id = ANY($1::uuid[]) AND e.username <> $6
+			    AND NOT EXISTS (
+			        SELECT 1 FROM notification_preferences p
+			        WHERE p.employee_id = e.id AND p.type = $2 AND NOT p.enabled)
+			  RETURNING recipient_id, id`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(recipientIDs), n.Type, n.TenderID, n.BidID, data, actorUsername)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to insert notifications: %w", err))
+	}
+	defer rows.Close()
+
+	created := map[string]string{}
+	for rows.Next() {
+		var recipientID, id string
+		if err := rows.Scan(&recipientID, &id); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan notification: %w", err))
+		}
+		created[recipientID] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate notifications: %w", err))
+	}
+	return created, nil
+}
+
+// TenderResponsibleIDs возвращает сотрудников, ответственных за организацию тендера.
+func (r *PostgresRepository) TenderResponsibleIDs(ctx context.Context, tenderID string) ([]string, error) {
+	query := `SELECT org_resp.user_id
+			  FROM tenders t
+			  JOIN organization_responsible org_resp ON org_resp.organization_id = t.organization_id
+			  WHERE t.id = $1`
+	rows, err := r.DB.QueryContext(ctx, query, tenderID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query tender responsibles: %w", err))
+	}
+	return scanIDs(ctx, rows)
+}
+
+// TenderBidderIDs возвращает авторов предложений к тендеру.
+func (r *PostgresRepository) TenderBidderIDs(ctx context.Context, tenderID string) ([]string, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT DISTINCT author_id FROM bid WHERE tender_id = $1 AND deleted_at IS NULL`, tenderID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query tender bidders: %w", err))
+	}
+	return scanIDs(ctx, rows)
+}
+
+func scanIDs(ctx context.Context, rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan id: %w", err))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate ids: %w", err))
+	}
+	return ids, nil
+}
+
+// ListNotifications возвращает уведомления сотрудника от новых к старым.
+func (r *PostgresRepository) ListNotifications(ctx context.Context, employeeID string, unreadOnly bool, limit, offset int) ([]*domain.Notification, error) {
+	query := `SELECT id, type, tender_id, bid_id, data, created_at, read_at
+			  FROM notifications
+			  WHERE recipient_id = $1 AND (NOT $2 OR read_at IS NULL)
+			  ORDER BY created_at DESC, id DESC
+			  LIMIT $3 OFFSET $4`
+	rows, err := r.DB.QueryContext(ctx, query, employeeID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query notifications: %w", err))
+	}
+	defer rows.Close()
+
+	notifications := []*domain.Notification{}
+	for rows.Next() {
+		var (
+			n    domain.Notification
+			data []byte
+		)
+		if err := rows.Scan(&n.ID, &n.Type, &n.TenderID, &n.BidID, &data, &n.CreatedAt, &n.ReadAt); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan notification: %w", err))
+		}
+		if err := json.Unmarshal(data, &n.Data); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to decode notification %s: %w", n.ID, err))
+		}
+		notifications = append(notifications, &n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate notifications: %w", err))
+	}
+	return notifications, nil
+}
+
+// UnreadNotificationCounts возвращает число непрочитанных уведомлений по типам.
+func (r *PostgresRepository) UnreadNotificationCounts(ctx context.Context, employeeID string) (map[string]int, error) {
+	query := `SELECT type, count(*) FROM notifications
+			  WHERE recipient_id = $1 AND read_at IS NULL
+			  GROUP BY type`
+	rows, err := r.DB.QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to count notifications: %w", err))
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var (
+			typ   string
+			count int
+		)
+		if err := rows.Scan(&typ, &count); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan notification count: %w", err))
+		}
+		counts[typ] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate notification counts: %w", err))
+	}
+	return counts, nil
+}
+
+// MarkNotificationRead отмечает прочитанным уведомление сотрудника; повторная отметка не меняет время.
+func (r *PostgresRepository) MarkNotificationRead(ctx context.Context, employeeID, notificationID string) error {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+			  WHERE id = $1 AND recipient_id = $2`
+	res, err := r.DB.ExecContext(ctx, query, notificationID, employeeID)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to mark notification read: %w", err))
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return wrapError(ctx, ErrNotificationNotFound)
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead отмечает прочитанными все непрочитанные уведомления сотрудника,
+// при непустом typ — только этого типа. Возвращает число отмеченных.
+func (r *PostgresRepository) MarkAllNotificationsRead(ctx context.Context, employeeID, typ string) (int64, error) {
+	query := `UPDATE notifications SET read_at = CURRENT_TIMESTAMP
+			  WHERE recipient_id = $1 AND read_at IS NULL AND ($2 = '' OR type = $2)`
+	res, err := r.DB.ExecContext(ctx, query, employeeID, typ)
+	if err != nil {
+		return 0, wrapError(ctx, fmt.Errorf("failed to mark notifications read: %w", err))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, wrapError(ctx, fmt.Errorf("failed to mark notifications read: %w", err))
+	}
+	return n, nil
+}
+
+// NotificationPreferences возвращает явно заданные настройки сотрудника по типам.
+func (r *PostgresRepository) NotificationPreferences(ctx context.Context, employeeID string) (map[string]bool, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT type, enabled FROM notification_preferences WHERE employee_id = $1`, employeeID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query notification preferences: %w", err))
+	}
+	defer rows.Close()
+
+	prefs := map[string]bool{}
+	for rows.Next() {
+		var (
+			typ     string
+			enabled bool
+		)
+		if err := rows.Scan(&typ, &enabled); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan notification preference: %w", err))
+		}
+		prefs[typ] = enabled
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate notification preferences: %w", err))
+	}
+	return prefs, nil
+}
+
+// SetNotificationPreferences сохраняет настройки для перечисленных типов, остальные не меняет.
+func (r *PostgresRepository) SetNotificationPreferences(ctx context.Context, employeeID string, prefs []domain.NotificationPreference) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO notification_preferences (employee_id, type, enabled)
+			  VALUES ($1, $2, $3)
+			  ON CONFLICT (employee_id, type) DO UPDATE
+			  SET enabled = EXCLUDED.enabled, updated_at = CURRENT_TIMESTAMP`
+	for _, p := range prefs {
+		if _, err := tx.ExecContext(ctx, query, employeeID, p.Type, p.Enabled); err != nil {
+			return wrapError(ctx, fmt.Errorf("failed to save notification preference: %w", err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to commit notification preferences: %w", err))
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// Операции tenderctl: справочники, назначение ответственных и правки тендеров и
+// предложений в обход проверок прав REST API.
+
+func (r *PostgresRepository) InsertEmployee(ctx context.Context, e *domain.Employee) error {
+	query := `INSERT INTO employee (username, first_name, last_name, email)
+			  VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4)
+			  RETURNING id, created_at, updated_at`
+	err := r.DB.QueryRowContext(ctx, query, e.Username, e.FirstName, e.LastName, e.Email).
+		Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return wrapError(ctx, ErrUsernameTaken)
+		}
+		return wrapError(ctx, fmt.Errorf("failed to insert employee: %w", err))
+	}
+	return nil
+}
+
+func (r *PostgresRepository) InsertOrganization(ctx context.Context, o *domain.Organization) error {
+	query := `INSERT INTO organization (name, description, type)
+			  VALUES ($1, NULLIF($2, ''), NULLIF($3, '')::organization_type)
+			  RETURNING id`
+	err := r.DB.QueryRowContext(ctx, query, o.Name, o.Description, o.Type).Scan(&o.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		// 22P02 — значение вне перечисления organization_type
+		if errors.As(err, &pqErr) && pqErr.Code == "22P02" {
+			return wrapError(ctx, ErrInvalidOrganizationType)
+		}
+		return wrapError(ctx, fmt.Errorf("failed to insert organization: %w", err))
+	}
+	return nil
+}
+
+// AddResponsible назначает сотрудника ответственным за организацию. Повторное
+// назначение ничего не меняет и возвращает added = false.
+func (r *PostgresRepository) AddResponsible(ctx context.Context, organizationID, username string) (added bool, err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	var userID string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM employee WHERE username = $1`, username).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, wrapError(ctx, ErrEmployeeNotFound)
+	}
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to get employee: %w", err))
+	}
+
+	// Блокировка строки организации не дает двум параллельным вызовам добавить дубль
+	err = tx.QueryRowContext(ctx, `SELECT id FROM organization WHERE id::text = $1 FOR UPDATE`, organizationID).Scan(&organizationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, wrapError(ctx, ErrOrganizationNotFound)
+	}
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to get organization: %w", err))
+	}
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO organization_responsible (organization_id, user_id)
+									  SELECT $1, $2
+									  WHERE NOT EXISTS (SELECT 1 FROM organization_responsible
+									                    WHERE organization_id = $1 AND user_id = $2)`, organizationID, userID)
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to add responsible: %w", err))
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to add responsible: %w", err))
+	}
+	if err := tx.Commit(); err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to commit responsible: %w", err))
+	}
+	return n > 0, nil
+}
+
+// TenderListFilter — отбор тендеров для оператора; пустые поля не ограничивают выборку.
+type TenderListFilter struct {
+	OrganizationID  string
+	Status          string
+	IncludeArchived bool
+}
+
+func (r *PostgresRepository) ListTenders(ctx context.Context, filter TenderListFilter) ([]*domain.Tender, error) {
+	query := `SELECT ` + archivedTenderColumns + `
+			  FROM tenders
+			  WHERE ($1 = '' OR organization_id::text = $1)
+			    AND ($2 = '' OR status = $2)
+			    AND ($3 OR deleted_at IS NULL)
+			  ORDER BY created_at DESC, id DESC`
+	return r.queryTenders(ctx, query, filter.OrganizationID, filter.Status, filter.IncludeArchived)
+}
+
+// ForceTenderStatus меняет статус тендера без проверок прав; reason попадает в
+// историю версий. Закрытый запечатанный тендер, как и в RollbackTender, не открывается.
+func (r *PostgresRepository) ForceTenderStatus(ctx context.Context, tenderID, status, reason string) (*domain.Tender, error) {
+	query := `UPDATE tenders
+			  SET status = $2, change_reason = $3
+			  WHERE id = $1 AND deleted_at IS NULL
+			    AND NOT (sealed AND status = 'CLOSED' AND $2 <> 'CLOSED')
+			  RETURNING ` + archivedTenderColumns
+	var t domain.Tender
+	err := r.DB.QueryRowContext(ctx, query, tenderID, status, reason).Scan(archivedTenderScanDest(&t)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := r.GetTenderByID(ctx, tenderID); err != nil {
+			return nil, err
+		}
+		return nil, wrapError(ctx, ErrTenderClosed)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to force tender status: %w", err))
+	}
+	return &t, nil
+}
+
+// RollbackBid возвращает предложению название, описание, статус и условия версии
+// version. Откат сам становится новой версией с причиной «rollback to version N».
+func (r *PostgresRepository) RollbackBid(ctx context.Context, bidID string, version int) (*domain.Bid, error) {
+	query := `
+		WITH previous_version AS (
+			SELECT name, description, status, amount, currency, delivery_days, valid_from, valid_until, sealed_payload, version
+			FROM bid_versions
+			WHERE bid_id = $1 AND version = $2
+		)
+		UPDATE bid b
+		SET name = pv.name,
+			description = pv.description,
+			status = pv.status,
+			amount = pv.amount,
+			currency = pv.currency,
+			delivery_days = pv.delivery_days,
+			valid_from = pv.valid_from,
+			valid_until = pv.valid_until,
+			sealed_payload = pv.sealed_payload,
+			change_action = NULL,
+			change_reason = 'rollback to version ' || pv.version,
+			changed_at = now()
+		FROM previous_version pv
+		WHERE b.id = $1 AND b.deleted_at IS NULL
+		RETURNING b.id, b.name, b.description, b.status, b.tender_id, b.author_type, b.author_id, b.version, b.created_at,
+			b.amount, b.currency, b.delivery_days, b.valid_from, b.valid_until, b.sealed_payload
+	`
+
+	var b domain.Bid
+	err := r.DB.QueryRowContext(ctx, query, bidID, version).Scan(bidScanDest(&b)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := r.GetBidByID(ctx, bidID); err != nil {
+			return nil, err
+		}
+		return nil, wrapError(ctx, ErrVersionNotFound)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to rollback bid: %w", err))
+	}
+	return &b, nil
+}
@@ -3,8 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
 	"tender_srevice/internal/domain"
 )
 
@@ -28,16 +28,16 @@ func (r *PostgresRepository) GetUserIDByUsername(ctx context.Context, username s
 	var userID string
 	err := r.DB.QueryRowContext(ctx, query, username).Scan(&userID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get user ID: %w", err)
+		return "", wrapError(ctx, fmt.Errorf("failed to get user ID: %w", err))
 	}
 	return userID, nil
 }
 
 
 func (r *PostgresRepository) InsertTender(ctx context.Context, item *domain.Tender) error {
-	query := `INSERT INTO tenders (name, description, status, service_type, organization_id, creator_username) 
-              VALUES ($1, $2, $3, $4, $5, $6)
-              RETURNING id`
+	query := `INSERT INTO tenders (name, description, status, service_type, organization_id, creator_username, submission_deadline, sealed) 
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+              RETURNING id, version`
    
 
 	err := r.DB.QueryRowContext(ctx, query, 
@@ -46,28 +46,40 @@ func (r *PostgresRepository) InsertTender(ctx context.Context, item *domain.Tend
 		item.Status, 
 		item.ServiceType, 
 		item.OrganizationID, 
-		item.CreatorUsername).Scan(&item.ID)
+		item.CreatorUsername,
+		item.SubmissionDeadline,
+		item.Sealed).Scan(&item.ID, &item.Version)
 	if err != nil {
-		log.Printf("Error inserting tender: %v", err)
-		return fmt.Errorf("failed to insert tender: %w", err)
+		return wrapError(ctx, fmt.Errorf("failed to insert tender: %w", err))
 	}
 	return nil
 }
 
 // Добавить сортировку по алфавиту
-func (r *PostgresRepository) GetAllTenders(ctx context.Context) ([]*domain.Tender, error) {
-	query := `SELECT id, name, description, status, service_type, organization_id, creator_username FROM tenders`
-	rows, err := r.DB.QueryContext(ctx, query)
+// GetAllTenders при непустом serviceType возвращает тендеры этого типа и всех его подтипов.
+// serviceTypeSubtypes — код вида услуг из $1 и все его подтипы. Запросы фильтруют
+// по нему условием ($1 = '' OR service_type IN (SELECT code FROM subtypes)).
+const serviceTypeSubtypes = `WITH RECURSIVE subtypes AS (
+				SELECT code FROM service_types WHERE code = $1
+				UNION
+				SELECT st.code FROM service_types st JOIN subtypes s ON st.parent_code = s.code
+			  )`
+
+func (r *PostgresRepository) GetAllTenders(ctx context.Context, serviceType string) ([]*domain.Tender, error) {
+	query := serviceTypeSubtypes + `
+			  SELECT id, name, description, status, service_type, organization_id, creator_username, version, submission_deadline, sealed FROM tenders
+			  WHERE deleted_at IS NULL AND ($1 = '' OR service_type IN (SELECT code FROM subtypes))`
+	rows, err := r.DB.QueryContext(ctx, query, serviceType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query tenders: %w", err)
+		return nil, wrapError(ctx, fmt.Errorf("failed to query tenders: %w", err))
 	}
 	defer rows.Close()
 
 	var tenders []*domain.Tender
 	for rows.Next() {
 		var t domain.Tender
-		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername); err != nil {
-			return nil, fmt.Errorf("failed to scan tender: %w", err)
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername, &t.Version, &t.SubmissionDeadline, &t.Sealed); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan tender: %w", err))
 		}
 		tenders = append(tenders, &t)
 	}
@@ -75,17 +87,17 @@ func (r *PostgresRepository) GetAllTenders(ctx context.Context) ([]*domain.Tende
 }
 
 func (r *PostgresRepository) GetTenderByID(ctx context.Context, tenderID string) (*domain.Tender, error) {
-	query := `SELECT id, name, description, status, service_type, organization_id, creator_username
+	query := `SELECT id, name, description, status, service_type, organization_id, creator_username, version, submission_deadline, sealed
               FROM tenders 
-              WHERE id = $1`  
+              WHERE id = $1 AND deleted_at IS NULL`  
 	var t domain.Tender
 	err := r.DB.QueryRowContext(ctx, query, tenderID).Scan(
-		&t.ID, &t.Name, &t.Description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername)
+		&t.ID, &t.Name, &t.Description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername, &t.Version, &t.SubmissionDeadline, &t.Sealed)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("tender not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wrapError(ctx, ErrTenderNotFound)
 		}
-		return nil, fmt.Errorf("failed to get tender: %w", err)
+		return nil, wrapError(ctx, fmt.Errorf("failed to get tender: %w", err))
 	}
 	return &t, nil
 }
@@ -93,11 +105,15 @@ func (r *PostgresRepository) GetTenderByID(ctx context.Context, tenderID string)
 func (r *PostgresRepository) UpdateTenderStatus(ctx context.Context, tender *domain.Tender) error {
 	query := `UPDATE tenders 
               SET status = $2
-              WHERE id = $1`  
-	_, err := r.DB.ExecContext(ctx, query, tender.ID, tender.Status)
+              WHERE id = $1
+              RETURNING version`  
+	err := r.DB.QueryRowContext(ctx, query, tender.ID, tender.Status).Scan(&tender.Version)
     
+	if errors.Is(err, sql.ErrNoRows) {
+		return wrapError(ctx, ErrTenderNotFound)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to update tender status: %w", err)
+		return wrapError(ctx, fmt.Errorf("failed to update tender status: %w", err))
 	}
     
 	return nil
@@ -105,33 +121,39 @@ func (r *PostgresRepository) UpdateTenderStatus(ctx context.Context, tender *dom
 
 func (r *PostgresRepository) UpdateTender(ctx context.Context, tender *domain.Tender) error {
 	query := `UPDATE tenders 
-			  SET name = $2, description = $3, status = $4, service_type = $5, organization_id = $6, creator_username = $7
-			  WHERE id = $1`
-	_, err := r.DB.ExecContext(ctx, query,
+			  SET name = $2, description = $3, status = $4, service_type = $5, organization_id = $6, creator_username = $7,
+			      submission_deadline = $8
+			  WHERE id = $1
+			  RETURNING version`
+	err := r.DB.QueryRowContext(ctx, query,
 		tender.ID, tender.Name, tender.Description, tender.Status,
-		tender.ServiceType, tender.OrganizationID, tender.CreatorUsername)
+		tender.ServiceType, tender.OrganizationID, tender.CreatorUsername,
+		tender.SubmissionDeadline).Scan(&tender.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return wrapError(ctx, ErrTenderNotFound)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to update tender: %w", err)
+		return wrapError(ctx, fmt.Errorf("failed to update tender: %w", err))
 	}
 	return nil
 }
 
 func (r *PostgresRepository) GetTendersByUsername(ctx context.Context, username string) ([]*domain.Tender, error) {
-	query := `SELECT id, name, description, status, service_type, organization_id, creator_username 
+	query := `SELECT id, name, description, status, service_type, organization_id, creator_username, version, submission_deadline, sealed 
               FROM tenders 
-              WHERE creator_username = $1
+              WHERE creator_username = $1 AND deleted_at IS NULL
               ORDER BY name ASC`
 	rows, err := r.DB.QueryContext(ctx, query, username)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query tenders: %w", err)
+		return nil, wrapError(ctx, fmt.Errorf("failed to query tenders: %w", err))
 	}
 	defer rows.Close()
 
 	var tenders []*domain.Tender
 	for rows.Next() {
 		var t domain.Tender
-		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername); err != nil {
-			return nil, fmt.Errorf("failed to scan tender: %w", err)
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername, &t.Version, &t.SubmissionDeadline, &t.Sealed); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan tender: %w", err))
 		}
 		tenders = append(tenders, &t)
 	}
@@ -143,10 +165,10 @@ func (r *PostgresRepository) GetTenderStatus(ctx context.Context, tenderID strin
 	var status string
 	err := r.DB.QueryRowContext(ctx, query, tenderID).Scan(&status)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", fmt.Errorf("tender not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", wrapError(ctx, ErrTenderNotFound)
 		}
-		return "", fmt.Errorf("failed to get tender status: %w", err)
+		return "", wrapError(ctx, fmt.Errorf("failed to get tender status: %w", err))
 	}
 	return status, nil
 }
@@ -157,7 +179,7 @@ func (r *PostgresRepository) IsUserResponsibleForOrganization(ctx context.Contex
 	var userID string
 	userID, err := r.GetUserIDByUsername(ctx, username)
 	if err != nil {
-		return false, fmt.Errorf("ошибка при получении ID пользователя: %w", err)
+		return false, wrapError(ctx, fmt.Errorf("failed to get user ID: %w", err))
 	}
 
 	// Теперь проверяем, является ли пользователь ответственным за организацию
@@ -166,7 +188,7 @@ func (r *PostgresRepository) IsUserResponsibleForOrganization(ctx context.Contex
 	var exists bool
 	err = r.DB.QueryRowContext(ctx, query, userID, organizationID).Scan(&exists)
 	if err != nil {
-		return false, fmt.Errorf("ошибка при проверке ответственности пользователя: %w", err)
+		return false, wrapError(ctx, fmt.Errorf("failed to check user responsibility: %w", err))
 	}
 	return exists, nil
 }
@@ -176,73 +198,89 @@ func (r *PostgresRepository) GetOrganizationIDByTenderID(ctx context.Context, te
 	var organizationID string
 	err := r.DB.QueryRowContext(ctx, query, tenderID).Scan(&organizationID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get organization ID: %w", err)
+		return "", wrapError(ctx, fmt.Errorf("failed to get organization ID: %w", err))
 	}
 	return organizationID, nil
 }
 
+// GetTenderVersionStatus возвращает статус тендера в сохраненной версии version.
+func (r *PostgresRepository) GetTenderVersionStatus(ctx context.Context, tenderID string, version int) (string, error) {
+	var status string
+	err := r.DB.QueryRowContext(ctx, `SELECT status FROM tender_versions WHERE tender_id = $1 AND version = $2`,
+		tenderID, version).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", wrapError(ctx, ErrVersionNotFound)
+		}
+		return "", wrapError(ctx, fmt.Errorf("failed to get tender version: %w", err))
+	}
+	return status, nil
+}
+
 func (r *PostgresRepository) RollbackTender(ctx context.Context, tenderID string, version int) (*domain.Tender, error) {
 	query := `
 		WITH previous_version AS (
-			SELECT name, description, status, service_type, version
+			SELECT name, description, status, service_type, submission_deadline, version
 			FROM tender_versions
 			WHERE tender_id = $1 AND version = $2
 		)
 		UPDATE tenders t
 		SET name = pv.name,
 			description = pv.description,
-			status = pv.status,
+			-- закрытый запечатанный тендер не открывается повторно: его предложения уже вскрыты
+			status = CASE WHEN t.sealed AND t.status = 'CLOSED' THEN t.status ELSE pv.status END,
 			service_type = pv.service_type,
+			submission_deadline = pv.submission_deadline,
+			change_reason = 'rollback to version ' || pv.version,
 			version = pv.version
 		FROM previous_version pv
 		WHERE t.id = $1
-		RETURNING t.id, t.name, t.description, t.status, t.service_type, t.organization_id, t.creator_username, t.version
+		RETURNING t.id, t.name, t.description, t.status, t.service_type, t.organization_id, t.creator_username, t.version, t.submission_deadline, t.sealed
 	`
 
 	var t domain.Tender
 	err := r.DB.QueryRowContext(ctx, query, tenderID, version).Scan(
-		&t.ID, &t.Name, &t.Description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername, &t.Version)
+		&t.ID, &t.Name, &t.Description, &t.Status, &t.ServiceType, &t.OrganizationID, &t.CreatorUsername, &t.Version, &t.SubmissionDeadline, &t.Sealed)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("version not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wrapError(ctx, ErrVersionNotFound)
 		}
-		return nil, fmt.Errorf("failed to rollback tender: %w", err)
+		return nil, wrapError(ctx, fmt.Errorf("failed to rollback tender: %w", err))
 	}
 
 	return &t, nil
 }
 
 func (r *PostgresRepository) InsertBid(ctx context.Context, bid *domain.Bid) error {
-	query := `INSERT INTO bid (name, description, status, tender_id, author_type, author_id)
-			  VALUES ($1, $2, $3, $4, $5, $6)
+	query := `INSERT INTO bid (name, description, status, tender_id, author_type, author_id,
+			  	amount, currency, delivery_days, valid_from, valid_until, sealed_payload)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 			  RETURNING id, version, created_at`
 
 	err := r.DB.QueryRowContext(ctx, query,
-		bid.Name, bid.Description, bid.Status, bid.TenderID, bid.AuthorType, bid.AuthorID).
+		bid.Name, bid.Description, bid.Status, bid.TenderID, bid.AuthorType, bid.AuthorID,
+		bid.Amount, bid.Currency, bid.DeliveryDays, bid.ValidFrom, bid.ValidUntil, bid.SealedPayload).
 		Scan(&bid.ID, &bid.Version, &bid.CreatedAt)
 	if err != nil {
-		return fmt.Errorf("failed to insert bid: %w", err)
+		return wrapError(ctx, fmt.Errorf("failed to insert bid: %w", err))
 	}
 	return nil
 }
 
-func (r *PostgresRepository) GetBidsByTenderID(ctx context.Context, tenderID string) ([]*domain.Bid, error) {
-	query := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
-			  FROM bid
-			  WHERE tender_id = $1
-			  ORDER BY created_at DESC`
+func (r *PostgresRepository) GetBidsByTenderID(ctx context.Context, tenderID string, filter BidFilter) ([]*domain.Bid, error) {
+	query, args := tenderBidsQuery(tenderID, filter)
 
-	rows, err := r.DB.QueryContext(ctx, query, tenderID)
+	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query bids: %w", err)
+		return nil, wrapError(ctx, fmt.Errorf("failed to query bids: %w", err))
 	}
 	defer rows.Close()
 
 	var bids []*domain.Bid
 	for rows.Next() {
 		var b domain.Bid
-		if err := rows.Scan(&b.ID, &b.Name, &b.Description, &b.Status, &b.TenderID, &b.AuthorType, &b.AuthorID, &b.Version, &b.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan bid: %w", err)
+		if err := rows.Scan(bidScanDest(&b)...); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan bid: %w", err))
 		}
 		bids = append(bids, &b)
 	}
@@ -263,7 +301,7 @@ func (r *PostgresRepository) IsUserInTenderOrganization(ctx context.Context, use
 	var exists bool
 	err := r.DB.QueryRowContext(ctx, query, tenderID, username).Scan(&exists)
 	if err != nil {
-		return false, fmt.Errorf("failed to check user organization: %w", err)
+		return false, wrapError(ctx, fmt.Errorf("failed to check user organization: %w", err))
 	}
 	return exists, nil
 }
@@ -272,28 +310,40 @@ func (r *PostgresRepository) UpdateBidStatus(ctx context.Context, bidID, newStat
 	query := `UPDATE bid SET status = $1 WHERE id = $2`
 	_, err := r.DB.ExecContext(ctx, query, newStatus, bidID)
 	if err != nil {
-		return fmt.Errorf("failed to update bid status: %w", err)
+		return wrapError(ctx, fmt.Errorf("failed to update bid status: %w", err))
 	}
 	return nil
 }
 
-func (r *PostgresRepository) GetBidsByAuthorID(ctx context.Context, authorID string) ([]*domain.Bid, error) {
-	query := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
+// GetBidsByAuthorID возвращает до limit предложений автора после курсора after,
+// от новых к старым; при limit 0 — все.
+func (r *PostgresRepository) GetBidsByAuthorID(ctx context.Context, authorID string, after *BidCursor, limit int) ([]*domain.Bid, error) {
+	query := `SELECT ` + bidColumns + `
 			  FROM bid
-			  WHERE author_id = $1
-			  ORDER BY created_at DESC`
+			  WHERE author_id = $1 AND deleted_at IS NULL
+			    AND NOT EXISTS (SELECT 1 FROM tenders t WHERE t.id = bid.tender_id AND t.deleted_at IS NOT NULL)`
+	args := []interface{}{authorID}
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		query += ` AND (created_at, id) < ($2::timestamptz, $3::uuid)`
+	}
+	query += ` ORDER BY created_at DESC, id DESC`
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
 
-	rows, err := r.DB.QueryContext(ctx, query, authorID)
+	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query bids: %w", err)
+		return nil, wrapError(ctx, fmt.Errorf("failed to query bids: %w", err))
 	}
 	defer rows.Close()
 
 	var bids []*domain.Bid
 	for rows.Next() {
 		var b domain.Bid
-		if err := rows.Scan(&b.ID, &b.Name, &b.Description, &b.Status, &b.TenderID, &b.AuthorType, &b.AuthorID, &b.Version, &b.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan bid: %w", err)
+		if err := rows.Scan(bidScanDest(&b)...); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan bid: %w", err))
 		}
 		bids = append(bids, &b)
 	}
@@ -302,18 +352,17 @@ func (r *PostgresRepository) GetBidsByAuthorID(ctx context.Context, authorID str
 
 // GetBidByID возвращает ставку по её ID
 func (r *PostgresRepository) GetBidByID(ctx context.Context, bidID string) (*domain.Bid, error) {
-	query := `SELECT id, name, description, status, tender_id, author_type, author_id, version, created_at
+	query := `SELECT ` + bidColumns + `
 			  FROM bid
-			  WHERE id = $1`
+			  WHERE id = $1 AND deleted_at IS NULL`
 
 	var b domain.Bid
-	err := r.DB.QueryRowContext(ctx, query, bidID).Scan(
-		&b.ID, &b.Name, &b.Description, &b.Status, &b.TenderID, &b.AuthorType, &b.AuthorID, &b.Version, &b.CreatedAt)
+	err := r.DB.QueryRowContext(ctx, query, bidID).Scan(bidScanDest(&b)...)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("bid not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wrapError(ctx, ErrBidNotFound)
 		}
-		return nil, fmt.Errorf("failed to get bid: %w", err)
+		return nil, wrapError(ctx, fmt.Errorf("failed to get bid: %w", err))
 	}
 	return &b, nil
 }
@@ -331,7 +380,7 @@ func (r *PostgresRepository) IsUserResponsibleForTender(ctx context.Context, use
 	var isResponsible bool
 	err := r.DB.QueryRowContext(ctx, query, tenderID, username).Scan(&isResponsible)
 	if err != nil {
-		return false, fmt.Errorf("failed to check user responsibility: %w", err)
+		return false, wrapError(ctx, fmt.Errorf("failed to check user responsibility: %w", err))
 	}
 	
 	return isResponsible, nil
@@ -351,7 +400,7 @@ func (r *PostgresRepository) UserHasAccessToBid(ctx context.Context, username, b
 	var hasAccess bool
 	err := r.DB.QueryRowContext(ctx, query, bidID, username).Scan(&hasAccess)
 	if err != nil {
-		return false, fmt.Errorf("failed to check user access to bid: %w", err)
+		return false, wrapError(ctx, fmt.Errorf("failed to check user access to bid: %w", err))
 	}
 	return hasAccess, nil
 }
@@ -361,7 +410,13 @@ func (r *PostgresRepository) UpdateBid(ctx context.Context, bid *domain.Bid) err
 		UPDATE bid 
 		SET name = $2, 
 			description = $3, 
-			status = $4
+			status = $4,
+			amount = $5,
+			currency = $6,
+			delivery_days = $7,
+			valid_from = $8,
+			valid_until = $9,
+			sealed_payload = $10
 		WHERE id = $1
 		RETURNING version
 	`
@@ -370,13 +425,19 @@ func (r *PostgresRepository) UpdateBid(ctx context.Context, bid *domain.Bid) err
 		bid.ID, 
 		bid.Name, 
 		bid.Description, 
-		bid.Status).Scan(&bid.Version)
+		bid.Status,
+		bid.Amount,
+		bid.Currency,
+		bid.DeliveryDays,
+		bid.ValidFrom,
+		bid.ValidUntil,
+		bid.SealedPayload).Scan(&bid.Version)
 	
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return fmt.Errorf("заявка с ID %s не найдена", bid.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return wrapError(ctx, ErrBidNotFound)
 		}
-		return fmt.Errorf("не удалось обновить заявку: %w", err)
+		return wrapError(ctx, fmt.Errorf("failed to update bid: %w", err))
 	}
 
 	return nil
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+	"time"
+)
+
+func (r *PostgresRepository) GetQuestionDeadline(ctx context.Context, tenderID string) (*time.Time, error) {
+	var deadline *time.Time
+	err := r.DB.QueryRowContext(ctx, `SELECT deadline FROM tender_question_settings WHERE tender_id = $1`, tenderID).Scan(&deadline)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, fmt.Errorf("failed to get question deadline: %w", err))
+	}
+	return deadline, nil
+}
+
+func (r *PostgresRepository) SetQuestionDeadline(ctx context.Context, tenderID string, deadline *time.Time) error {
+	query := `INSERT INTO tender_question_settings (tender_id, deadline)
+			  VALUES ($1, $2)
+			  ON CONFLICT (tender_id) DO UPDATE
+			  SET deadline = EXCLUDED.deadline, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.DB.ExecContext(ctx, query, tenderID, deadline); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to set question deadline: %w", err))
+	}
+	return nil
+}
+
+func (r *PostgresRepository) InsertQuestion(ctx context.Context, q *domain.TenderQuestion) error {
+	query := `INSERT INTO tender_questions (tender_id, author_id, text)
+			  VALUES ($1, $2, $3)
+			  RETURNING id, status, created_at`
+	err := r.DB.QueryRowContext(ctx, query, q.TenderID, q.AuthorID, q.Text).Scan(&q.ID, &q.Status, &q.CreatedAt)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to insert question: %w", err))
+	}
+	return nil
+}
+
+const questionColumns = `q.id, q.tender_id, q.text, q.status, e.username, q.original_text, q.answer, q.answered_at,
+		q.amended_version, q.rejection_reason, q.created_at, q.author_id`
+
+func questionScanDest(q *domain.TenderQuestion) []interface{} {
+	return []interface{}{
+		&q.ID, &q.TenderID, &q.Text, &q.Status, &q.AuthorUsername, &q.OriginalText, &q.Answer, &q.AnsweredAt,
+		&q.AmendedVersion, &q.RejectionReason, &q.CreatedAt, &q.AuthorID,
+	}
+}
+
+// ListQuestions возвращает вопросы тендера от старых к новым: все при all, иначе
+// отвеченные и заданные viewerID. Пустой status — вопросы в любом статусе.
+func (r *PostgresRepository) ListQuestions(ctx context.Context, tenderID, viewerID string, all bool, status string) ([]*domain.TenderQuestion, error) {
+	query := `SELECT ` + questionColumns + `
+			  FROM tender_questions q
+			  JOIN employee e ON e.id = q.author_id
+			  WHERE q.tender_id = $1
+			    AND ($2 OR q.status = 'ANSWERED' OR q.author_id::text = $3)
+			    AND ($4 = '' OR q.status = $4)
+			  ORDER BY q.created_at, q.id`
+	rows, err := r.DB.QueryContext(ctx, query, tenderID, all, viewerID, status)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query questions: %w", err))
+	}
+	defer rows.Close()
+
+	questions := []*domain.TenderQuestion{}
+	for rows.Next() {
+		var q domain.TenderQuestion
+		if err := rows.Scan(questionScanDest(&q)...); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan question: %w", err))
+		}
+		questions = append(questions, &q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate questions: %w", err))
+	}
+	return questions, nil
+}
+
+func (r *PostgresRepository) GetQuestion(ctx context.Context, questionID string) (*domain.TenderQuestion, error) {
+	query := `SELECT ` + questionColumns + `
+			  FROM tender_questions q
+			  JOIN employee e ON e.id = q.author_id
+			  WHERE q.id = $1`
+	var q domain.TenderQuestion
+	err := r.DB.QueryRowContext(ctx, query, questionID).Scan(questionScanDest(&q)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, ErrQuestionNotFound)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to get question: %w", err))
+	}
+	return &q, nil
+}
+
+// AnswerQuestion сохраняет ответ и, если передан amended, в той же транзакции
+// обновляет тендер: триггер сохраняет прежнюю версию, а новая записывается в вопрос.
+// q.Text, если отличается от сохраненного, публикуется вместо исходного текста.
+func (r *PostgresRepository) AnswerQuestion(ctx context.Context, q *domain.TenderQuestion, answeredBy string, amended *domain.Tender) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	var version *int
+	if amended != nil {
+		query := `UPDATE tenders
+				  SET name = $2, description = $3, submission_deadline = $4,
+				      change_reason = 'clarification ' || $5::text
+				  WHERE id = $1 AND status <> 'CLOSED'
+				  RETURNING version`
+		err := tx.QueryRowContext(ctx, query, amended.ID, amended.Name, amended.Description, amended.SubmissionDeadline, q.ID).
+			Scan(&amended.Version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return wrapError(ctx, ErrTenderClosed)
+		}
+		if err != nil {
+			return wrapError(ctx, fmt.Errorf("failed to amend tender: %w", err))
+		}
+		version = &amended.Version
+	}
+
+	query := `UPDATE tender_questions
+			  SET original_text = CASE WHEN text <> $2 THEN COALESCE(original_text, text) ELSE original_text END,
+			      text = $2,
+			      status = 'ANSWERED',
+			      answer = $3,
+			      answered_by = $4,
+			      answered_at = CURRENT_TIMESTAMP,
+			      amended_version = COALESCE($5, amended_version),
+			      rejection_reason = NULL
+			  WHERE id = $1
+			  RETURNING original_text, answered_at, amended_version`
+	err = tx.QueryRowContext(ctx, query, q.ID, q.Text, q.Answer, answeredBy, version).
+		Scan(&q.OriginalText, &q.AnsweredAt, &q.AmendedVersion)
+	if errors.Is(err, sql.ErrNoRows) {
+		return wrapError(ctx, ErrQuestionNotFound)
+	}
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to answer question: %w", err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to commit answer: %w", err))
+	}
+	q.Status = domain.QuestionStatusAnswered
+	q.RejectionReason = nil
+	return nil
+}
+
+// RejectQuestion скрывает вопрос от других участников; автор видит причину отклонения.
+func (r *PostgresRepository) RejectQuestion(ctx context.Context, questionID, moderatorID, reason string) error {
+	query := `UPDATE tender_questions
+			  SET status = 'REJECTED', rejection_reason = $3, moderated_by = $2, moderated_at = CURRENT_TIMESTAMP
+			  WHERE id = $1`
+	res, err := r.DB.ExecContext(ctx, query, questionID, moderatorID, reason)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to reject question: %w", err))
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return wrapError(ctx, ErrQuestionNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// TakeRateLimitToken пополняет корзину key по прошедшему времени, списывает токен,
+// если он есть, и возвращает количество токенов до списания.
+func (r *PostgresRepository) TakeRateLimitToken(ctx context.Context, key string, rate float64, burst int) (float64, error) {
+	query := `
+		WITH bucket_state AS (
+			SELECT LEAST($2::float8, tokens + EXTRACT(EPOCH FROM (now() - updated_at)) * $3::float8) AS available
+			FROM rate_limit_buckets
+			WHERE key = $1
+			FOR UPDATE
+		)
+		UPDATE rate_limit_buckets b
+		SET tokens = CASE WHEN c.available >= 1 THEN c.available - 1 ELSE c.available END,
+			updated_at = now()
+		FROM bucket_state c
+		WHERE b.key = $1
+		RETURNING c.available
+	`
+	var available float64
+	err := r.DB.QueryRowContext(ctx, query, key, burst, rate).Scan(&available)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Первая попытка для ключа: создаем полную корзину и сразу списываем токен
+		insert := `INSERT INTO rate_limit_buckets (key, tokens, updated_at)
+				   VALUES ($1, $2::float8 - 1, now())
+				   ON CONFLICT (key) DO NOTHING`
+		res, err := r.DB.ExecContext(ctx, insert, key, burst)
+		if err != nil {
+			return 0, wrapError(ctx, fmt.Errorf("failed to create rate limit bucket: %w", err))
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			// Корзину параллельно создала другая реплика
+			return r.TakeRateLimitToken(ctx, key, rate, burst)
+		}
+		return float64(burst), nil
+	}
+	if err != nil {
+		return 0, wrapError(ctx, fmt.Errorf("failed to take rate limit token: %w", err))
+	}
+	return available, nil
+}
+
+// RefundRateLimitToken возвращает в корзину key токен, списанный TakeRateLimitToken.
+func (r *PostgresRepository) RefundRateLimitToken(ctx context.Context, key string, burst int) error {
+	query := `UPDATE rate_limit_buckets SET tokens = LEAST($2::float8, tokens + 1) WHERE key = $1`
+	if _, err := r.DB.ExecContext(ctx, query, key, burst); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to refund rate limit token: %w", err))
+	}
+	return nil
+}
+
+// DeleteIdleRateLimitBuckets удаляет корзины, которые не использовались дольше idleSeconds.
+func (r *PostgresRepository) DeleteIdleRateLimitBuckets(ctx context.Context, idleSeconds int) (int64, error) {
+	query := `DELETE FROM rate_limit_buckets WHERE updated_at < now() - make_interval(secs => $1)`
+	res, err := r.DB.ExecContext(ctx, query, idleSeconds)
+	if err != nil {
+		return 0, wrapError(ctx, fmt.Errorf("failed to delete idle rate limit buckets: %w", err))
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"tender_srevice/internal/domain"
+	"time"
+)
+
+// SealedBidSubmissions возвращает время подачи предложений по тендеру — единственное,
+// что видно организации, пока запечатанный тендер открыт.
+func (r *PostgresRepository) SealedBidSubmissions(ctx context.Context, tenderID string) ([]time.Time, error) {
+	query := `SELECT created_at FROM bid WHERE tender_id = $1 AND deleted_at IS NULL ORDER BY created_at ASC, id ASC`
+	rows, err := r.DB.QueryContext(ctx, query, tenderID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query bid submissions: %w", err))
+	}
+	defer rows.Close()
+
+	submissions := []time.Time{}
+	for rows.Next() {
+		var at time.Time
+		if err := rows.Scan(&at); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan bid submission: %w", err))
+		}
+		submissions = append(submissions, at)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate bid submissions: %w", err))
+	}
+	return submissions, nil
+}
+
+// HasSealedBids сообщает, остались ли у тендера невскрытые предложения или их версии.
+func (r *PostgresRepository) HasSealedBids(ctx context.Context, tenderID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM bid WHERE tender_id = $1 AND sealed_payload IS NOT NULL)
+			      OR EXISTS(SELECT 1 FROM bid_versions WHERE tender_id = $1 AND sealed_payload IS NOT NULL)`
+	var sealed bool
+	if err := r.DB.QueryRowContext(ctx, query, tenderID).Scan(&sealed); err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to check sealed bids: %w", err))
+	}
+	return sealed, nil
+}
+
+// UnsealBids в одной транзакции расшифровывает через open все запечатанные предложения
+// тендера и их прошлые версии, записывает содержимое в открытые колонки и добавляет
+// событие в bid_unseal_events. Вскрытие не создает новых версий предложений.
+// Если вскрывать нечего, событие не записывается и возвращается 0.
+func (r *PostgresRepository) UnsealBids(ctx context.Context, tenderID, unsealedBy, reason string, open func(payload []byte) (domain.BidContent, error)) (int, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	bids, err := unsealRows(ctx, tx, open,
+		`SELECT id, sealed_payload FROM bid WHERE tender_id = $1 AND sealed_payload IS NOT NULL FOR UPDATE`,
+		`UPDATE bid
+		 SET name = $2, description = $3, amount = $4, currency = $5, delivery_days = $6,
+		     valid_from = $7, valid_until = $8, sealed_payload = NULL
+		 WHERE id = $1`, tenderID)
+	if err != nil {
+		return 0, err
+	}
+	versions, err := unsealRows(ctx, tx, open,
+		`SELECT version_id, sealed_payload FROM bid_versions WHERE tender_id = $1 AND sealed_payload IS NOT NULL FOR UPDATE`,
+		`UPDATE bid_versions
+		 SET name = $2, description = $3, amount = $4, currency = $5, delivery_days = $6,
+		     valid_from = $7, valid_until = $8, sealed_payload = NULL
+		 WHERE version_id = $1`, tenderID)
+	if err != nil {
+		return 0, err
+	}
+	if bids == 0 && versions == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO bid_unseal_events (tender_id, bids_count, versions_count, unsealed_by, reason)
+								  VALUES ($1, $2, $3, $4, $5)`, tenderID, bids, versions, unsealedBy, reason)
+	if err != nil {
+		return 0, wrapError(ctx, fmt.Errorf("failed to record unseal event: %w", err))
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, wrapError(ctx, fmt.Errorf("failed to commit unsealed bids: %w", err))
+	}
+	return bids, nil
+}
+
+func unsealRows(ctx context.Context, tx *sql.Tx, open func([]byte) (domain.BidContent, error), selectQuery, updateQuery, tenderID string) (int, error) {
+	type sealedRow struct {
+		id      string
+		payload []byte
+	}
+	rows, err := tx.QueryContext(ctx, selectQuery, tenderID)
+	if err != nil {
+		return 0, wrapError(ctx, fmt.Errorf("failed to query sealed bids: %w", err))
+	}
+	var sealed []sealedRow
+	for rows.Next() {
+		var row sealedRow
+		if err := rows.Scan(&row.id, &row.payload); err != nil {
+			rows.Close()
+			return 0, wrapError(ctx, fmt.Errorf("failed to scan sealed bid: %w", err))
+		}
+		sealed = append(sealed, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, wrapError(ctx, fmt.Errorf("failed to iterate sealed bids: %w", err))
+	}
+
+	for _, row := range sealed {
+		c, err := open(row.payload)
+		if err != nil {
+			return 0, wrapError(ctx, fmt.Errorf("failed to unseal bid %s: %w", row.id, err))
+		}
+		_, err = tx.ExecContext(ctx, updateQuery, row.id,
+			c.Name, c.Description, c.Amount, c.Currency, c.DeliveryDays, c.ValidFrom, c.ValidUntil)
+		if err != nil {
+			return 0, wrapError(ctx, fmt.Errorf("failed to store unsealed bid %s: %w", row.id, err))
+		}
+	}
+	return len(sealed), nil
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+const serviceTypeSelect = `SELECT st.code, st.parent_code, st.labels, st.active,
+			  COALESCE(array_agg(a.alias ORDER BY a.alias) FILTER (WHERE a.alias IS NOT NULL), '{}')
+			  FROM service_types st
+			  LEFT JOIN service_type_aliases a ON a.code = st.code`
+
+type serviceTypeScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanServiceType(row serviceTypeScanner) (*domain.ServiceType, error) {
+	var (
+		st     domain.ServiceType
+		labels []byte
+	)
+	if err := row.Scan(&st.Code, &st.ParentCode, &labels, &st.Active, pq.Array(&st.Aliases)); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(labels, &st.Labels); err != nil {
+		return nil, fmt.Errorf("failed to decode labels of service type %s: %w", st.Code, err)
+	}
+	return &st, nil
+}
+
+// ServiceTypeRef — результат сопоставления значения из запроса с кодом справочника.
+type ServiceTypeRef struct {
+	Code   string
+	Active bool
+}
+
+// ListServiceTypes возвращает справочник в порядке кодов; неактивные типы — только при includeInactive.
+func (r *PostgresRepository) ListServiceTypes(ctx context.Context, includeInactive bool) ([]*domain.ServiceType, error) {
+	query := serviceTypeSelect + `
+			  WHERE $1 OR st.active
+			  GROUP BY st.code
+			  ORDER BY st.code ASC`
+	rows, err := r.DB.QueryContext(ctx, query, includeInactive)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query service types: %w", err))
+	}
+	defer rows.Close()
+
+	types := []*domain.ServiceType{}
+	for rows.Next() {
+		st, err := scanServiceType(rows)
+		if err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan service type: %w", err))
+		}
+		types = append(types, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate service types: %w", err))
+	}
+	return types, nil
+}
+
+func (r *PostgresRepository) GetServiceType(ctx context.Context, code string) (*domain.ServiceType, error) {
+	query := serviceTypeSelect + `
+			  WHERE st.code = $1
+			  GROUP BY st.code`
+	st, err := scanServiceType(r.DB.QueryRowContext(ctx, query, code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, ErrServiceTypeNotFound)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to get service type: %w", err))
+	}
+	return st, nil
+}
+
+// ResolveServiceTypes сопоставляет значения с кодами: значение принимается как код
+// в любом регистре или как синоним из service_type_aliases. Несопоставленных значений в ответе нет.
+func (r *PostgresRepository) ResolveServiceTypes(ctx context.Context, values []string) (map[string]ServiceTypeRef, error) {
+	query := `SELECT v.value, st.code, st.active
+			  FROM unnest($1::text[]) AS v(value)
+			  LEFT JOIN service_type_aliases a ON a.alias = lower(btrim(v.value))
+			  JOIN service_types st ON st.code = COALESCE(a.code, upper(btrim(v.value)))`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(values))
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to resolve service types: %w", err))
+	}
+	defer rows.Close()
+
+	refs := map[string]ServiceTypeRef{}
+	for rows.Next() {
+		var (
+			value string
+			ref   ServiceTypeRef
+		)
+		if err := rows.Scan(&value, &ref.Code, &ref.Active); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan service type: %w", err))
+		}
+		refs[value] = ref
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate service types: %w", err))
+	}
+	return refs, nil
+}
+
+// SaveServiceType создает тип или заменяет его родителя, подписи, синонимы и активность.
+// Возвращает true, если тип создан.
+func (r *PostgresRepository) SaveServiceType(ctx context.Context, st *domain.ServiceType) (bool, error) {
+	labels, err := json.Marshal(st.Labels)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	// Иерархия меняется под блокировкой, чтобы два параллельных изменения не замкнули цикл
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE service_types IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to lock service types: %w", err))
+	}
+
+	if st.ParentCode != nil {
+		var cycle, parentExists bool
+		err := tx.QueryRowContext(ctx, `
+			WITH RECURSIVE ancestors AS (
+				SELECT code, parent_code FROM service_types WHERE code = $1
+				UNION
+				SELECT p.code, p.parent_code FROM service_types p JOIN ancestors a ON p.code = a.parent_code
+			)
+			SELECT EXISTS (SELECT 1 FROM ancestors WHERE code = $2), EXISTS (SELECT 1 FROM ancestors)`,
+			*st.ParentCode, st.Code).Scan(&cycle, &parentExists)
+		if err != nil {
+			return false, wrapError(ctx, fmt.Errorf("failed to check service type hierarchy: %w", err))
+		}
+		if !parentExists {
+			return false, wrapError(ctx, ErrServiceTypeParentNotFound)
+		}
+		if cycle {
+			return false, wrapError(ctx, ErrServiceTypeCycle)
+		}
+	}
+
+	var created bool
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO service_types (code, parent_code, labels, active)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code) DO UPDATE
+		SET parent_code = EXCLUDED.parent_code, labels = EXCLUDED.labels, active = EXCLUDED.active, updated_at = CURRENT_TIMESTAMP
+		RETURNING (xmax = 0)`,
+		st.Code, st.ParentCode, labels, st.Active).Scan(&created)
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to save service type: %w", err))
+	}
+
+	var taken string
+	err = tx.QueryRowContext(ctx, `SELECT alias FROM service_type_aliases WHERE alias = ANY($1) AND code <> $2 LIMIT 1`,
+		pq.Array(st.Aliases), st.Code).Scan(&taken)
+	if err == nil {
+		return false, wrapError(ctx, fmt.Errorf("%w: %q", ErrServiceTypeAliasTaken, taken))
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, wrapError(ctx, fmt.Errorf("failed to check service type aliases: %w", err))
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM service_type_aliases WHERE code = $1`, st.Code); err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to replace service type aliases: %w", err))
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO service_type_aliases (alias, code) SELECT unnest($1::text[]), $2`,
+		pq.Array(st.Aliases), st.Code)
+	if err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to insert service type aliases: %w", err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, wrapError(ctx, fmt.Errorf("failed to commit service type: %w", err))
+	}
+	return created, nil
+}
+
+// DeleteServiceType удаляет тип, если на него не ссылаются подтипы, тендеры и их версии.
+func (r *PostgresRepository) DeleteServiceType(ctx context.Context, code string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	var exists, inUse bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM service_types WHERE code = $1),
+		       EXISTS (SELECT 1 FROM service_types WHERE parent_code = $1)
+		       OR EXISTS (SELECT 1 FROM tenders WHERE service_type = $1)
+		       OR EXISTS (SELECT 1 FROM tender_versions WHERE service_type = $1)`, code).Scan(&exists, &inUse)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to check service type usage: %w", err))
+	}
+	if !exists {
+		return wrapError(ctx, ErrServiceTypeNotFound)
+	}
+	if inUse {
+		return wrapError(ctx, ErrServiceTypeInUse)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM service_types WHERE code = $1`, code); err != nil {
+		var pqErr *pq.Error
+		// Тендер с этим типом мог появиться после проверки
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return wrapError(ctx, ErrServiceTypeInUse)
+		}
+		return wrapError(ctx, fmt.Errorf("failed to delete service type: %w", err))
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to commit service type deletion: %w", err))
+	}
+	return nil
+}
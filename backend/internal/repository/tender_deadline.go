@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// deadlineSchedulerLockKey — ключ advisory-блокировки планировщика закрытия тендеров.
+// Блокировку держит только одна реплика за раз.
+const deadlineSchedulerLockKey = 61050001
+
+// IsTenderDeadlinePassed сверяет срок подачи с часами базы данных, а не сервиса.
+func (r *PostgresRepository) IsTenderDeadlinePassed(ctx context.Context, tenderID string) (bool, error) {
+	query := `SELECT submission_deadline IS NOT NULL AND submission_deadline <= now() FROM tenders WHERE id = $1`
+	var passed bool
+	err := r.DB.QueryRowContext(ctx, query, tenderID).Scan(&passed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, wrapError(ctx, ErrTenderNotFound)
+		}
+		return false, wrapError(ctx, fmt.Errorf("failed to check tender deadline: %w", err))
+	}
+	return passed, nil
+}
+
+// CloseExpiredTenders переводит опубликованные тендеры с истекшим сроком в CLOSED.
+// Работает под транзакционной advisory-блокировкой: если ее держит другая реплика,
+// возвращает locked = false и ничего не меняет. Причина попадает в историю версий.
+func (r *PostgresRepository) CloseExpiredTenders(ctx context.Context, reason string) (closed []string, locked bool, err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1)`, deadlineSchedulerLockKey).Scan(&locked); err != nil {
+		return nil, false, wrapError(ctx, fmt.Errorf("failed to acquire scheduler lock: %w", err))
+	}
+	if !locked {
+		return nil, false, nil
+	}
+
+	query := `UPDATE tenders
+			  SET status = 'CLOSED', change_reason = $1
+			  WHERE status = 'PUBLISHED' AND submission_deadline IS NOT NULL AND submission_deadline <= now()
+			    AND deleted_at IS NULL
+			  RETURNING id`
+	rows, err := tx.QueryContext(ctx, query, reason)
+	if err != nil {
+		return nil, true, wrapError(ctx, fmt.Errorf("failed to close expired tenders: %w", err))
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, true, wrapError(ctx, fmt.Errorf("failed to scan closed tender: %w", err))
+		}
+		closed = append(closed, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, true, wrapError(ctx, fmt.Errorf("failed to close expired tenders: %w", err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, true, wrapError(ctx, fmt.Errorf("failed to commit closed tenders: %w", err))
+	}
+	return closed, true, nil
+}
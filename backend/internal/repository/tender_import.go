@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"tender_srevice/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// ExistingOrganizationIDs возвращает множество тех ids, для которых есть организация.
+func (r *PostgresRepository) ExistingOrganizationIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	query := `SELECT id FROM organization WHERE id::text = ANY($1)`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query organizations: %w", err))
+	}
+	defer rows.Close()
+
+	existing := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan organization: %w", err))
+		}
+		existing[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate organizations: %w", err))
+	}
+	return existing, nil
+}
+
+// ResponsibleOrganizations возвращает для каждого существующего сотрудника из usernames
+// множество организаций, за которые он отвечает (возможно, пустое).
+func (r *PostgresRepository) ResponsibleOrganizations(ctx context.Context, usernames []string) (map[string]map[string]bool, error) {
+	query := `SELECT e.username, org_resp.organization_id
+			  FROM employee e
+			  LEFT JOIN organization_responsible org_resp ON org_resp.user_id = e.id
+			  WHERE e.username = ANY($1)`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(usernames))
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query responsibles: %w", err))
+	}
+	defer rows.Close()
+
+	result := map[string]map[string]bool{}
+	for rows.Next() {
+		var (
+			username       string
+			organizationID sql.NullString
+		)
+		if err := rows.Scan(&username, &organizationID); err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan responsible: %w", err))
+		}
+		if result[username] == nil {
+			result[username] = map[string]bool{}
+		}
+		if organizationID.Valid {
+			result[username][organizationID.String] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate responsibles: %w", err))
+	}
+	return result, nil
+}
+
+// CopyTenders вставляет тендеры пачками по batchSize через COPY в одной транзакции.
+// Идентификаторы генерируются заранее и проставляются в items.
+func (r *PostgresRepository) CopyTenders(ctx context.Context, items []*domain.Tender, batchSize int) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	for start := 0; start < len(items); start += batchSize {
+		end := start + batchSize
+		if end > len(items) {
+			end = len(items)
+		}
+		if err := copyTenderBatch(ctx, tx, items[start:end]); err != nil {
+			return wrapError(ctx, fmt.Errorf("failed to copy tenders %d-%d: %w", start+1, end, err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to commit tender import: %w", err))
+	}
+	return nil
+}
+
+func copyTenderBatch(ctx context.Context, tx *sql.Tx, items []*domain.Tender) error {
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("tenders",
+		"id", "name", "description", "status", "service_type", "organization_id", "creator_username", "submission_deadline"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, t := range items {
+		id, err := newUUID()
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx, id, t.Name, t.Description, t.Status, t.ServiceType, t.OrganizationID, t.CreatorUsername, t.SubmissionDeadline); err != nil {
+			return err
+		}
+		t.ID = id
+		t.Version = 1
+	}
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
+
+// newUUID генерирует UUID версии 4.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
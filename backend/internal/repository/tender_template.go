@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// InsertTenderWithCriteria создает тендер вместе с критериями оценки одной транзакцией.
+func (r *PostgresRepository) InsertTenderWithCriteria(ctx context.Context, t *domain.Tender, criteria []domain.CriterionSpec) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO tenders (name, description, status, service_type, organization_id, creator_username, submission_deadline, sealed)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING id, version`
+	err = tx.QueryRowContext(ctx, query, t.Name, t.Description, t.Status, t.ServiceType, t.OrganizationID,
+		t.CreatorUsername, t.SubmissionDeadline, t.Sealed).Scan(&t.ID, &t.Version)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to insert tender: %w", err))
+	}
+	for i, c := range criteria {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO tender_criteria (tender_id, position, name, description, weight, scale_min, scale_max)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			t.ID, i, c.Name, c.Description, c.Weight, c.ScaleMin, c.ScaleMax)
+		if err != nil {
+			return wrapError(ctx, fmt.Errorf("failed to insert criterion: %w", err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to commit tender: %w", err))
+	}
+	return nil
+}
+
+const templateColumns = `id, organization_id, title, name, description, service_type, sealed, criteria, created_by, created_at`
+
+func scanTemplate(row interface{ Scan(...interface{}) error }) (*domain.TenderTemplate, error) {
+	var t domain.TenderTemplate
+	var criteria []byte
+	err := row.Scan(&t.ID, &t.OrganizationID, &t.Title, &t.Name, &t.Description, &t.ServiceType, &t.Sealed,
+		&criteria, &t.CreatedBy, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(criteria, &t.Criteria); err != nil {
+		return nil, fmt.Errorf("invalid criteria of template %s: %w", t.ID, err)
+	}
+	return &t, nil
+}
+
+func (r *PostgresRepository) InsertTemplate(ctx context.Context, t *domain.TenderTemplate) error {
+	criteria, err := json.Marshal(t.Criteria)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to encode template criteria: %w", err))
+	}
+	query := `INSERT INTO tender_templates (organization_id, title, name, description, service_type, sealed, criteria, created_by)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING id, created_at`
+	err = r.DB.QueryRowContext(ctx, query, t.OrganizationID, t.Title, t.Name, t.Description, t.ServiceType,
+		t.Sealed, criteria, t.CreatedBy).Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return wrapError(ctx, ErrTemplateTitleTaken)
+		}
+		return wrapError(ctx, fmt.Errorf("failed to insert template: %w", err))
+	}
+	return nil
+}
+
+func (r *PostgresRepository) ListTemplates(ctx context.Context, organizationID string) ([]*domain.TenderTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM tender_templates WHERE organization_id = $1 ORDER BY lower(title)`
+	rows, err := r.DB.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to query templates: %w", err))
+	}
+	defer rows.Close()
+
+	templates := []*domain.TenderTemplate{}
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, wrapError(ctx, fmt.Errorf("failed to scan template: %w", err))
+		}
+		templates = append(templates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to iterate templates: %w", err))
+	}
+	return templates, nil
+}
+
+func (r *PostgresRepository) GetTemplate(ctx context.Context, templateID string) (*domain.TenderTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM tender_templates WHERE id = $1`
+	t, err := scanTemplate(r.DB.QueryRowContext(ctx, query, templateID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, wrapError(ctx, ErrTemplateNotFound)
+	}
+	if err != nil {
+		return nil, wrapError(ctx, fmt.Errorf("failed to get template: %w", err))
+	}
+	return t, nil
+}
+
+func (r *PostgresRepository) DeleteTemplate(ctx context.Context, templateID string) error {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM tender_templates WHERE id = $1`, templateID)
+	if err != nil {
+		return wrapError(ctx, fmt.Errorf("failed to delete template: %w", err))
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return wrapError(ctx, ErrTemplateNotFound)
+	}
+	return nil
+}
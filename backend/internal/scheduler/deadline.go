@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"tender_srevice/internal/repository"
+	"time"
+)
+
+// DeadlineCloseReason записывается в историю версий при автоматическом закрытии.
+const DeadlineCloseReason = "automatically closed: submission deadline passed"
+
+// Unsealer вскрывает запечатанные предложения закрытого тендера.
+type Unsealer interface {
+	UnsealTender(ctx context.Context, tenderID, unsealedBy, reason string) (int, error)
+}
+
+// unsealedBy — от чьего имени планировщик записывает события вскрытия.
+const unsealedBy = "scheduler"
+
+// DeadlineScheduler периодически закрывает опубликованные тендеры с истекшим сроком подачи
+// и вскрывает их запечатанные предложения.
+// Одновременно работать может только одна реплика — ее выбирает advisory-блокировка в Postgres.
+type DeadlineScheduler struct {
+	repo     *repository.PostgresRepository
+	interval time.Duration
+	unsealer Unsealer
+}
+
+func NewDeadlineScheduler(repo *repository.PostgresRepository, interval time.Duration, unsealer Unsealer) *DeadlineScheduler {
+	return &DeadlineScheduler{repo: repo, interval: interval, unsealer: unsealer}
+}
+
+// Run выполняет проверки до отмены ctx.
+func (s *DeadlineScheduler) Run(ctx context.Context) {
+	slog.Info("deadline scheduler started", slog.Duration("interval", s.interval))
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+		select {
+		case <-ctx.Done():
+			slog.Info("deadline scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DeadlineScheduler) tick(ctx context.Context) {
+	closed, locked, err := s.repo.CloseExpiredTenders(ctx, DeadlineCloseReason)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("failed to close expired tenders", slog.Any("error", err))
+		}
+		return
+	}
+	if !locked {
+		slog.Debug("deadline scheduler lock is held by another replica")
+		return
+	}
+	for _, id := range closed {
+		slog.Info("tender closed by deadline", slog.String("tender_id", id))
+		// Невскрытые здесь предложения вскроются при первом чтении списка
+		if _, err := s.unsealer.UnsealTender(ctx, id, unsealedBy, DeadlineCloseReason); err != nil {
+			slog.Error("failed to unseal bids", slog.String("tender_id", id), slog.Any("error", err))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package sealing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// KeySize — длина ключа AES-256 в байтах.
+const KeySize = 32
+
+var ErrMalformed = errors.New("sealed payload is malformed")
+
+// Sealer шифрует содержимое запечатанных предложений AES-256-GCM.
+// Результат Seal — случайный nonce, за которым следует шифртекст с тегом.
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+func New(key []byte) (*Sealer, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("sealing key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Sealer{aead: aead}, nil
+}
+
+// Seal шифрует plaintext. associated привязывает шифртекст к контексту
+// (например, к ID тендера): Open с другим значением вернет ошибку.
+func (s *Sealer) Seal(plaintext, associated []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(plaintext)+s.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return s.aead.Seal(nonce, nonce, plaintext, associated), nil
+}
+
+func (s *Sealer) Open(sealed, associated []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize()+s.aead.Overhead() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, associated)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,72 @@
+package sealing
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func newTestSealer(t *testing.T) *Sealer {
+	t.Helper()
+	s, err := New(bytes.Repeat([]byte{7}, KeySize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	s := newTestSealer(t)
+	plaintext := []byte(`{"name":"Предложение","amount":150000}`)
+	tenderID := []byte("5f0c0c3e-0000-4000-8000-000000000001")
+
+	sealed, err := s.Seal(plaintext, tenderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, plaintext) {
+		t.Fatal("sealed payload contains the plaintext")
+	}
+	opened, err := s.Open(sealed, tenderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("opened = %q, want %q", opened, plaintext)
+	}
+
+	// Случайный nonce: одинаковое содержимое дает разные шифртексты
+	again, err := s.Seal(plaintext, tenderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(again, sealed) {
+		t.Error("sealing the same plaintext twice gave identical payloads")
+	}
+}
+
+func TestOpenRejectsOtherTender(t *testing.T) {
+	s := newTestSealer(t)
+	sealed, err := s.Seal([]byte("secret"), []byte("5f0c0c3e-0000-4000-8000-000000000001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Open(sealed, []byte("5f0c0c3e-0000-4000-8000-000000000002")); !errors.Is(err, ErrMalformed) {
+		t.Errorf("open with another tender ID: err = %v, want ErrMalformed", err)
+	}
+	tampered := append([]byte(nil), sealed...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := s.Open(tampered, []byte("5f0c0c3e-0000-4000-8000-000000000001")); !errors.Is(err, ErrMalformed) {
+		t.Errorf("open tampered payload: err = %v, want ErrMalformed", err)
+	}
+	if _, err := s.Open(sealed[:10], nil); !errors.Is(err, ErrMalformed) {
+		t.Errorf("open truncated payload: err = %v, want ErrMalformed", err)
+	}
+}
+
+func TestNewRejectsShortKey(t *testing.T) {
+	if _, err := New(make([]byte, 16)); err == nil {
+		t.Error("New accepted a 16-byte key")
+	}
+}
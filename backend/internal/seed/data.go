@@ -0,0 +1,98 @@
+package seed
+
+// Словари, из которых генератор собирает имена и тексты. Порядок элементов входит в
+// результат: изменение словаря меняет содержимое набора при том же Seed.
+
+var people = []struct {
+	name   string
+	latin  string
+	female bool
+}{
+	{"Анна", "anna", true}, {"Борис", "boris", false}, {"Вера", "vera", true},
+	{"Глеб", "gleb", false}, {"Дарья", "darya", true}, {"Егор", "egor", false},
+	{"Зоя", "zoya", true}, {"Иван", "ivan", false}, {"Кира", "kira", true},
+	{"Лев", "lev", false}, {"Мария", "maria", true}, {"Никита", "nikita", false},
+	{"Ольга", "olga", true}, {"Павел", "pavel", false}, {"Софья", "sofya", true},
+	{"Тимур", "timur", false},
+}
+
+var lastNames = []struct {
+	male   string
+	female string
+	latin  string
+}{
+	{"Иванов", "Иванова", "ivanov"}, {"Смирнов", "Смирнова", "smirnov"},
+	{"Кузнецов", "Кузнецова", "kuznetsov"}, {"Попов", "Попова", "popov"},
+	{"Соколов", "Соколова", "sokolov"}, {"Лебедев", "Лебедева", "lebedev"},
+	{"Морозов", "Морозова", "morozov"}, {"Волков", "Волкова", "volkov"},
+	{"Орлов", "Орлова", "orlov"}, {"Зайцев", "Зайцева", "zaytsev"},
+}
+
+var organizationTypes = []struct {
+	code   string
+	prefix string
+}{
+	{"LLC", "ООО"}, {"JSC", "АО"}, {"IE", "ИП"},
+}
+
+var organizationNames = []string{
+	"Северный ветер", "Гранит", "Техностандарт", "Волга-Логистик", "Альфа Строй",
+	"Мебельная фабрика №1", "Уральские системы", "Светлый дом", "ПромРесурс", "Кедр",
+}
+
+var organizationDescriptions = []string{
+	"Закупки для собственных нужд и филиалов",
+	"Управляющая компания офисных центров",
+	"Производство и оптовая торговля",
+	"Сеть складов в центральном регионе",
+}
+
+var tenderSubjects = []struct {
+	serviceType  string
+	names        []string
+	descriptions []string
+}{
+	{
+		serviceType:  "CONSTRUCTION",
+		names:        []string{"Ремонт офиса", "Строительство склада", "Замена кровли", "Благоустройство территории"},
+		descriptions: []string{"Работы под ключ с материалами подрядчика.", "Объем работ — по приложенной смете.", "Работы в нерабочее время, без остановки офиса."},
+	},
+	{
+		serviceType:  "DELIVERY",
+		names:        []string{"Доставка оборудования", "Перевозка мебели", "Поставка канцтоваров", "Экспресс-доставка документов"},
+		descriptions: []string{"Доставка до склада покупателя с разгрузкой.", "Ежемесячные поставки по заявкам.", "Перевозка между филиалами компании."},
+	},
+	{
+		serviceType:  "MANUFACTURE",
+		names:        []string{"Изготовление мебели", "Производство упаковки", "Печать каталогов", "Пошив униформы"},
+		descriptions: []string{"Партия по образцу заказчика.", "Изготовление по техническому заданию.", "Серийное производство с приемкой на месте."},
+	},
+	{
+		serviceType:  "OTHER",
+		names:        []string{"Уборка помещений", "Охрана объекта", "Обслуживание кондиционеров", "Аудит ИТ-инфраструктуры"},
+		descriptions: []string{"Услуги по договору на год.", "Ежедневное обслуживание по графику.", "Разовая услуга с отчетом."},
+	},
+}
+
+var tenderAmendments = []string{
+	"Уточнен объем работ.",
+	"Добавлено требование о гарантии 12 месяцев.",
+	"Изменен адрес объекта.",
+}
+
+var bidNames = []string{
+	"Предложение под ключ", "Экономичный вариант", "Быстрое исполнение", "Расширенная гарантия", "Стандартное предложение",
+}
+
+var bidDescriptions = []string{
+	"Выполним в срок, опыт аналогичных проектов — 5 лет.",
+	"Собственная бригада и техника, гарантия 2 года.",
+	"Работаем по предоплате 30%, остальное по факту.",
+	"Цена включает доставку и монтаж.",
+}
+
+var withdrawReasons = []string{
+	"Ошибка в расчете стоимости",
+	"Нет свободных мощностей на эти сроки",
+	"Изменились цены поставщиков",
+}
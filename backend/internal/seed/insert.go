@@ -0,0 +1,149 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/scheduler"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrAlreadySeeded — в базе уже есть организации набора с тем же Seed.
+var ErrAlreadySeeded = errors.New("database already contains fixtures for this seed")
+
+// Insert записывает набор в одной транзакции. Тендеры и предложения вставляются в
+// первой ревизии, остальные ревизии применяются UPDATE-ами, поэтому историю версий
+// пишут те же триггеры, что и при работе через API.
+func Insert(ctx context.Context, db *sql.DB, f *Fixtures) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, len(f.Organizations))
+	for i, o := range f.Organizations {
+		ids[i] = o.ID
+	}
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM organization WHERE id::text = ANY($1))`, pq.Array(ids)).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check existing fixtures: %w", err)
+	}
+	if exists {
+		return ErrAlreadySeeded
+	}
+
+	w := writer{ctx: ctx, tx: tx}
+	for _, o := range f.Organizations {
+		w.exec("organization "+o.ID, `INSERT INTO organization (id, name, description, type) VALUES ($1, $2, $3, $4)`,
+			o.ID, o.Name, o.Description, o.Type)
+	}
+	for _, e := range f.Employees {
+		w.exec("employee "+e.Username, `INSERT INTO employee (id, username, first_name, last_name, email) VALUES ($1, $2, $3, $4, $5)`,
+			e.ID, e.Username, e.FirstName, e.LastName, e.Email)
+	}
+	for _, r := range f.Responsibles {
+		w.exec("responsible "+r.EmployeeID, `INSERT INTO organization_responsible (organization_id, user_id) VALUES ($1, $2)`,
+			r.OrganizationID, r.EmployeeID)
+	}
+	for _, t := range f.Tenders {
+		w.tender(t)
+	}
+
+	bidsByTender := map[string]int{}
+	for _, b := range f.Bids {
+		w.bid(b)
+		bidsByTender[b.TenderID]++
+	}
+	for _, t := range f.Tenders {
+		if t.Sealed && t.Current().Status == domain.TenderStatusClosed {
+			// Предложения набора сразу открытые; событие вскрытия показывает, что тендер
+			// закрыт по сроку и вскрыт планировщиком
+			w.exec("unseal event "+t.ID, `INSERT INTO bid_unseal_events (tender_id, bids_count, versions_count, unsealed_by, reason, unsealed_at)
+				VALUES ($1, $2, (SELECT count(*) FROM bid_versions WHERE tender_id = $1), 'scheduler', $3, $4)`,
+				t.ID, bidsByTender[t.ID], scheduler.DeadlineCloseReason, t.Current().SubmissionDeadline)
+		}
+		for i, c := range t.Criteria {
+			w.exec("criterion "+c.ID, `INSERT INTO tender_criteria (id, tender_id, position, name, description, weight, scale_min, scale_max)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+				c.ID, c.TenderID, i+1, c.Name, c.Description, c.Weight, c.ScaleMin, c.ScaleMax)
+		}
+	}
+	for _, s := range f.Scores {
+		w.exec("score "+s.BidID, `INSERT INTO bid_scores (bid_id, criterion_id, evaluator_id, score, comment) VALUES ($1, $2, $3, $4, $5)`,
+			s.BidID, s.CriterionID, s.EvaluatorID, s.Score, s.Comment)
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit fixtures: %w", err)
+	}
+	return nil
+}
+
+// writer запоминает первую ошибку, чтобы Insert не проверял каждый запрос.
+type writer struct {
+	ctx context.Context
+	tx  *sql.Tx
+	err error
+}
+
+func (w *writer) exec(what, query string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	if _, err := w.tx.ExecContext(w.ctx, query, args...); err != nil {
+		w.err = fmt.Errorf("failed to insert %s: %w", what, err)
+	}
+}
+
+func (w *writer) tender(t *Tender) {
+	first := t.Revisions[0]
+	w.exec("tender "+t.ID, `INSERT INTO tenders (id, name, description, status, organization_id, creator_username, service_type,
+			created_at, submission_deadline, sealed)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		t.ID, first.Name, first.Description, first.Status, t.OrganizationID, t.CreatorUsername, first.ServiceType,
+		t.CreatedAt, first.SubmissionDeadline, t.Sealed)
+	for _, r := range t.Revisions[1:] {
+		w.exec("tender "+t.ID, `UPDATE tenders
+			SET name = $2, description = $3, status = $4, service_type = $5, submission_deadline = $6, change_reason = $7
+			WHERE id = $1`,
+			t.ID, r.Name, r.Description, r.Status, r.ServiceType, r.SubmissionDeadline, nullString(r.Reason))
+	}
+	if t.Archived {
+		w.exec("tender "+t.ID, `UPDATE tenders SET deleted_at = $2, deleted_by = $3 WHERE id = $1`,
+			t.ID, t.Current().SubmissionDeadline.Add(24*time.Hour), t.CreatorUsername)
+	}
+}
+
+func (w *writer) bid(b *Bid) {
+	first := b.Revisions[0]
+	w.exec("bid "+b.ID, `INSERT INTO bid (id, name, description, status, tender_id, author_type, author_id, created_at,
+			amount, currency, delivery_days, valid_from, valid_until)
+		VALUES ($1, $2, $3, $4, $5, 'User', $6, $7, $8, $9, $10, $11, $12)`,
+		b.ID, first.Name, first.Description, first.Status, b.TenderID, b.AuthorID, b.CreatedAt,
+		first.Amount, first.Currency, first.DeliveryDays, first.ValidFrom, first.ValidUntil)
+	for i, r := range b.Revisions[1:] {
+		var changedAt *time.Time
+		if r.Action != "" {
+			at := b.CreatedAt.Add(time.Duration(i+1) * 2 * time.Hour)
+			changedAt = &at
+		}
+		w.exec("bid "+b.ID, `UPDATE bid
+			SET name = $2, description = $3, status = $4, amount = $5, currency = $6, delivery_days = $7,
+			    valid_from = $8, valid_until = $9, change_action = $10, change_reason = $11, changed_at = $12
+			WHERE id = $1`,
+			b.ID, r.Name, r.Description, r.Status, r.Amount, r.Currency, r.DeliveryDays, r.ValidFrom, r.ValidUntil,
+			nullString(r.Action), nullString(r.Reason), changedAt)
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,459 @@
+// Package seed генерирует воспроизводимый набор данных для локальной разработки:
+// один и тот же Config дает одинаковые организации, сотрудников, тендеры и предложения
+// с теми же идентификаторами.
+package seed
+
+import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"math/rand"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/scheduler"
+	"time"
+)
+
+// Config — размер набора. Идентификаторы зависят только от Seed и порядкового номера
+// объекта, поэтому при изменении размеров уже известные ID не меняются.
+type Config struct {
+	Seed int64
+	// Organizations — число организаций
+	Organizations int
+	// Responsibles — ответственные за каждую организацию
+	Responsibles int
+	// Bidders — сотрудники, которые только подают предложения
+	Bidders int
+	// Tenders — тендеры каждой организации; от 3, чтобы были все статусы
+	Tenders int
+	// BidsPerTender — наибольшее число предложений к тендеру, не больше Bidders
+	BidsPerTender int
+}
+
+func DefaultConfig() Config {
+	return Config{Seed: 1, Organizations: 3, Responsibles: 2, Bidders: 6, Tenders: 6, BidsPerTender: 4}
+}
+
+var ErrInvalidConfig = errors.New("invalid seed config")
+
+func (c Config) Validate() error {
+	switch {
+	case c.Organizations < 1:
+		return fmt.Errorf("%w: at least one organization is required", ErrInvalidConfig)
+	case c.Responsibles < 1:
+		return fmt.Errorf("%w: every organization needs at least one responsible", ErrInvalidConfig)
+	case c.Bidders < 1:
+		return fmt.Errorf("%w: at least one bidder is required", ErrInvalidConfig)
+	case c.Tenders < 3:
+		return fmt.Errorf("%w: at least 3 tenders per organization are required to cover every status", ErrInvalidConfig)
+	case c.BidsPerTender < 1 || c.BidsPerTender > c.Bidders:
+		return fmt.Errorf("%w: bids per tender must be between 1 and the number of bidders", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// Fixtures — сгенерированный набор в порядке вставки.
+type Fixtures struct {
+	Organizations []*domain.Organization
+	Employees     []*domain.Employee
+	Responsibles  []Responsible
+	Tenders       []*Tender
+	Bids          []*Bid
+	// Scores — оценки принятых предложений закрытых тендеров
+	Scores []domain.BidScore
+}
+
+type Responsible struct {
+	OrganizationID string
+	EmployeeID     string
+}
+
+// Tender вставляется в состоянии Revisions[0]; остальные ревизии применяются по
+// очереди, и прошлые версии сохраняет триггер tenders, как при работе через API.
+type Tender struct {
+	ID              string
+	OrganizationID  string
+	CreatorUsername string
+	Sealed          bool
+	CreatedAt       time.Time
+	Revisions       []TenderRevision
+	// Archived — тендер архивируется после всех ревизий
+	Archived bool
+	Criteria []domain.Criterion
+}
+
+type TenderRevision struct {
+	Name               string
+	Description        string
+	ServiceType        string
+	Status             string
+	SubmissionDeadline *time.Time
+	// Reason попадает в change_reason; пустая — NULL
+	Reason string
+}
+
+// Current — итоговое состояние тендера.
+func (t *Tender) Current() TenderRevision {
+	return t.Revisions[len(t.Revisions)-1]
+}
+
+// Bid, как и Tender, получает историю версий из последовательных ревизий.
+type Bid struct {
+	ID        string
+	TenderID  string
+	AuthorID  string
+	CreatedAt time.Time
+	Revisions []BidRevision
+}
+
+type BidRevision struct {
+	domain.BidContent
+	Status string
+	// Action — withdraw или resubmit для действий автора, пустое — обычная правка или решение организации
+	Action string
+	Reason string
+}
+
+func (b *Bid) Current() BidRevision {
+	return b.Revisions[len(b.Revisions)-1]
+}
+
+// Generate строит набор для cfg. Сроки подачи и даты отсчитываются от now, все
+// остальное зависит только от cfg.
+func Generate(cfg Config, now time.Time) (*Fixtures, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	g := &generator{cfg: cfg, rng: rand.New(rand.NewSource(cfg.Seed)), now: now.UTC().Truncate(time.Second), usernames: map[string]bool{}}
+	g.addOrganizations()
+	g.addBidders()
+	for i, o := range g.f.Organizations {
+		for j := 0; j < cfg.Tenders; j++ {
+			g.tender(i, o, j)
+		}
+	}
+	return &g.f, nil
+}
+
+type generator struct {
+	cfg Config
+	rng *rand.Rand
+	now time.Time
+	f   Fixtures
+
+	usernames    map[string]bool
+	responsibles map[string][]*domain.Employee
+	bidders      []*domain.Employee
+}
+
+// id — UUID версии 5 от Seed, вида объекта и его ключа.
+func (g *generator) id(kind string, key interface{}) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("tender-service-seed/%d/%s/%v", g.cfg.Seed, kind, key)))
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rng.Intn(len(values))]
+}
+
+func (g *generator) chance(p float64) bool {
+	return g.rng.Float64() < p
+}
+
+func (g *generator) days(min, max int) time.Duration {
+	return time.Duration(min+g.rng.Intn(max-min+1)) * 24 * time.Hour
+}
+
+func (g *generator) addOrganizations() {
+	g.responsibles = map[string][]*domain.Employee{}
+	for i := 0; i < g.cfg.Organizations; i++ {
+		typ := organizationTypes[g.rng.Intn(len(organizationTypes))]
+		o := &domain.Organization{
+			ID:          g.id("organization", i),
+			Name:        fmt.Sprintf("%s «%s»", typ.prefix, g.pick(organizationNames)),
+			Description: g.pick(organizationDescriptions),
+			Type:        typ.code,
+		}
+		g.f.Organizations = append(g.f.Organizations, o)
+
+		for j := 0; j < g.cfg.Responsibles; j++ {
+			e := g.employee(fmt.Sprintf("responsible/%d", i), j)
+			g.responsibles[o.ID] = append(g.responsibles[o.ID], e)
+			g.f.Responsibles = append(g.f.Responsibles, Responsible{OrganizationID: o.ID, EmployeeID: e.ID})
+		}
+	}
+}
+
+func (g *generator) addBidders() {
+	for i := 0; i < g.cfg.Bidders; i++ {
+		g.bidders = append(g.bidders, g.employee("bidder", i))
+	}
+}
+
+func (g *generator) employee(kind string, i int) *domain.Employee {
+	p := people[g.rng.Intn(len(people))]
+	last := lastNames[g.rng.Intn(len(lastNames))]
+	e := &domain.Employee{ID: g.id(kind, i), FirstName: p.name, LastName: last.male}
+	if p.female {
+		e.LastName = last.female
+	}
+
+	base := p.latin + "." + last.latin
+	if p.female {
+		base += "a"
+	}
+	username := base
+	for n := 2; g.usernames[username]; n++ {
+		username = fmt.Sprintf("%s%d", base, n)
+	}
+	g.usernames[username] = true
+	e.Username = username
+	email := username + "@example.com"
+	e.Email = &email
+
+	g.f.Employees = append(g.f.Employees, e)
+	return e
+}
+
+// tenderPlans задает статус j-го тендера организации по кругу, так что первые три
+// покрывают все статусы. Четвертый закрытый тендер запечатан, пятый — архивный.
+var tenderPlans = []struct {
+	status   string
+	sealed   bool
+	archived bool
+}{
+	{status: domain.TenderStatusPublished},
+	{status: domain.TenderStatusClosed},
+	{status: domain.TenderStatusCreated},
+	{status: domain.TenderStatusPublished},
+	{status: domain.TenderStatusClosed, sealed: true},
+	{status: domain.TenderStatusClosed, archived: true},
+}
+
+func (g *generator) tender(orgIndex int, o *domain.Organization, j int) {
+	plan := tenderPlans[j%len(tenderPlans)]
+	responsibles := g.responsibles[o.ID]
+	subject := tenderSubjects[g.rng.Intn(len(tenderSubjects))]
+	t := &Tender{
+		ID:              g.id("tender", fmt.Sprintf("%d/%d", orgIndex, j)),
+		OrganizationID:  o.ID,
+		CreatorUsername: responsibles[g.rng.Intn(len(responsibles))].Username,
+		Sealed:          plan.sealed,
+		Archived:        plan.archived,
+	}
+
+	var deadline time.Time
+	switch plan.status {
+	case domain.TenderStatusClosed:
+		deadline = g.now.Add(-g.days(1, 20))
+		t.CreatedAt = deadline.Add(-g.days(10, 30))
+	default:
+		deadline = g.now.Add(g.days(7, 45))
+		t.CreatedAt = g.now.Add(-g.days(1, 10))
+	}
+
+	first := TenderRevision{
+		Name:               g.pick(subject.names),
+		Description:        g.pick(subject.descriptions),
+		ServiceType:        subject.serviceType,
+		Status:             domain.TenderStatusCreated,
+		SubmissionDeadline: &deadline,
+	}
+	t.Revisions = append(t.Revisions, first)
+	if g.chance(0.6) {
+		edited := first
+		edited.Description = first.Description + " " + g.pick(tenderAmendments)
+		t.Revisions = append(t.Revisions, edited)
+	}
+	if plan.status != domain.TenderStatusCreated {
+		published := t.Current()
+		published.Status = domain.TenderStatusPublished
+		t.Revisions = append(t.Revisions, published)
+	}
+	if plan.status == domain.TenderStatusClosed {
+		closed := t.Current()
+		closed.Status = domain.TenderStatusClosed
+		closed.Reason = scheduler.DeadlineCloseReason
+		t.Revisions = append(t.Revisions, closed)
+	}
+	g.f.Tenders = append(g.f.Tenders, t)
+
+	if plan.status == domain.TenderStatusCreated {
+		return
+	}
+	bids := g.bids(t, plan.status, &deadline)
+	if plan.status == domain.TenderStatusClosed {
+		g.decide(t, bids, responsibles)
+	}
+}
+
+func (g *generator) bids(t *Tender, status string, deadline *time.Time) []*Bid {
+	n := 1 + g.rng.Intn(g.cfg.BidsPerTender)
+	authors := g.rng.Perm(len(g.bidders))[:n]
+	bids := make([]*Bid, 0, n)
+	for k, a := range authors {
+		amount := int64(50_000+g.rng.Intn(5_000_000)) * 100
+		currency := g.pick([]string{"RUB", "RUB", "RUB", "USD", "EUR"})
+		deliveryDays := 5 + g.rng.Intn(60)
+		b := &Bid{
+			ID:        g.id("bid", fmt.Sprintf("%s/%d", t.ID, k)),
+			TenderID:  t.ID,
+			AuthorID:  g.bidders[a].ID,
+			CreatedAt: t.CreatedAt.Add(time.Duration(k+1)*time.Hour + time.Duration(g.rng.Intn(3600))*time.Second),
+		}
+		first := BidRevision{
+			BidContent: domain.BidContent{
+				Name:         g.pick(bidNames),
+				Description:  g.pick(bidDescriptions),
+				Amount:       &amount,
+				Currency:     &currency,
+				DeliveryDays: &deliveryDays,
+				ValidUntil:   deadline,
+			},
+			Status: domain.BidStatusPending,
+		}
+		b.Revisions = append(b.Revisions, first)
+
+		if g.chance(0.5) {
+			// Автор снижает цену
+			lower := *first.Amount * int64(85+g.rng.Intn(11)) / 100
+			edited := b.Current()
+			edited.Amount = &lower
+			edited.Description = first.Description + " Цена пересмотрена."
+			edited.Action, edited.Reason = "", ""
+			b.Revisions = append(b.Revisions, edited)
+		}
+		if status == domain.TenderStatusPublished && g.chance(0.3) {
+			withdrawn := b.Current()
+			withdrawn.Status = domain.BidStatusRejected
+			withdrawn.Action, withdrawn.Reason = domain.BidActionWithdraw, g.pick(withdrawReasons)
+			b.Revisions = append(b.Revisions, withdrawn)
+			if g.chance(0.5) {
+				resubmitted := b.Current()
+				resubmitted.Status = b.Revisions[len(b.Revisions)-2].Status
+				resubmitted.Action, resubmitted.Reason = domain.BidActionResubmit, "Условия уточнены, подаем снова"
+				b.Revisions = append(b.Revisions, resubmitted)
+			}
+		}
+		g.f.Bids = append(g.f.Bids, b)
+		bids = append(bids, b)
+	}
+	return bids
+}
+
+// decide выносит решения по предложениям закрытого тендера: организация принимает
+// часть предложений (Published), остальные отклоняет (Canceled), и ответственные
+// оценивают принятые по критериям тендера.
+func (g *generator) decide(t *Tender, bids []*Bid, responsibles []*domain.Employee) {
+	t.Criteria = []domain.Criterion{
+		{ID: g.id("criterion", t.ID+"/price"), TenderID: t.ID,
+			CriterionSpec: domain.CriterionSpec{Name: "Цена", Weight: 3, ScaleMin: 0, ScaleMax: 10}},
+		{ID: g.id("criterion", t.ID+"/terms"), TenderID: t.ID,
+			CriterionSpec: domain.CriterionSpec{Name: "Сроки", Weight: 2, ScaleMin: 1, ScaleMax: 5}},
+		{ID: g.id("criterion", t.ID+"/experience"), TenderID: t.ID,
+			CriterionSpec: domain.CriterionSpec{Name: "Опыт", Description: "Выполненные проекты такого же масштаба", Weight: 1, ScaleMin: 1, ScaleMax: 5}},
+	}
+
+	accepted := 0
+	for i, b := range bids {
+		decided := b.Current()
+		decided.Action, decided.Reason = "", ""
+		// Хотя бы одно предложение принимается
+		if i == 0 || g.chance(0.5) {
+			decided.Status = domain.BidStatusAccepted
+			accepted++
+		} else {
+			decided.Status = domain.BidStatusRejected
+		}
+		b.Revisions = append(b.Revisions, decided)
+		if decided.Status != domain.BidStatusAccepted {
+			continue
+		}
+		for _, e := range responsibles {
+			for _, c := range t.Criteria {
+				g.f.Scores = append(g.f.Scores, domain.BidScore{
+					BidID:       b.ID,
+					CriterionID: c.ID,
+					EvaluatorID: e.ID,
+					Evaluator:   e.Username,
+					Score:       c.ScaleMin + g.rng.Intn(c.ScaleMax-c.ScaleMin+1),
+				})
+			}
+		}
+	}
+}
+
+// Summary — сводка по набору для вывода после заполнения базы.
+type Summary struct {
+	Seed            int64          `json:"seed"`
+	Organizations   int            `json:"organizations"`
+	Employees       int            `json:"employees"`
+	Responsibles    int            `json:"responsibles"`
+	Tenders         map[string]int `json:"tenders"`
+	ArchivedTenders int            `json:"archivedTenders"`
+	TenderVersions  int            `json:"tenderVersions"`
+	Bids            map[string]int `json:"bids"`
+	BidVersions     int            `json:"bidVersions"`
+	Scores          int            `json:"scores"`
+	Example         Example        `json:"example"`
+}
+
+// Example — объекты, на которые ссылается examples/api.http.
+type Example struct {
+	OrganizationID string `json:"organizationId"`
+	Responsible    string `json:"responsible"`
+	TenderID       string `json:"tenderId"`
+	BidID          string `json:"bidId"`
+	BidAuthor      string `json:"bidAuthor"`
+	BidAuthorID    string `json:"bidAuthorId"`
+}
+
+func (f *Fixtures) Summary(seed int64) Summary {
+	s := Summary{
+		Seed:          seed,
+		Organizations: len(f.Organizations),
+		Employees:     len(f.Employees),
+		Responsibles:  len(f.Responsibles),
+		Tenders:       map[string]int{},
+		Bids:          map[string]int{},
+		Scores:        len(f.Scores),
+	}
+	for _, t := range f.Tenders {
+		s.Tenders[t.Current().Status]++
+		s.TenderVersions += len(t.Revisions) - 1
+		if t.Archived {
+			s.ArchivedTenders++
+		}
+	}
+	for _, b := range f.Bids {
+		s.Bids[b.Current().Status]++
+		s.BidVersions += len(b.Revisions) - 1
+	}
+	s.Example = f.example()
+	return s
+}
+
+// example — первый опубликованный тендер первой организации, ее ответственный,
+// который создал тендер, и первое предложение к тендеру.
+func (f *Fixtures) example() Example {
+	employees := map[string]*domain.Employee{}
+	for _, e := range f.Employees {
+		employees[e.ID] = e
+	}
+	var ex Example
+	for _, t := range f.Tenders {
+		if t.OrganizationID != f.Organizations[0].ID || t.Current().Status != domain.TenderStatusPublished {
+			continue
+		}
+		ex.OrganizationID, ex.Responsible, ex.TenderID = t.OrganizationID, t.CreatorUsername, t.ID
+		for _, b := range f.Bids {
+			if b.TenderID == t.ID {
+				ex.BidID, ex.BidAuthorID, ex.BidAuthor = b.ID, b.AuthorID, employees[b.AuthorID].Username
+				break
+			}
+		}
+		break
+	}
+	return ex
+}
@@ -0,0 +1,133 @@
+package seed
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"tender_srevice/internal/domain"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func TestGenerateIsReproducible(t *testing.T) {
+	a, err := Generate(DefaultConfig(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Generate(DefaultConfig(), now)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("two runs with the same config produced different fixtures")
+	}
+
+	other := DefaultConfig()
+	other.Seed = 2
+	c, _ := Generate(other, now)
+	if c.Organizations[0].ID == a.Organizations[0].ID {
+		t.Error("different seeds produced the same organization ID")
+	}
+
+	// Идентификаторы не зависят от размеров набора
+	bigger := DefaultConfig()
+	bigger.Organizations, bigger.Bidders = 5, 10
+	d, _ := Generate(bigger, now)
+	if d.Organizations[0].ID != a.Organizations[0].ID || d.Tenders[0].ID != a.Tenders[0].ID {
+		t.Error("growing the fixture set changed existing IDs")
+	}
+}
+
+func TestGenerateCoversEveryState(t *testing.T) {
+	f, err := Generate(DefaultConfig(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := f.Summary(1)
+	for _, status := range []string{domain.TenderStatusCreated, domain.TenderStatusPublished, domain.TenderStatusClosed} {
+		if s.Tenders[status] == 0 {
+			t.Errorf("no %s tenders", status)
+		}
+	}
+	for _, status := range []string{domain.BidStatusPending, domain.BidStatusAccepted, domain.BidStatusRejected} {
+		if s.Bids[status] == 0 {
+			t.Errorf("no %s bids", status)
+		}
+	}
+	if s.ArchivedTenders == 0 || s.Scores == 0 || s.TenderVersions == 0 {
+		t.Errorf("summary = %+v, want archived tenders, scores and tender versions", s)
+	}
+
+	actions := map[string]bool{}
+	usernames := map[string]bool{}
+	for _, e := range f.Employees {
+		if usernames[e.Username] {
+			t.Errorf("duplicate username %q", e.Username)
+		}
+		usernames[e.Username] = true
+	}
+	tenders := map[string]*Tender{}
+	for _, tender := range f.Tenders {
+		tenders[tender.ID] = tender
+		if d := tender.Current().SubmissionDeadline; tender.Current().Status == domain.TenderStatusPublished && !d.After(now) {
+			t.Errorf("published tender %s has a past deadline", tender.ID)
+		}
+	}
+	for _, b := range f.Bids {
+		if st := tenders[b.TenderID].Current().Status; st == domain.TenderStatusCreated {
+			t.Errorf("bid %s belongs to an unpublished tender", b.ID)
+		}
+		for _, r := range b.Revisions {
+			actions[r.Action] = true
+		}
+	}
+	if !actions[domain.BidActionWithdraw] || !actions[domain.BidActionResubmit] {
+		t.Errorf("bid actions = %v, want withdraw and resubmit", actions)
+	}
+}
+
+// examples/api.http ссылается на набор по умолчанию.
+func TestExampleRequestsUseDefaultFixtures(t *testing.T) {
+	f, err := Generate(DefaultConfig(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("../../examples/api.http")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := f.Summary(1).Example
+	for name, value := range map[string]string{
+		"organizationId": ex.OrganizationID,
+		"responsible":    ex.Responsible,
+		"tenderId":       ex.TenderID,
+		"bidId":          ex.BidID,
+		"bidAuthor":      ex.BidAuthor,
+		"bidAuthorId":    ex.BidAuthorID,
+	} {
+		if value == "" {
+			t.Errorf("default fixtures have no example %s", name)
+			continue
+		}
+		if !containsLine(string(data), "@"+name+" = "+value) {
+			t.Errorf("examples/api.http: want @%s = %s; update it after changing the generator", name, value)
+		}
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Tenders = 2
+	if _, err := Generate(cfg, now); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("2 tenders: err = %v, want ErrInvalidConfig", err)
+	}
+	cfg = DefaultConfig()
+	cfg.BidsPerTender = cfg.Bidders + 1
+	if _, err := Generate(cfg, now); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("more bids than bidders: err = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func containsLine(text, line string) bool {
+	return strings.Contains("\n"+text+"\n", "\n"+line+"\n")
+}
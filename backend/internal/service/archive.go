@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/storage"
+)
+
+// ArchiveTender переносит тендер в архив: он пропадает из списков и перестает
+// находиться по ID, но вместе с версиями и предложениями остается в базе.
+// Опубликованный тендер сначала нужно закрыть или снять с публикации.
+func (s *TenderService) ArchiveTender(ctx context.Context, tenderID, username string) (*domain.Tender, error) {
+	tender, err := s.archivableTender(ctx, tenderID, username)
+	if err != nil {
+		return nil, err
+	}
+	if tender.DeletedAt != nil {
+		return nil, repository.ErrAlreadyArchived
+	}
+	if tender.Status == domain.TenderStatusPublished {
+		return nil, ErrArchiveActive
+	}
+	return s.Repo.ArchiveTender(ctx, tenderID, username)
+}
+
+func (s *TenderService) RestoreTender(ctx context.Context, tenderID, username string) (*domain.Tender, error) {
+	if _, err := s.archivableTender(ctx, tenderID, username); err != nil {
+		return nil, err
+	}
+	return s.Repo.RestoreTender(ctx, tenderID)
+}
+
+// ArchivedTenders возвращает архив тендеров организаций, за которые отвечает пользователь.
+func (s *TenderService) ArchivedTenders(ctx context.Context, username string) ([]*domain.Tender, error) {
+	if _, err := s.Repo.GetUserIDByUsername(ctx, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+	return s.Repo.ArchivedTenders(ctx, username)
+}
+
+func (s *TenderService) archivableTender(ctx context.Context, tenderID, username string) (*domain.Tender, error) {
+	tender, err := s.Repo.GetTenderIncludingArchived(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOrganizationResponsible(ctx, s.Repo, username, tender.OrganizationID); err != nil {
+		return nil, err
+	}
+	return tender, nil
+}
+
+// ArchiveBid переносит предложение автора в архив. Опубликованное предложение
+// можно архивировать только после закрытия тендера.
+func (s *BidService) ArchiveBid(ctx context.Context, bidID, username string) (*domain.Bid, error) {
+	bid, err := s.archivableBid(ctx, bidID, username)
+	if err != nil {
+		return nil, err
+	}
+	if bid.DeletedAt != nil {
+		return nil, repository.ErrAlreadyArchived
+	}
+	if bid.Status == domain.BidStatusAccepted {
+		status, err := s.Repo.GetTenderStatus(ctx, bid.TenderID)
+		if err != nil {
+			return nil, err
+		}
+		if status != domain.TenderStatusClosed {
+			return nil, ErrArchiveActive
+		}
+	}
+
+	archived, err := s.Repo.ArchiveBid(ctx, bidID, username)
+	if err != nil {
+		return nil, err
+	}
+	return archived, s.sealing.Reveal(archived)
+}
+
+func (s *BidService) RestoreBid(ctx context.Context, bidID, username string) (*domain.Bid, error) {
+	if _, err := s.archivableBid(ctx, bidID, username); err != nil {
+		return nil, err
+	}
+	restored, err := s.Repo.RestoreBid(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	return restored, s.sealing.Reveal(restored)
+}
+
+// ArchivedBids возвращает архивные предложения автора, запечатанные — расшифрованными.
+func (s *BidService) ArchivedBids(ctx context.Context, username string) ([]*domain.Bid, error) {
+	userID, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+	bids, err := s.Repo.ArchivedBidsByAuthorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bids {
+		if err := s.sealing.Reveal(b); err != nil {
+			return nil, err
+		}
+	}
+	return bids, nil
+}
+
+func (s *BidService) archivableBid(ctx context.Context, bidID, username string) (*domain.Bid, error) {
+	bid, err := s.Repo.GetBidIncludingArchived(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+	if bid.AuthorID != userID {
+		return nil, ErrNotBidAuthor
+	}
+	return bid, nil
+}
+
+// PurgeService окончательно удаляет архивные тендеры и предложения. Доступен
+// только администратору; файлы вложений удаляются из хранилища после фиксации.
+type PurgeService struct {
+	Repo  *repository.PostgresRepository
+	store storage.Storage
+}
+
+func NewPurgeService(repo *repository.PostgresRepository, store storage.Storage) *PurgeService {
+	return &PurgeService{Repo: repo, store: store}
+}
+
+func (s *PurgeService) PurgeTender(ctx context.Context, tenderID string) error {
+	keys, err := s.Repo.PurgeTender(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+	s.deleteContent(ctx, keys)
+	slog.InfoContext(ctx, "tender purged", slog.String("tender_id", tenderID), slog.Int("attachments", len(keys)))
+	return nil
+}
+
+func (s *PurgeService) PurgeBid(ctx context.Context, bidID string) error {
+	keys, err := s.Repo.PurgeBid(ctx, bidID)
+	if err != nil {
+		return err
+	}
+	s.deleteContent(ctx, keys)
+	slog.InfoContext(ctx, "bid purged", slog.String("bid_id", bidID), slog.Int("attachments", len(keys)))
+	return nil
+}
+
+func (s *PurgeService) deleteContent(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			slog.ErrorContext(ctx, "failed to delete attachment content", slog.String("key", key), slog.Any("error", err))
+		}
+	}
+}
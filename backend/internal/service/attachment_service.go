@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/storage"
+	"unicode/utf8"
+)
+
+// sniffLen — столько первых байт анализирует http.DetectContentType.
+const sniffLen = 512
+
+const maxFileNameLength = 255
+
+// AttachmentOwner — тендер или предложение, к которому относятся вложения.
+type AttachmentOwner struct {
+	Kind string
+	ID   string
+}
+
+// AttachmentService хранит файлы во внешнем хранилище, а метаданные — в базе.
+// Права повторяют права на владельца: вложения опубликованного тендера видны всем,
+// остальные — ответственным за организацию; вложения предложения — его автору и
+// ответственным за тендер (у запечатанного тендера — только автору до закрытия).
+type AttachmentService struct {
+	Repo         *repository.PostgresRepository
+	store        storage.Storage
+	maxSize      int64
+	allowedTypes []string
+}
+
+func NewAttachmentService(repo *repository.PostgresRepository, store storage.Storage, maxSize int64, allowedTypes []string) *AttachmentService {
+	return &AttachmentService{Repo: repo, store: store, maxSize: maxSize, allowedTypes: allowedTypes}
+}
+
+// MaxSize — наибольший допустимый размер одного вложения в байтах.
+func (s *AttachmentService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Upload сохраняет файл: считает SHA-256 и размер, определяет тип по содержимому
+// и только после записи в хранилище добавляет метаданные.
+func (s *AttachmentService) Upload(ctx context.Context, owner AttachmentOwner, username, fileName string, body io.Reader) (*domain.Attachment, error) {
+	if err := s.authorize(ctx, owner, username, true); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer attachment: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(body, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if size > s.maxSize {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrAttachmentTooLarge, s.maxSize)
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := tmp.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !s.typeAllowed(contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentType, contentType)
+	}
+
+	key, err := attachmentKey(owner)
+	if err != nil {
+		return nil, err
+	}
+	attachment := &domain.Attachment{
+		FileName:    cleanFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		UploadedBy:  username,
+		StorageKey:  key,
+	}
+	if owner.Kind == domain.AttachmentOwnerTender {
+		attachment.TenderID = &owner.ID
+	} else {
+		attachment.BidID = &owner.ID
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	info := storage.ObjectInfo{Size: size, ContentType: contentType, SHA256: attachment.SHA256}
+	if err := s.store.Put(ctx, key, tmp, info); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if err := s.Repo.InsertAttachment(ctx, attachment); err != nil {
+		if delErr := s.store.Delete(context.WithoutCancel(ctx), key); delErr != nil {
+			slog.ErrorContext(ctx, "failed to remove orphaned attachment", slog.String("key", key), slog.Any("error", delErr))
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (s *AttachmentService) List(ctx context.Context, owner AttachmentOwner, username string) ([]*domain.Attachment, error) {
+	if err := s.authorize(ctx, owner, username, false); err != nil {
+		return nil, err
+	}
+	return s.Repo.ListAttachments(ctx, owner.Kind, owner.ID)
+}
+
+// Open возвращает метаданные и содержимое вложения; вызывающий закрывает reader.
+func (s *AttachmentService) Open(ctx context.Context, owner AttachmentOwner, attachmentID, username string) (*domain.Attachment, io.ReadCloser, error) {
+	if err := s.authorize(ctx, owner, username, false); err != nil {
+		return nil, nil, err
+	}
+	attachment, err := s.Repo.GetAttachment(ctx, owner.Kind, owner.ID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			slog.ErrorContext(ctx, "attachment content is missing", slog.String("attachment_id", attachmentID), slog.String("key", attachment.StorageKey))
+			return nil, nil, repository.ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+	return attachment, body, nil
+}
+
+// Delete удаляет метаданные, затем содержимое; ошибка хранилища только логируется.
+func (s *AttachmentService) Delete(ctx context.Context, owner AttachmentOwner, attachmentID, username string) error {
+	if err := s.authorize(ctx, owner, username, true); err != nil {
+		return err
+	}
+	attachment, err := s.Repo.GetAttachment(ctx, owner.Kind, owner.ID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.Repo.DeleteAttachment(ctx, attachment.ID); err != nil {
+		return err
+	}
+	if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+		slog.ErrorContext(ctx, "failed to delete attachment content", slog.String("key", attachment.StorageKey), slog.Any("error", err))
+	}
+	return nil
+}
+
+func (s *AttachmentService) authorize(ctx context.Context, owner AttachmentOwner, username string, write bool) error {
+	switch owner.Kind {
+	case domain.AttachmentOwnerTender:
+		return s.authorizeTender(ctx, owner.ID, username, write)
+	case domain.AttachmentOwnerBid:
+		return s.authorizeBid(ctx, owner.ID, username, write)
+	}
+	return fmt.Errorf("unknown attachment owner %q", owner.Kind)
+}
+
+func (s *AttachmentService) authorizeTender(ctx context.Context, tenderID, username string, write bool) error {
+	tender, err := s.Repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+	if !write && tender.Status == domain.TenderStatusPublished {
+		return nil
+	}
+	if username == "" {
+		return ErrAttachmentForbidden
+	}
+	isResponsible, err := s.Repo.IsUserResponsibleForOrganization(ctx, username, tender.OrganizationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUnknownUser
+		}
+		return err
+	}
+	if !isResponsible {
+		return ErrAttachmentForbidden
+	}
+	return nil
+}
+
+func (s *AttachmentService) authorizeBid(ctx context.Context, bidID, username string, write bool) error {
+	bid, err := s.Repo.GetBidByID(ctx, bidID)
+	if err != nil {
+		return err
+	}
+	if username == "" {
+		return ErrAttachmentForbidden
+	}
+	userID, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUnknownUser
+		}
+		return err
+	}
+
+	if userID != bid.AuthorID {
+		hasAccess, err := s.Repo.UserHasAccessToBid(ctx, username, bidID)
+		if err != nil {
+			return err
+		}
+		if !hasAccess {
+			return ErrAttachmentForbidden
+		}
+		if bid.Sealed() {
+			return ErrBidsSealed
+		}
+	}
+
+	if write {
+		passed, err := s.Repo.IsTenderDeadlinePassed(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+		if passed {
+			return ErrSubmissionDeadlinePassed
+		}
+	}
+	return nil
+}
+
+func (s *AttachmentService) typeAllowed(contentType string) bool {
+	if len(s.allowedTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range s.allowedTypes {
+		if t, _, err := mime.ParseMediaType(allowed); err == nil && t == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// attachmentKey — случайный ключ в хранилище; имя файла в него не попадает.
+func attachmentKey(owner AttachmentOwner) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	return owner.Kind + "s/" + owner.ID + "/" + hex.EncodeToString(b), nil
+}
+
+// cleanFileName оставляет только базовое имя файла без управляющих символов.
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	for utf8.RuneCountInString(name) > maxFileNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
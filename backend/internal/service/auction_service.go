@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"time"
+)
+
+// AuctionService ведет реверсивные аукционы: участники снижают цену, пока аукцион идет,
+// а ставка в последние секунды продлевает его (anti-sniping).
+type AuctionService struct {
+	Repo       *repository.PostgresRepository
+	currencies []string
+}
+
+func NewAuctionService(repo *repository.PostgresRepository, currencies []string) *AuctionService {
+	return &AuctionService{Repo: repo, currencies: currencies}
+}
+
+// AuctionRequest — настройки аукциона. Суммы в минимальных единицах валюты, длительности в секундах.
+type AuctionRequest struct {
+	StartsAt               time.Time `json:"startsAt"`
+	DurationSeconds        int       `json:"durationSeconds"`
+	Currency               string    `json:"currency"`
+	StartPrice             *int64    `json:"startPrice,omitempty"`
+	MinDecrement           int64     `json:"minDecrement"`
+	ExtensionWindowSeconds int       `json:"extensionWindowSeconds"`
+	ExtensionSeconds       int       `json:"extensionSeconds"`
+}
+
+// AuctionBidResult — ответ на ставку: место участника и актуальное окончание аукциона.
+type AuctionBidResult struct {
+	Bid      *domain.AuctionBid `json:"bid"`
+	Rank     int                `json:"rank"`
+	EndsAt   time.Time          `json:"endsAt"`
+	Extended bool               `json:"extended"`
+}
+
+// AuctionStanding — рейтинг для участника: только его собственное место.
+// Ответственные за организацию тендера получают полный рейтинг в Ranking.
+type AuctionStanding struct {
+	Auction      *domain.Auction      `json:"auction"`
+	Participants int                  `json:"participants"`
+	Rank         *int                 `json:"rank,omitempty"`
+	OwnBest      *int64               `json:"ownBestAmount,omitempty"`
+	Ranking      []domain.AuctionRank `json:"ranking,omitempty"`
+}
+
+// ConfigureAuction включает для тендера режим аукциона или меняет его настройки до старта.
+func (s *AuctionService) ConfigureAuction(ctx context.Context, tenderID, username string, req AuctionRequest) (*domain.Auction, error) {
+	if err := s.validate(req); err != nil {
+		return nil, err
+	}
+
+	tender, err := s.Repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	isResponsible, err := s.Repo.IsUserResponsibleForOrganization(ctx, username, tender.OrganizationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, ErrNotResponsible
+	}
+	// Рейтинг аукциона открыт организации с первой ставки, а у запечатанного
+	// тендера до закрытия она видит только количество предложений
+	if tender.Sealed {
+		return nil, ErrAuctionSealed
+	}
+
+	auction := &domain.Auction{
+		TenderID:               tenderID,
+		StartsAt:               req.StartsAt,
+		DurationSeconds:        req.DurationSeconds,
+		Currency:               req.Currency,
+		StartPrice:             req.StartPrice,
+		MinDecrement:           req.MinDecrement,
+		ExtensionWindowSeconds: req.ExtensionWindowSeconds,
+		ExtensionSeconds:       req.ExtensionSeconds,
+	}
+	saved, err := s.Repo.UpsertAuction(ctx, auction)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, ErrAuctionStarted
+	}
+	auction.Status = auction.StatusAt(time.Now())
+	return auction, nil
+}
+
+func (s *AuctionService) validate(req AuctionRequest) error {
+	switch {
+	case !req.StartsAt.After(time.Now()):
+		return fmt.Errorf("%w: startsAt must be in the future", ErrInvalidAuction)
+	case req.DurationSeconds <= 0:
+		return fmt.Errorf("%w: durationSeconds must be positive", ErrInvalidAuction)
+	case !containsString(s.currencies, req.Currency):
+		return fmt.Errorf("%w: unsupported currency %q", ErrInvalidAuction, req.Currency)
+	case req.StartPrice != nil && *req.StartPrice <= 0:
+		return fmt.Errorf("%w: startPrice must be positive", ErrInvalidAuction)
+	case req.MinDecrement <= 0:
+		return fmt.Errorf("%w: minDecrement must be positive", ErrInvalidAuction)
+	case req.ExtensionWindowSeconds < 0 || req.ExtensionSeconds < 0:
+		return fmt.Errorf("%w: extension settings must not be negative", ErrInvalidAuction)
+	case (req.ExtensionWindowSeconds == 0) != (req.ExtensionSeconds == 0):
+		return fmt.Errorf("%w: extensionWindowSeconds and extensionSeconds must be set together", ErrInvalidAuction)
+	}
+	return nil
+}
+
+func (s *AuctionService) GetAuction(ctx context.Context, tenderID string) (*domain.Auction, error) {
+	return s.Repo.GetAuction(ctx, tenderID)
+}
+
+// PlaceBid принимает ставку участника. Проверка цены, времени и продление выполняются
+// под блокировкой аукциона, поэтому одновременные ставки не могут нарушить правила.
+func (s *AuctionService) PlaceBid(ctx context.Context, tenderID, username string, amount int64) (*AuctionBidResult, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrAuctionBidRejected)
+	}
+
+	userID, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+	status, err := s.Repo.GetTenderStatus(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if status != domain.TenderStatusPublished {
+		return nil, ErrAuctionNotRunning
+	}
+	// Организация тендера не торгуется сама с собой
+	inOrganization, err := s.Repo.IsUserInTenderOrganization(ctx, username, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if inOrganization {
+		return nil, ErrAuctionForbidden
+	}
+
+	var extended bool
+	auction, bid, err := s.Repo.PlaceAuctionBid(ctx, tenderID, userID, amount, func(a *domain.Auction, now time.Time) error {
+		if a.StatusAt(now) != domain.AuctionStatusRunning {
+			return ErrAuctionNotRunning
+		}
+		if limit := maxAllowedBid(a); limit != nil && amount > *limit {
+			return fmt.Errorf("%w: amount must not exceed %d", ErrAuctionBidRejected, *limit)
+		}
+		a.BestAmount = &amount
+
+		window := time.Duration(a.ExtensionWindowSeconds) * time.Second
+		if window > 0 && a.EndsAt.Sub(now) <= window {
+			if ends := now.Add(time.Duration(a.ExtensionSeconds) * time.Second); ends.After(a.EndsAt) {
+				a.EndsAt = ends
+				a.Extensions++
+				extended = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AuctionBidResult{Bid: bid, EndsAt: auction.EndsAt, Extended: extended}
+	ranking, err := s.Repo.AuctionRanking(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if own := findRank(ranking, userID); own != nil {
+		result.Rank = own.Rank
+	}
+	return result, nil
+}
+
+// maxAllowedBid — наибольшая допустимая следующая ставка: лучшая минус шаг,
+// а до первой ставки — начальная цена (без нее ограничения нет).
+func maxAllowedBid(a *domain.Auction) *int64 {
+	if a.BestAmount != nil {
+		limit := *a.BestAmount - a.MinDecrement
+		return &limit
+	}
+	return a.StartPrice
+}
+
+// Standing возвращает ответственным за организацию полный рейтинг,
+// а остальным — только их собственное место среди участников.
+func (s *AuctionService) Standing(ctx context.Context, tenderID, username string) (*AuctionStanding, error) {
+	auction, err := s.Repo.GetAuction(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+	ranking, err := s.Repo.AuctionRanking(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	standing := &AuctionStanding{Auction: auction, Participants: len(ranking)}
+	inOrganization, err := s.Repo.IsUserInTenderOrganization(ctx, username, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if inOrganization {
+		standing.Ranking = ranking
+		return standing, nil
+	}
+	if own := findRank(ranking, userID); own != nil {
+		standing.Rank = &own.Rank
+		standing.OwnBest = &own.BestAmount
+	}
+	return standing, nil
+}
+
+func findRank(ranking []domain.AuctionRank, authorID string) *domain.AuctionRank {
+	for i := range ranking {
+		if ranking[i].AuthorID == authorID {
+			return &ranking[i]
+		}
+	}
+	return nil
+}
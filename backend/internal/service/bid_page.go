@@ -0,0 +1,93 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"time"
+)
+
+const (
+	DefaultBidPageLimit = 50
+	MaxBidPageLimit     = 200
+)
+
+// BidPageRequest — страница списка предложений: Limit предложений после курсора
+// Cursor из NextCursor предыдущей страницы. Limit 0 отдает весь список.
+type BidPageRequest struct {
+	Cursor string
+	Limit  int
+}
+
+// BidPage — страница предложений; NextCursor пуст на последней странице.
+// Новые предложения попадают в начало списка и не сдвигают следующие страницы.
+type BidPage struct {
+	Bids       []*domain.Bid `json:"bids"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+// bidCursor — содержимое курсора. Клиенту он непрозрачен; порядок сортировки
+// записан в курсор, чтобы его нельзя было продолжить в другом порядке.
+type bidCursor struct {
+	Sort      string    `json:"s"`
+	Amount    *int64    `json:"a,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+}
+
+func encodeBidCursor(b *domain.Bid, sort string) string {
+	c := bidCursor{Sort: sort, CreatedAt: b.CreatedAt, ID: b.ID}
+	if sort == repository.BidSortPriceAsc || sort == repository.BidSortPriceDesc {
+		c.Amount = b.Amount
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeBidCursor(raw, sort string) (*repository.BidCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidBidPage)
+	}
+	var c bidCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" || c.CreatedAt.IsZero() {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidBidPage)
+	}
+	if c.Sort != sort {
+		return nil, fmt.Errorf("%w: cursor was issued for sort %q", ErrInvalidBidPage, c.Sort)
+	}
+	return &repository.BidCursor{Amount: c.Amount, CreatedAt: c.CreatedAt, ID: c.ID}, nil
+}
+
+// bidPageQuery проверяет страницу и возвращает курсор и лимит для запроса к базе:
+// на одно предложение больше, чтобы узнать, есть ли следующая страница.
+func bidPageQuery(page BidPageRequest, sort string) (*repository.BidCursor, int, error) {
+	if page.Limit < 0 || page.Limit > MaxBidPageLimit {
+		return nil, 0, fmt.Errorf("%w: limit must be 1 to %d", ErrInvalidBidPage, MaxBidPageLimit)
+	}
+	var after *repository.BidCursor
+	if page.Cursor != "" {
+		var err error
+		if after, err = decodeBidCursor(page.Cursor, sort); err != nil {
+			return nil, 0, err
+		}
+	}
+	if page.Limit == 0 {
+		return after, 0, nil
+	}
+	return after, page.Limit + 1, nil
+}
+
+func newBidPage(bids []*domain.Bid, limit int, sort string) *BidPage {
+	page := &BidPage{Bids: bids}
+	if limit > 0 && len(bids) > limit {
+		page.Bids = bids[:limit]
+		page.NextCursor = encodeBidCursor(page.Bids[limit-1], sort)
+	}
+	if page.Bids == nil {
+		page.Bids = []*domain.Bid{}
+	}
+	return page
+}
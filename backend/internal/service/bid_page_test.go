@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"testing"
+	"time"
+)
+
+func TestBidCursorRoundTrip(t *testing.T) {
+	amount := int64(150000)
+	bid := &domain.Bid{
+		ID:        "5f0c0c3e-0000-4000-8000-000000000001",
+		CreatedAt: time.Date(2024, 3, 1, 10, 30, 0, 123456000, time.FixedZone("MSK", 3*60*60)),
+		Amount:    &amount,
+	}
+
+	after, err := decodeBidCursor(encodeBidCursor(bid, repository.BidSortPriceAsc), repository.BidSortPriceAsc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.ID != bid.ID || !after.CreatedAt.Equal(bid.CreatedAt) || after.Amount == nil || *after.Amount != amount {
+		t.Fatalf("cursor = %+v, want the key of %+v", after, bid)
+	}
+
+	// В порядке по дате цена в курсор не попадает
+	after, err = decodeBidCursor(encodeBidCursor(bid, repository.BidSortCreated), repository.BidSortCreated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Amount != nil {
+		t.Errorf("created cursor carries amount %d", *after.Amount)
+	}
+
+	for _, tc := range []struct{ raw, sort string }{
+		{encodeBidCursor(bid, repository.BidSortCreated), repository.BidSortPriceDesc},
+		{"not-a-cursor", repository.BidSortCreated},
+		{"e30", repository.BidSortCreated}, // {}
+	} {
+		if _, err := decodeBidCursor(tc.raw, tc.sort); !errors.Is(err, ErrInvalidBidPage) {
+			t.Errorf("decodeBidCursor(%q, %s) err = %v, want ErrInvalidBidPage", tc.raw, tc.sort, err)
+		}
+	}
+}
+
+func TestNewBidPage(t *testing.T) {
+	now := time.Now()
+	bids := []*domain.Bid{{ID: "a", CreatedAt: now}, {ID: "b", CreatedAt: now.Add(-time.Second)}, {ID: "c", CreatedAt: now.Add(-time.Minute)}}
+
+	page := newBidPage(bids, 2, repository.BidSortCreated)
+	if len(page.Bids) != 2 || page.NextCursor == "" {
+		t.Fatalf("page = %+v, want 2 bids and a cursor", page)
+	}
+	after, err := decodeBidCursor(page.NextCursor, repository.BidSortCreated)
+	if err != nil || after.ID != "b" {
+		t.Fatalf("next cursor = %+v, %v; want the key of the last bid on the page", after, err)
+	}
+
+	if page := newBidPage(bids, 3, repository.BidSortCreated); len(page.Bids) != 3 || page.NextCursor != "" {
+		t.Errorf("last page = %+v, want 3 bids without a cursor", page)
+	}
+	if page := newBidPage(nil, 2, repository.BidSortCreated); page.Bids == nil {
+		t.Error("empty page encodes bids as null")
+	}
+}
+
+func TestBidPageQuery(t *testing.T) {
+	if _, limit, err := bidPageQuery(BidPageRequest{Limit: 10}, repository.BidSortCreated); err != nil || limit != 11 {
+		t.Errorf("limit = %d, err = %v; want one extra row to detect the next page", limit, err)
+	}
+	if _, limit, err := bidPageQuery(BidPageRequest{}, repository.BidSortCreated); err != nil || limit != 0 {
+		t.Errorf("limit = %d, err = %v; want the whole list", limit, err)
+	}
+	if _, _, err := bidPageQuery(BidPageRequest{Limit: MaxBidPageLimit + 1}, repository.BidSortCreated); !errors.Is(err, ErrInvalidBidPage) {
+		t.Errorf("err = %v, want ErrInvalidBidPage", err)
+	}
+}
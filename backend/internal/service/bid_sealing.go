@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"tender_srevice/internal/sealing"
+	"time"
+)
+
+// SealedBids — то, что организация видит по запечатанному тендеру до его закрытия.
+type SealedBids struct {
+	Sealed      bool        `json:"sealed"`
+	Count       int         `json:"count"`
+	SubmittedAt []time.Time `json:"submittedAt"`
+}
+
+// BidSealing шифрует содержимое предложений к запечатанным тендерам и вскрывает
+// его после закрытия тендера. Без ключа запечатанные тендеры создавать нельзя.
+type BidSealing struct {
+	repo   *repository.PostgresRepository
+	sealer *sealing.Sealer
+}
+
+// NewBidSealing принимает nil вместо sealer, если ключ не настроен.
+func NewBidSealing(repo *repository.PostgresRepository, sealer *sealing.Sealer) *BidSealing {
+	return &BidSealing{repo: repo, sealer: sealer}
+}
+
+func (s *BidSealing) Enabled() bool {
+	return s.sealer != nil
+}
+
+// seal переносит содержимое предложения в SealedPayload и очищает открытые поля.
+// Шифртекст привязан к тендеру и не расшифруется в составе другого.
+func (s *BidSealing) seal(bid *domain.Bid) error {
+	if s.sealer == nil {
+		return ErrSealingUnavailable
+	}
+	plaintext, err := json.Marshal(bid.Content())
+	if err != nil {
+		return fmt.Errorf("failed to encode bid content: %w", err)
+	}
+	payload, err := s.sealer.Seal(plaintext, []byte(bid.TenderID))
+	if err != nil {
+		return fmt.Errorf("failed to seal bid: %w", err)
+	}
+	bid.SetContent(domain.BidContent{})
+	bid.SealedPayload = payload
+	return nil
+}
+
+// Reveal расшифровывает запечатанное предложение в памяти, не меняя его в базе.
+func (s *BidSealing) Reveal(bid *domain.Bid) error {
+	if !bid.Sealed() {
+		return nil
+	}
+	c, err := s.open(bid.TenderID)(bid.SealedPayload)
+	if err != nil {
+		return err
+	}
+	bid.SetContent(c)
+	return nil
+}
+
+func (s *BidSealing) open(tenderID string) func([]byte) (domain.BidContent, error) {
+	return func(payload []byte) (domain.BidContent, error) {
+		var c domain.BidContent
+		if s.sealer == nil {
+			return c, ErrSealingUnavailable
+		}
+		plaintext, err := s.sealer.Open(payload, []byte(tenderID))
+		if err != nil {
+			return c, err
+		}
+		if err := json.Unmarshal(plaintext, &c); err != nil {
+			return c, fmt.Errorf("failed to decode bid content: %w", err)
+		}
+		return c, nil
+	}
+}
+
+// UnsealTender вскрывает все предложения закрытого тендера и записывает событие
+// вскрытия от имени unsealedBy. Для открытого тендера возвращает ErrBidsSealed.
+func (s *BidSealing) UnsealTender(ctx context.Context, tenderID, unsealedBy, reason string) (int, error) {
+	status, err := s.repo.GetTenderStatus(ctx, tenderID)
+	if err != nil {
+		return 0, err
+	}
+	if status != domain.TenderStatusClosed {
+		return 0, ErrBidsSealed
+	}
+
+	sealed, err := s.repo.HasSealedBids(ctx, tenderID)
+	if err != nil || !sealed {
+		return 0, err
+	}
+
+	n, err := s.repo.UnsealBids(ctx, tenderID, unsealedBy, reason, s.open(tenderID))
+	if err != nil {
+		return 0, err
+	}
+	slog.InfoContext(ctx, "sealed bids unsealed",
+		slog.String("tender_id", tenderID), slog.Int("bids", n), slog.String("unsealed_by", unsealedBy), slog.String("reason", reason))
+	return n, nil
+}
+
+// visibleBids решает, что организация может увидеть по тендеру: для открытого
+// запечатанного тендера возвращает сводку, для закрытого — вскрывает предложения,
+// если этого еще не сделали при закрытии.
+func (s *BidSealing) visibleBids(ctx context.Context, tenderID, username string) (*SealedBids, error) {
+	tender, err := s.repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if !tender.Sealed {
+		return nil, nil
+	}
+
+	if tender.Status != domain.TenderStatusClosed {
+		submissions, err := s.repo.SealedBidSubmissions(ctx, tenderID)
+		if err != nil {
+			return nil, err
+		}
+		return &SealedBids{Sealed: true, Count: len(submissions), SubmittedAt: submissions}, nil
+	}
+
+	if _, err := s.UnsealTender(ctx, tenderID, username, UnsealOnAccessReason); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+const (
+	// UnsealOnCloseReason записывается, когда ответственный закрывает тендер вручную.
+	UnsealOnCloseReason = "tender closed"
+	// UnsealOnAccessReason — запасной путь: тендер закрыт, но вскрытие при закрытии не удалось.
+	UnsealOnAccessReason = "first access after close"
+)
@@ -2,18 +2,36 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
 	"tender_srevice/internal/domain"
 	"tender_srevice/internal/repository"
-	
+	"time"
 )
 
 type BidService struct {
-	Repo *repository.PostgresRepository
+	Repo          *repository.PostgresRepository
+	currencies    []string
+	sealing       *BidSealing
+	notifications *NotificationService
 }
 
-func NewBidService(repo *repository.PostgresRepository) *BidService {
-	return &BidService{Repo: repo}
+// NewBidService принимает список допустимых валют цены предложения (config.BidsConfig),
+// шифрование предложений к запечатанным тендерам и источник уведомлений о событиях предложений.
+func NewBidService(repo *repository.PostgresRepository, currencies []string, sealing *BidSealing, notifications *NotificationService) *BidService {
+	return &BidService{Repo: repo, currencies: currencies, sealing: sealing, notifications: notifications}
+}
+
+// BidTerms — коммерческие условия предложения. Amount указывается в минимальных
+// единицах валюты (копейках, центах), Currency обязательна вместе с Amount.
+type BidTerms struct {
+	Amount       *int64     `json:"amount,omitempty"`
+	Currency     *string    `json:"currency,omitempty"`
+	DeliveryDays *int       `json:"deliveryDays,omitempty"`
+	ValidFrom    *time.Time `json:"validFrom,omitempty"`
+	ValidUntil   *time.Time `json:"validUntil,omitempty"`
 }
 
 type CreateBidRequest struct {
@@ -22,9 +40,17 @@ type CreateBidRequest struct {
 	TenderID    string
 	AuthorType  string
 	AuthorID    string
+	BidTerms
 }
 
 func (s *BidService) CreateBid(ctx context.Context, req CreateBidRequest) (*domain.Bid, error) {
+	if err := s.validateTerms(req.BidTerms); err != nil {
+		return nil, err
+	}
+	if err := s.checkSubmissionOpen(ctx, req.TenderID); err != nil {
+		return nil, err
+	}
+
 	newBid := &domain.Bid{
 		Name:        req.Name,
 		Description: req.Description,
@@ -33,29 +59,95 @@ func (s *BidService) CreateBid(ctx context.Context, req CreateBidRequest) (*doma
 		AuthorType:  req.AuthorType,
 		AuthorID:    req.AuthorID,
 	}
+	req.BidTerms.apply(newBid)
 
-	err := s.Repo.InsertBid(ctx, newBid)
+	tender, err := s.Repo.GetTenderByID(ctx, req.TenderID)
 	if err != nil {
 		return nil, err
 	}
+	sealed := tender.Sealed && tender.Status != domain.TenderStatusClosed
+	if sealed {
+		if err := s.sealing.seal(newBid); err != nil {
+			return nil, err
+		}
+	}
 
+	err = s.Repo.InsertBid(ctx, newBid)
+	if err != nil {
+		return nil, err
+	}
+	s.notifications.bidCreated(ctx, tender, newBid)
+
+	// Автор видит свое предложение целиком
+	if sealed {
+		if err := s.sealing.Reveal(newBid); err != nil {
+			return nil, err
+		}
+	}
 	return newBid, nil
 }
 
-func (s *BidService) GetBidsByTenderID(ctx context.Context, tenderID, username string) ([]*domain.Bid, error) {
+// GetBidsByTenderID возвращает либо страницу предложений, либо — для открытого
+// запечатанного тендера — только их количество и время подачи.
+func (s *BidService) GetBidsByTenderID(ctx context.Context, tenderID, username string, filter repository.BidFilter, page BidPageRequest) (*BidPage, *SealedBids, error) {
+	sort := filter.Sort
+	if sort == "" {
+		sort = repository.BidSortCreated
+	}
+	after, limit, err := bidPageQuery(page, sort)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkTenderBidsAccess(ctx, tenderID, username); err != nil {
+		return nil, nil, err
+	}
+
+	summary, err := s.sealing.visibleBids(ctx, tenderID, username)
+	if err != nil || summary != nil {
+		return nil, summary, err
+	}
+
+	filter.After, filter.Limit = after, limit
+	bids, err := s.Repo.GetBidsByTenderID(ctx, tenderID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newBidPage(bids, page.Limit, sort), nil, nil
+}
+
+// ExportBidsByTenderID — потоковый вариант GetBidsByTenderID с теми же проверками доступа.
+func (s *BidService) ExportBidsByTenderID(ctx context.Context, tenderID, username string, filter repository.BidFilter, fn func(*domain.Bid) error) error {
+	if err := s.checkTenderBidsAccess(ctx, tenderID, username); err != nil {
+		return err
+	}
+
+	summary, err := s.sealing.visibleBids(ctx, tenderID, username)
+	if err != nil {
+		return err
+	}
+	if summary != nil {
+		return ErrBidsSealed
+	}
+
+	return s.Repo.StreamBidsByTenderID(ctx, tenderID, filter, fn)
+}
+
+func (s *BidService) checkTenderBidsAccess(ctx context.Context, tenderID, username string) error {
 	// Проверяем, принадлежит ли пользователь к организации тендера
-	
 	isAllowed, err := s.Repo.IsUserInTenderOrganization(ctx, username, tenderID)
 	if err != nil {
-		fmt.Println("Error checking user permissions:", err)
-		return nil, fmt.Errorf("failed to check user permissions: %w", err)
+		slog.ErrorContext(ctx, "failed to check user permissions", slog.String("tender_id", tenderID), slog.Any("error", err))
+		return fmt.Errorf("failed to check user permissions: %w", err)
 
 	}
 	if !isAllowed {
-		return nil, fmt.Errorf("user is not authorized to view bids for this tender")
+		// Для несуществующего тендера проверка тоже отказывает; отвечаем 404, а не 403
+		if _, err := s.Repo.GetTenderByID(ctx, tenderID); err != nil {
+			return err
+		}
+		return fmt.Errorf("user is not authorized to view bids for this tender")
 	}
-
-	return s.Repo.GetBidsByTenderID(ctx, tenderID)
+	return nil
 }
 
 func (s *BidService) UpdateBidStatus(ctx context.Context, bidID, username, newStatus string) error {
@@ -68,7 +160,7 @@ func (s *BidService) UpdateBidStatus(ctx context.Context, bidID, username, newSt
 	// Проверяем, принадлежит ли пользователь к организации тендера
 	isAllowed, err := s.Repo.IsUserInTenderOrganization(ctx, username, bid.TenderID)
 	if err != nil {
-		fmt.Println("Error checking user permissions:", err)
+		slog.ErrorContext(ctx, "failed to check user permissions", slog.String("bid_id", bidID), slog.Any("error", err))
 		return fmt.Errorf("failed to check user permissions: %w", err)
 
 	}
@@ -81,12 +173,31 @@ func (s *BidService) UpdateBidStatus(ctx context.Context, bidID, username, newSt
 	if err != nil {
 		return fmt.Errorf("ошибка при обновлении статуса заявки: %w", err)
 	}
+	if bid.Status != newStatus {
+		previousStatus := bid.Status
+		bid.Status = newStatus
+		s.notifications.bidStatusChanged(ctx, bid, previousStatus, username)
+	}
 
 	return nil
 }
 
-func (s *BidService) GetBidsByAuthorID(ctx context.Context, authorID string) ([]*domain.Bid, error) {
-	return s.Repo.GetBidsByAuthorID(ctx, authorID)
+// GetBidsByAuthorID показывает автору его запечатанные предложения расшифрованными.
+func (s *BidService) GetBidsByAuthorID(ctx context.Context, authorID string, page BidPageRequest) (*BidPage, error) {
+	after, limit, err := bidPageQuery(page, repository.BidSortCreated)
+	if err != nil {
+		return nil, err
+	}
+	bids, err := s.Repo.GetBidsByAuthorID(ctx, authorID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bids {
+		if err := s.sealing.Reveal(b); err != nil {
+			return nil, err
+		}
+	}
+	return newBidPage(bids, page.Limit, repository.BidSortCreated), nil
 }
 
 func (s *BidService) GetBidStatus(ctx context.Context, bidID, username string) (string, error) {
@@ -108,26 +219,64 @@ func (s *BidService) GetBidStatus(ctx context.Context, bidID, username string) (
 	return bid.Status, nil
 }
 
-func (s *BidService) EditBid(ctx context.Context, bidID, username, name, description string) error {
+// EditBid заменяет название, описание и коммерческие условия заявки целиком.
+func (s *BidService) EditBid(ctx context.Context, bidID, username, name, description string, terms BidTerms) error {
+	if err := s.validateTerms(terms); err != nil {
+		return err
+	}
+
+
 	// Проверяем, существует ли заявка
-	fmt.Println("bidID:", bidID)
 	bid, err := s.Repo.GetBidByID(ctx, bidID)
 	if err != nil {
 		return fmt.Errorf("не удалось получить заявку: %w", err)
 	}
 
-	// Проверяем, имеет ли пользователь право редактировать эту заявку
-	hasAccess, err := s.Repo.UserHasAccessToBid(ctx, username, bidID)
-	if err != nil {
-		return fmt.Errorf("не удалось проверить права доступа пользователя: %w", err)
+	// Запечатанную заявку до закрытия тендера правит только ее автор: организация
+	// не видит содержимое и не может его подменить
+	sealed := bid.Sealed()
+	isAuthor := false
+	if sealed {
+		userID, err := s.Repo.GetUserIDByUsername(ctx, username)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("не удалось проверить права доступа пользователя: %w", err)
+		}
+		isAuthor = err == nil && userID == bid.AuthorID
 	}
-	if !hasAccess {
-		return fmt.Errorf("пользователь не имеет прав на редактирование этой заявки")
+
+	if !isAuthor {
+		// Проверяем, имеет ли пользователь право редактировать эту заявку
+		hasAccess, err := s.Repo.UserHasAccessToBid(ctx, username, bidID)
+		if err != nil {
+			return fmt.Errorf("не удалось проверить права доступа пользователя: %w", err)
+		}
+		if !hasAccess {
+			return fmt.Errorf("пользователь не имеет прав на редактирование этой заявки")
+		}
+		if sealed {
+			return ErrBidsSealed
+		}
+	}
+
+	if err := s.checkSubmissionOpen(ctx, bid.TenderID); err != nil {
+		return err
+	}
+
+	// Запечатанную заявку расшифровываем, правим и шифруем заново
+	if err := s.sealing.Reveal(bid); err != nil {
+		return err
 	}
 
 	// Обновляем данные заявки
 	bid.Name = name
 	bid.Description = description
+	terms.apply(bid)
+
+	if sealed {
+		if err := s.sealing.seal(bid); err != nil {
+			return err
+		}
+	}
 
 	// Сохраняем обновленную заявку в репозитории
 	err = s.Repo.UpdateBid(ctx, bid)
@@ -136,4 +285,44 @@ func (s *BidService) EditBid(ctx context.Context, bidID, username, name, descrip
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// checkSubmissionOpen запрещает подачу и правку предложений после срока подачи тендера.
+func (s *BidService) checkSubmissionOpen(ctx context.Context, tenderID string) error {
+	passed, err := s.Repo.IsTenderDeadlinePassed(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+	if passed {
+		return ErrSubmissionDeadlinePassed
+	}
+	return nil
+}
+
+// validateTerms проверяет условия предложения и валюту по списку из конфигурации.
+func (s *BidService) validateTerms(t BidTerms) error {
+	if t.Amount != nil && *t.Amount < 0 {
+		return fmt.Errorf("%w: amount must not be negative", ErrInvalidBidTerms)
+	}
+	if t.Amount != nil && t.Currency == nil {
+		return fmt.Errorf("%w: currency is required with amount", ErrInvalidBidTerms)
+	}
+	if t.Currency != nil && !containsString(s.currencies, *t.Currency) {
+		return fmt.Errorf("%w: unsupported currency %q", ErrInvalidBidTerms, *t.Currency)
+	}
+	if t.DeliveryDays != nil && *t.DeliveryDays < 0 {
+		return fmt.Errorf("%w: deliveryDays must not be negative", ErrInvalidBidTerms)
+	}
+	if t.ValidFrom != nil && t.ValidUntil != nil && !t.ValidUntil.After(*t.ValidFrom) {
+		return fmt.Errorf("%w: validUntil must be after validFrom", ErrInvalidBidTerms)
+	}
+	return nil
+}
+
+func (t BidTerms) apply(b *domain.Bid) {
+	b.Amount = t.Amount
+	b.Currency = t.Currency
+	b.DeliveryDays = t.DeliveryDays
+	b.ValidFrom = t.ValidFrom
+	b.ValidUntil = t.ValidUntil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"tender_srevice/internal/domain"
+)
+
+const maxWithdrawalReasonLength = 1000
+
+// WithdrawBid отзывает предложение от имени его автора с обязательной причиной.
+func (s *BidService) WithdrawBid(ctx context.Context, bidID, username, reason string) (*domain.Bid, error) {
+	bid, reason, err := s.authorAction(ctx, bidID, username, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawn, err := s.Repo.WithdrawBid(ctx, bid, reason)
+	if err != nil {
+		return nil, err
+	}
+	if !withdrawn {
+		return nil, ErrBidAlreadyWithdrawn
+	}
+	return bid, s.sealing.Reveal(bid)
+}
+
+// ResubmitBid возвращает отозванное автором предложение в статус, который был до отзыва.
+// После закрытия тендера и истечения срока подачи повторная подача невозможна.
+func (s *BidService) ResubmitBid(ctx context.Context, bidID, username, reason string) (*domain.Bid, error) {
+	bid, reason, err := s.authorAction(ctx, bidID, username, reason)
+	if err != nil {
+		return nil, err
+	}
+	status, err := s.Repo.GetTenderStatus(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if status == domain.TenderStatusClosed {
+		return nil, ErrBidTenderClosed
+	}
+	if err := s.checkSubmissionOpen(ctx, bid.TenderID); err != nil {
+		return nil, err
+	}
+
+	resubmitted, err := s.Repo.ResubmitBid(ctx, bid, reason)
+	if err != nil {
+		return nil, err
+	}
+	if !resubmitted {
+		return nil, ErrBidNotWithdrawn
+	}
+	return bid, s.sealing.Reveal(bid)
+}
+
+// BidWithdrawals возвращает историю отзывов и повторных подач предложений тендера
+// ответственным за его организацию. У запечатанного тендера история доступна после
+// закрытия: до него она раскрыла бы авторов предложений.
+func (s *BidService) BidWithdrawals(ctx context.Context, tenderID, username string) ([]domain.BidWithdrawal, error) {
+	if _, err := s.Repo.GetTenderByID(ctx, tenderID); err != nil {
+		return nil, err
+	}
+	if _, err := s.Repo.GetUserIDByUsername(ctx, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+	isResponsible, err := s.Repo.IsUserResponsibleForTender(ctx, username, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, ErrNotResponsible
+	}
+
+	summary, err := s.sealing.visibleBids(ctx, tenderID, username)
+	if err != nil {
+		return nil, err
+	}
+	if summary != nil {
+		return nil, ErrBidsSealed
+	}
+	return s.Repo.BidWithdrawals(ctx, tenderID)
+}
+
+// authorAction проверяет причину и то, что username — автор предложения.
+func (s *BidService) authorAction(ctx context.Context, bidID, username, reason string) (*domain.Bid, string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > maxWithdrawalReasonLength {
+		return nil, "", fmt.Errorf("%w: reason must be 1 to %d characters", ErrInvalidWithdrawal, maxWithdrawalReasonLength)
+	}
+	bid, err := s.Repo.GetBidByID(ctx, bidID)
+	if err != nil {
+		return nil, "", err
+	}
+	userID, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrUnknownUser
+		}
+		return nil, "", err
+	}
+	if bid.AuthorID != userID {
+		return nil, "", ErrNotBidAuthor
+	}
+	return bid, reason, nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import "errors"
+
+var (
+	ErrSubmissionDeadlinePassed   = errors.New("submission deadline has passed")
+	ErrDeadlineInPast             = errors.New("submission deadline must be in the future")
+	ErrInvalidBidTerms            = errors.New("invalid bid terms")
+	ErrSealingUnavailable         = errors.New("sealed tenders require bids.sealing_key to be configured")
+	ErrBidsSealed                 = errors.New("bids are sealed until the tender closes")
+	ErrSealedTenderClosed         = errors.New("a closed sealed tender cannot be reopened")
+	ErrUnknownUser                = errors.New("user does not exist")
+	ErrNotResponsible             = errors.New("user is not responsible for the tender organization")
+	ErrInvalidAuction             = errors.New("invalid auction settings")
+	ErrAuctionStarted             = errors.New("auction has already started")
+	ErrAuctionNotRunning          = errors.New("auction is not running")
+	ErrAuctionForbidden           = errors.New("the tender organization cannot bid in its own auction")
+	ErrAuctionBidRejected         = errors.New("auction bid rejected")
+	ErrAuctionSealed              = errors.New("a sealed tender cannot run an auction")
+	ErrAttachmentForbidden        = errors.New("user is not allowed to access these attachments")
+	ErrAttachmentTooLarge         = errors.New("attachment is too large")
+	ErrAttachmentType             = errors.New("attachment type is not allowed")
+	ErrUnknownServiceType         = errors.New("unknown service type")
+	ErrInvalidServiceType         = errors.New("invalid service type")
+	ErrInvalidNotificationRequest = errors.New("invalid notification request")
+	ErrInvalidCriteria            = errors.New("invalid evaluation criteria")
+	ErrCriteriaLocked             = errors.New("criteria cannot change after bids have been scored")
+	ErrInvalidScore               = errors.New("invalid bid score")
+	ErrBidNotPublished            = errors.New("only published bids can be scored")
+	ErrRankingForbidden           = errors.New("user is not allowed to view the ranking of this tender")
+	ErrInvalidQuestion            = errors.New("invalid question request")
+	ErrQuestionsClosed            = errors.New("questions are accepted only on published tenders")
+	ErrQuestionDeadlinePassed     = errors.New("question deadline has passed")
+	ErrQuestionForbidden          = errors.New("the tender organization cannot ask questions on its own tender")
+	ErrInvalidWithdrawal          = errors.New("invalid withdrawal request")
+	ErrNotBidAuthor               = errors.New("only the bid author can withdraw or resubmit the bid")
+	ErrBidAlreadyWithdrawn        = errors.New("bid is already canceled")
+	ErrBidNotWithdrawn            = errors.New("only a bid withdrawn by its author can be resubmitted")
+	ErrBidTenderClosed            = errors.New("tender is closed")
+	ErrInvalidTemplate            = errors.New("invalid tender template request")
+	ErrArchiveActive              = errors.New("published tenders and bids cannot be archived until the tender is closed")
+	ErrInvalidOperation           = errors.New("invalid operator request")
+	ErrInvalidBidPage             = errors.New("invalid bid page request")
+)
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+)
+
+const (
+	maxCriteria         = 20
+	maxCriterionScale   = 1000
+	maxScoreCommentSize = 1000
+)
+
+// EvaluationService ведет взвешенную оценку предложений: ответственные за организацию
+// тендера задают критерии, оценивают опубликованные предложения и получают рейтинг.
+type EvaluationService struct {
+	Repo *repository.PostgresRepository
+}
+
+func NewEvaluationService(repo *repository.PostgresRepository) *EvaluationService {
+	return &EvaluationService{Repo: repo}
+}
+
+type CriterionRequest struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight"`
+	ScaleMin    int     `json:"scaleMin"`
+	ScaleMax    int     `json:"scaleMax"`
+}
+
+type ScoreRequest struct {
+	CriterionID string `json:"criterionId"`
+	Score       int    `json:"score"`
+	Comment     string `json:"comment"`
+}
+
+// BidRanking — рейтинг предложений тендера. Ответственные за организацию видят все
+// предложения с оценками каждого сотрудника, автор предложения — только свои места.
+type BidRanking struct {
+	Criteria     []domain.Criterion `json:"criteria"`
+	Participants int                `json:"participants"`
+	Ranking      []domain.BidRank   `json:"ranking"`
+}
+
+func (s *EvaluationService) Criteria(ctx context.Context, tenderID string) ([]domain.Criterion, error) {
+	if _, err := s.Repo.GetTenderByID(ctx, tenderID); err != nil {
+		return nil, err
+	}
+	return s.Repo.ListCriteria(ctx, tenderID)
+}
+
+// SetCriteria заменяет критерии тендера целиком. После первой оценки критерии не меняются.
+func (s *EvaluationService) SetCriteria(ctx context.Context, tenderID, username string, reqs []CriterionRequest) ([]domain.Criterion, error) {
+	criteria, err := validateCriteria(reqs)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.Repo.GetTenderByID(ctx, tenderID); err != nil {
+		return nil, err
+	}
+	if _, err := s.evaluator(ctx, tenderID, username); err != nil {
+		return nil, err
+	}
+
+	replaced, err := s.Repo.ReplaceCriteria(ctx, tenderID, criteria)
+	if err != nil {
+		return nil, err
+	}
+	if !replaced {
+		return nil, ErrCriteriaLocked
+	}
+	return criteria, nil
+}
+
+func validateCriteria(reqs []CriterionRequest) ([]domain.Criterion, error) {
+	if len(reqs) > maxCriteria {
+		return nil, fmt.Errorf("%w: at most %d criteria are allowed", ErrInvalidCriteria, maxCriteria)
+	}
+	criteria := make([]domain.Criterion, 0, len(reqs))
+	names := map[string]bool{}
+	for _, req := range reqs {
+		name := strings.TrimSpace(req.Name)
+		key := strings.ToLower(name)
+		switch {
+		case name == "" || len([]rune(name)) > 100:
+			return nil, fmt.Errorf("%w: name must be 1 to 100 characters", ErrInvalidCriteria)
+		case names[key]:
+			return nil, fmt.Errorf("%w: duplicate criterion %q", ErrInvalidCriteria, name)
+		case len([]rune(req.Description)) > 500:
+			return nil, fmt.Errorf("%w: description of %q is longer than 500 characters", ErrInvalidCriteria, name)
+		case !(req.Weight > 0) || math.IsInf(req.Weight, 0):
+			return nil, fmt.Errorf("%w: weight of %q must be positive", ErrInvalidCriteria, name)
+		case req.ScaleMax <= req.ScaleMin || req.ScaleMax-req.ScaleMin > maxCriterionScale:
+			return nil, fmt.Errorf("%w: scale of %q must satisfy scaleMin < scaleMax <= scaleMin+%d", ErrInvalidCriteria, name, maxCriterionScale)
+		}
+		names[key] = true
+		criteria = append(criteria, domain.Criterion{CriterionSpec: domain.CriterionSpec{
+			Name:        name,
+			Description: req.Description,
+			Weight:      req.Weight,
+			ScaleMin:    req.ScaleMin,
+			ScaleMax:    req.ScaleMax,
+		}})
+	}
+	return criteria, nil
+}
+
+// ScoreBid сохраняет оценки сотрудника по перечисленным критериям и возвращает
+// все его оценки этого предложения. Оцениваются только опубликованные предложения.
+func (s *EvaluationService) ScoreBid(ctx context.Context, bidID, username string, reqs []ScoreRequest) ([]domain.BidScore, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("%w: at least one score is required", ErrInvalidScore)
+	}
+	bid, err := s.Repo.GetBidByID(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	evaluatorID, err := s.evaluator(ctx, bid.TenderID, username)
+	if err != nil {
+		return nil, err
+	}
+	if bid.Status != domain.BidStatusAccepted {
+		return nil, ErrBidNotPublished
+	}
+	if bid.Sealed() {
+		return nil, ErrBidsSealed
+	}
+
+	criteria, err := s.Repo.ListCriteria(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.Criterion, len(criteria))
+	for i := range criteria {
+		byID[criteria[i].ID] = &criteria[i]
+	}
+	scores := make([]domain.BidScore, 0, len(reqs))
+	seen := map[string]bool{}
+	for _, req := range reqs {
+		c, ok := byID[req.CriterionID]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: criterion %q does not belong to the tender", ErrInvalidScore, req.CriterionID)
+		case seen[req.CriterionID]:
+			return nil, fmt.Errorf("%w: criterion %q is scored twice", ErrInvalidScore, c.Name)
+		case req.Score < c.ScaleMin || req.Score > c.ScaleMax:
+			return nil, fmt.Errorf("%w: score for %q must be between %d and %d", ErrInvalidScore, c.Name, c.ScaleMin, c.ScaleMax)
+		case len([]rune(req.Comment)) > maxScoreCommentSize:
+			return nil, fmt.Errorf("%w: comment is longer than %d characters", ErrInvalidScore, maxScoreCommentSize)
+		}
+		seen[req.CriterionID] = true
+		scores = append(scores, domain.BidScore{
+			BidID:       bidID,
+			CriterionID: req.CriterionID,
+			EvaluatorID: evaluatorID,
+			Evaluator:   username,
+			Score:       req.Score,
+			Comment:     req.Comment,
+		})
+	}
+
+	if err := s.Repo.SaveBidScores(ctx, bid.TenderID, scores); err != nil {
+		return nil, err
+	}
+	all, err := s.Repo.TenderBidScores(ctx, bid.TenderID, bidID)
+	if err != nil {
+		return nil, err
+	}
+	own := []domain.BidScore{}
+	for _, score := range all {
+		if score.EvaluatorID == evaluatorID {
+			own = append(own, score)
+		}
+	}
+	return own, nil
+}
+
+// BidScores возвращает оценки предложения всех сотрудников; доступно только ответственным.
+func (s *EvaluationService) BidScores(ctx context.Context, bidID, username string) ([]domain.BidScore, error) {
+	bid, err := s.Repo.GetBidByID(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.evaluator(ctx, bid.TenderID, username); err != nil {
+		return nil, err
+	}
+	tender, err := s.Repo.GetTenderByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Sealed && tender.Status != domain.TenderStatusClosed {
+		return nil, ErrBidsSealed
+	}
+	return s.Repo.TenderBidScores(ctx, bid.TenderID, bidID)
+}
+
+// Ranking ранжирует опубликованные предложения тендера по взвешенной оценке.
+// До закрытия запечатанного тендера рейтинг недоступен: он раскрыл бы авторов предложений.
+func (s *EvaluationService) Ranking(ctx context.Context, tenderID, username string) (*BidRanking, error) {
+	tender, err := s.Repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+	isOwner, err := s.Repo.IsUserResponsibleForTender(ctx, username, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Sealed && tender.Status != domain.TenderStatusClosed {
+		return nil, ErrBidsSealed
+	}
+
+	criteria, err := s.Repo.ListCriteria(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	bids, err := s.Repo.GetBidsByTenderID(ctx, tenderID, repository.BidFilter{})
+	if err != nil {
+		return nil, err
+	}
+	published := bids[:0]
+	for _, b := range bids {
+		if b.Status == domain.BidStatusAccepted {
+			published = append(published, b)
+		}
+	}
+	scores, err := s.Repo.TenderBidScores(ctx, tenderID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ranking := rankBids(criteria, published, scores)
+	result := &BidRanking{Criteria: criteria, Participants: len(ranking), Ranking: ranking}
+	if isOwner {
+		return result, nil
+	}
+
+	own := []domain.BidRank{}
+	for _, rank := range ranking {
+		if rank.AuthorID == userID {
+			rank.ByEvaluator = nil
+			own = append(own, rank)
+		}
+	}
+	if len(own) == 0 {
+		return nil, ErrRankingForbidden
+	}
+	result.Ranking = own
+	return result, nil
+}
+
+// evaluator проверяет, что username отвечает за организацию тендера, и возвращает его ID.
+func (s *EvaluationService) evaluator(ctx context.Context, tenderID, username string) (string, error) {
+	userID, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrUnknownUser
+		}
+		return "", err
+	}
+	isResponsible, err := s.Repo.IsUserResponsibleForTender(ctx, username, tenderID)
+	if err != nil {
+		return "", err
+	}
+	if !isResponsible {
+		return "", ErrNotResponsible
+	}
+	return userID, nil
+}
+
+// rankBids считает итог каждого предложения: среднюю по сотрудникам долю шкалы каждого
+// критерия, взвешенную по сумме весов, в процентах. bids должны идти от новых к старым.
+func rankBids(criteria []domain.Criterion, bids []*domain.Bid, scores []domain.BidScore) []domain.BidRank {
+	var totalWeight float64
+	for _, c := range criteria {
+		totalWeight += c.Weight
+	}
+	criterionIndex := make(map[string]int, len(criteria))
+	for i, c := range criteria {
+		criterionIndex[c.ID] = i
+	}
+	byBid := map[string][]domain.BidScore{}
+	for _, score := range scores {
+		byBid[score.BidID] = append(byBid[score.BidID], score)
+	}
+
+	ranking := make([]domain.BidRank, 0, len(bids))
+	for _, b := range bids {
+		rank := domain.BidRank{BidID: b.ID, BidName: b.Name, AuthorID: b.AuthorID, Criteria: []domain.CriterionResult{}}
+		sums := make([]float64, len(criteria))
+		counts := make([]int, len(criteria))
+		evaluators := map[string]*domain.EvaluatorResult{}
+		var order []string
+		for _, score := range byBid[b.ID] {
+			i, ok := criterionIndex[score.CriterionID]
+			if !ok {
+				continue
+			}
+			sums[i] += float64(score.Score)
+			counts[i]++
+
+			e, ok := evaluators[score.Evaluator]
+			if !ok {
+				e = &domain.EvaluatorResult{Evaluator: score.Evaluator}
+				evaluators[score.Evaluator] = e
+				order = append(order, score.Evaluator)
+			}
+			e.Scores = append(e.Scores, score)
+			if totalWeight > 0 {
+				e.Total += 100 * criteria[i].Weight * criteria[i].Normalize(score.Score) / totalWeight
+			}
+		}
+
+		for i, c := range criteria {
+			result := domain.CriterionResult{CriterionID: c.ID, Name: c.Name, Weight: c.Weight, Evaluations: counts[i]}
+			if counts[i] > 0 {
+				avg := sums[i] / float64(counts[i])
+				result.AverageScore = &avg
+				// Средняя оценка переводится в долю шкалы так же, как отдельная оценка
+				rank.Total += 100 * c.Weight * (avg - float64(c.ScaleMin)) / float64(c.ScaleMax-c.ScaleMin) / totalWeight
+			}
+			rank.Criteria = append(rank.Criteria, result)
+		}
+		rank.Total = roundScore(rank.Total)
+		rank.Evaluators = len(order)
+		for _, name := range order {
+			e := evaluators[name]
+			e.Total = roundScore(e.Total)
+			rank.ByEvaluator = append(rank.ByEvaluator, *e)
+		}
+		ranking = append(ranking, rank)
+	}
+
+	// bids идут от новых к старым: обратный порядок ставит раньше поданные выше при равенстве
+	for i, j := 0, len(ranking)-1; i < j; i, j = i+1, j-1 {
+		ranking[i], ranking[j] = ranking[j], ranking[i]
+	}
+	sort.SliceStable(ranking, func(i, j int) bool { return ranking[i].Total > ranking[j].Total })
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+	return ranking
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"tender_srevice/internal/domain"
+)
+
+func TestRankBids(t *testing.T) {
+	criteria := []domain.Criterion{
+		{ID: "price", CriterionSpec: domain.CriterionSpec{Name: "Цена", Weight: 3, ScaleMin: 0, ScaleMax: 10}},
+		{ID: "quality", CriterionSpec: domain.CriterionSpec{Name: "Качество", Weight: 1, ScaleMin: 1, ScaleMax: 5}},
+	}
+	now := time.Now()
+	// Как из GetBidsByTenderID: от новых к старым
+	bids := []*domain.Bid{
+		{ID: "c", AuthorID: "u3", CreatedAt: now},
+		{ID: "b", AuthorID: "u2", CreatedAt: now.Add(-time.Hour)},
+		{ID: "a", AuthorID: "u1", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	scores := []domain.BidScore{
+		{BidID: "a", CriterionID: "price", Evaluator: "anna", Score: 10},
+		{BidID: "a", CriterionID: "quality", Evaluator: "anna", Score: 1},
+		{BidID: "a", CriterionID: "price", Evaluator: "boris", Score: 6},
+		{BidID: "b", CriterionID: "price", Evaluator: "anna", Score: 8},
+		{BidID: "b", CriterionID: "quality", Evaluator: "anna", Score: 5},
+		{BidID: "c", CriterionID: "price", Evaluator: "anna", Score: 8},
+		{BidID: "c", CriterionID: "quality", Evaluator: "anna", Score: 5},
+		{BidID: "c", CriterionID: "removed", Evaluator: "anna", Score: 5},
+	}
+
+	ranking := rankBids(criteria, bids, scores)
+
+	// b и c: 100*(3*0.8 + 1*1)/4 = 85, b подан раньше; a: 100*(3*0.8 + 0)/4 = 60
+	want := []struct {
+		id    string
+		total float64
+	}{{"b", 85}, {"c", 85}, {"a", 60}}
+	if len(ranking) != len(want) {
+		t.Fatalf("got %d ranks, want %d", len(ranking), len(want))
+	}
+	for i, w := range want {
+		r := ranking[i]
+		if r.BidID != w.id || r.Total != w.total || r.Rank != i+1 {
+			t.Errorf("rank %d = %s (%v), want %s (%v)", i+1, r.BidID, r.Total, w.id, w.total)
+		}
+	}
+
+	a := ranking[2]
+	if a.Evaluators != 2 || len(a.ByEvaluator) != 2 {
+		t.Fatalf("bid a: evaluators = %d, breakdown = %d, want 2", a.Evaluators, len(a.ByEvaluator))
+	}
+	if a.ByEvaluator[0].Evaluator != "anna" || a.ByEvaluator[0].Total != 75 {
+		t.Errorf("anna's total for a = %v, want 75", a.ByEvaluator[0].Total)
+	}
+	if a.ByEvaluator[1].Evaluator != "boris" || a.ByEvaluator[1].Total != 45 {
+		t.Errorf("boris's total for a = %v, want 45", a.ByEvaluator[1].Total)
+	}
+	if avg := a.Criteria[0].AverageScore; avg == nil || *avg != 8 || a.Criteria[0].Evaluations != 2 {
+		t.Errorf("bid a price result = %+v", a.Criteria[0])
+	}
+}
+
+func TestRankBidsWithoutScores(t *testing.T) {
+	ranking := rankBids(nil, []*domain.Bid{{ID: "a"}}, nil)
+	if len(ranking) != 1 || ranking[0].Total != 0 || ranking[0].Criteria == nil {
+		t.Errorf("ranking = %+v", ranking)
+	}
+}
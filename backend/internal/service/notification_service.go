@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/mailer"
+	"tender_srevice/internal/repository"
+)
+
+const (
+	DefaultNotificationLimit = 20
+	MaxNotificationLimit     = 100
+)
+
+// NotificationService ведет входящие уведомления сотрудников и создает их
+// по событиям BidService и TenderService.
+type NotificationService struct {
+	Repo *repository.PostgresRepository
+	mail *mailer.Templates
+}
+
+// NewNotificationService при непустом mail дублирует уведомления письмами
+// через очередь email_outbox.
+func NewNotificationService(repo *repository.PostgresRepository, mail *mailer.Templates) *NotificationService {
+	return &NotificationService{Repo: repo, mail: mail}
+}
+
+// NotificationInbox — страница уведомлений и счетчики непрочитанных по всем уведомлениям сотрудника.
+type NotificationInbox struct {
+	UnreadCount   int                    `json:"unreadCount"`
+	UnreadByType  map[string]int         `json:"unreadByType"`
+	Notifications []*domain.Notification `json:"notifications"`
+}
+
+func (s *NotificationService) Inbox(ctx context.Context, username string, unreadOnly bool, limit, offset int) (*NotificationInbox, error) {
+	if limit <= 0 || limit > MaxNotificationLimit || offset < 0 {
+		return nil, fmt.Errorf("%w: limit must be 1 to %d, offset must not be negative", ErrInvalidNotificationRequest, MaxNotificationLimit)
+	}
+	employeeID, err := s.employeeID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.Repo.ListNotifications(ctx, employeeID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.Repo.UnreadNotificationCounts(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	inbox := &NotificationInbox{UnreadByType: counts, Notifications: notifications}
+	for _, n := range counts {
+		inbox.UnreadCount += n
+	}
+	return inbox, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, username, notificationID string) error {
+	employeeID, err := s.employeeID(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.Repo.MarkNotificationRead(ctx, employeeID, notificationID)
+}
+
+// MarkAllRead отмечает прочитанными все уведомления сотрудника, при непустом typ — только этого типа.
+func (s *NotificationService) MarkAllRead(ctx context.Context, username, typ string) (int64, error) {
+	if typ != "" && !containsString(domain.NotificationTypes, typ) {
+		return 0, fmt.Errorf("%w: unknown notification type %q", ErrInvalidNotificationRequest, typ)
+	}
+	employeeID, err := s.employeeID(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+	return s.Repo.MarkAllNotificationsRead(ctx, employeeID, typ)
+}
+
+// Preferences возвращает настройку для каждого типа; не заданные явно типы включены.
+func (s *NotificationService) Preferences(ctx context.Context, username string) ([]domain.NotificationPreference, error) {
+	employeeID, err := s.employeeID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return s.preferences(ctx, employeeID)
+}
+
+// SetPreferences меняет настройки перечисленных типов и возвращает настройки целиком.
+func (s *NotificationService) SetPreferences(ctx context.Context, username string, enabled map[string]bool) ([]domain.NotificationPreference, error) {
+	for typ := range enabled {
+		if !containsString(domain.NotificationTypes, typ) {
+			return nil, fmt.Errorf("%w: unknown notification type %q", ErrInvalidNotificationRequest, typ)
+		}
+	}
+	var prefs []domain.NotificationPreference
+	for _, typ := range domain.NotificationTypes {
+		if value, ok := enabled[typ]; ok {
+			prefs = append(prefs, domain.NotificationPreference{Type: typ, Enabled: value})
+		}
+	}
+
+	employeeID, err := s.employeeID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repo.SetNotificationPreferences(ctx, employeeID, prefs); err != nil {
+		return nil, err
+	}
+	return s.preferences(ctx, employeeID)
+}
+
+func (s *NotificationService) preferences(ctx context.Context, employeeID string) ([]domain.NotificationPreference, error) {
+	stored, err := s.Repo.NotificationPreferences(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	prefs := make([]domain.NotificationPreference, 0, len(domain.NotificationTypes))
+	for _, typ := range domain.NotificationTypes {
+		enabled, ok := stored[typ]
+		prefs = append(prefs, domain.NotificationPreference{Type: typ, Enabled: !ok || enabled})
+	}
+	return prefs, nil
+}
+
+func (s *NotificationService) employeeID(ctx context.Context, username string) (string, error) {
+	id, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrUnknownUser
+	}
+	return id, err
+}
+
+// bidCreated уведомляет ответственных за организацию тендера о новом предложении.
+// Название запечатанного предложения не раскрывается.
+func (s *NotificationService) bidCreated(ctx context.Context, tender *domain.Tender, bid *domain.Bid) {
+	data := map[string]string{"tenderName": tender.Name}
+	if !tender.Sealed {
+		data["bidName"] = bid.Name
+	}
+	s.notify(ctx, &domain.Notification{
+		Type:     domain.NotificationBidCreated,
+		TenderID: &tender.ID,
+		BidID:    &bid.ID,
+		Data:     data,
+	}, s.Repo.TenderResponsibleIDs, "")
+}
+
+// bidStatusChanged уведомляет автора предложения о смене статуса.
+func (s *NotificationService) bidStatusChanged(ctx context.Context, bid *domain.Bid, previousStatus, actorUsername string) {
+	data := map[string]string{"status": bid.Status, "previousStatus": previousStatus}
+	if !bid.Sealed() {
+		data["bidName"] = bid.Name
+	}
+	if tender, err := s.Repo.GetTenderByID(ctx, bid.TenderID); err == nil {
+		data["tenderName"] = tender.Name
+	}
+	s.notify(ctx, &domain.Notification{
+		Type:     domain.NotificationBidStatusChanged,
+		TenderID: &bid.TenderID,
+		BidID:    &bid.ID,
+		Data:     data,
+	}, func(context.Context, string) ([]string, error) {
+		return []string{bid.AuthorID}, nil
+	}, actorUsername)
+}
+
+// tenderStatusChanged уведомляет авторов предложений о смене статуса тендера.
+func (s *NotificationService) tenderStatusChanged(ctx context.Context, tender *domain.Tender, previousStatus, actorUsername string) {
+	s.notify(ctx, &domain.Notification{
+		Type:     domain.NotificationTenderStatusChanged,
+		TenderID: &tender.ID,
+		Data:     map[string]string{"tenderName": tender.Name, "status": tender.Status, "previousStatus": previousStatus},
+	}, s.Repo.TenderBidderIDs, actorUsername)
+}
+
+// tenderUpdated уведомляет авторов предложений об изменении условий тендера.
+func (s *NotificationService) tenderUpdated(ctx context.Context, tender *domain.Tender, actorUsername string) {
+	s.notify(ctx, &domain.Notification{
+		Type:     domain.NotificationTenderUpdated,
+		TenderID: &tender.ID,
+		Data:     map[string]string{"tenderName": tender.Name, "version": fmt.Sprint(tender.Version)},
+	}, s.Repo.TenderBidderIDs, actorUsername)
+}
+
+// questionAsked уведомляет ответственных за организацию тендера о новом вопросе.
+func (s *NotificationService) questionAsked(ctx context.Context, tender *domain.Tender, q *domain.TenderQuestion) {
+	s.notify(ctx, &domain.Notification{
+		Type:     domain.NotificationQuestionAsked,
+		TenderID: &tender.ID,
+		Data:     map[string]string{"tenderName": tender.Name, "questionId": q.ID},
+	}, s.Repo.TenderResponsibleIDs, "")
+}
+
+// questionAnswered уведомляет автора вопроса об ответе.
+func (s *NotificationService) questionAnswered(ctx context.Context, tender *domain.Tender, q *domain.TenderQuestion, actorUsername string) {
+	s.notify(ctx, &domain.Notification{
+		Type:     domain.NotificationQuestionAnswered,
+		TenderID: &tender.ID,
+		Data:     map[string]string{"tenderName": tender.Name, "questionId": q.ID},
+	}, func(context.Context, string) ([]string, error) {
+		return []string{q.AuthorID}, nil
+	}, actorUsername)
+}
+
+// notify не прерывает основную операцию: ошибки только пишутся в журнал.
+func (s *NotificationService) notify(ctx context.Context, n *domain.Notification,
+	recipients func(context.Context, string) ([]string, error), actorUsername string) {
+	ids, err := recipients(ctx, *n.TenderID)
+	var created map[string]string
+	if err == nil && len(ids) > 0 {
+		created, err = s.Repo.CreateNotifications(ctx, n, ids, actorUsername)
+	}
+	if err == nil && len(created) > 0 && s.mail != nil {
+		err = s.enqueueEmails(ctx, n, created)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create notifications",
+			slog.String("type", n.Type), slog.String("tender_id", *n.TenderID), slog.Any("error", err))
+	}
+}
+
+// emailData — данные шаблонов писем (internal/mailer/templates).
+type emailData struct {
+	FirstName string
+	TenderID  string
+	BidID     string
+	Data      map[string]string
+}
+
+// enqueueEmails ставит в очередь письма получателям уведомления, указавшим адрес и не отказавшимся от писем.
+func (s *NotificationService) enqueueEmails(ctx context.Context, n *domain.Notification, created map[string]string) error {
+	if !s.mail.Has(n.Type) {
+		return nil
+	}
+	ids := make([]string, 0, len(created))
+	for id := range created {
+		ids = append(ids, id)
+	}
+	recipients, err := s.Repo.EmailRecipients(ctx, ids)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	data := emailData{Data: n.Data}
+	if n.TenderID != nil {
+		data.TenderID = *n.TenderID
+	}
+	if n.BidID != nil {
+		data.BidID = *n.BidID
+	}
+	emails := make([]*domain.OutboxEmail, 0, len(recipients))
+	for _, rcpt := range recipients {
+		data.FirstName = rcpt.FirstName
+		subject, body, err := s.mail.Render(rcpt.Language, n.Type, data)
+		if err != nil {
+			return err
+		}
+		notificationID := created[rcpt.EmployeeID]
+		emails = append(emails, &domain.OutboxEmail{
+			NotificationID: &notificationID,
+			RecipientID:    rcpt.EmployeeID,
+			To:             rcpt.Email,
+			Subject:        subject,
+			HTMLBody:       body,
+		})
+	}
+	return s.Repo.EnqueueEmails(ctx, emails)
+}
+
+// EmailSettingsRequest — частичное изменение почтовых настроек; пустой Email удаляет адрес.
+type EmailSettingsRequest struct {
+	Email    *string `json:"email"`
+	Language *string `json:"language"`
+	Enabled  *bool   `json:"enabled"`
+}
+
+func (s *NotificationService) EmailSettings(ctx context.Context, username string) (*domain.EmailSettings, error) {
+	employeeID, err := s.employeeID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetEmailSettings(ctx, employeeID)
+}
+
+func (s *NotificationService) UpdateEmailSettings(ctx context.Context, username string, req EmailSettingsRequest) (*domain.EmailSettings, error) {
+	employeeID, err := s.employeeID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.Repo.GetEmailSettings(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if email == "" {
+			settings.Email = nil
+		} else {
+			addr, err := mail.ParseAddress(email)
+			if err != nil || addr.Address != email || len(email) > 254 {
+				return nil, fmt.Errorf("%w: %q is not an email address", ErrInvalidNotificationRequest, email)
+			}
+			settings.Email = &email
+		}
+	}
+	if req.Language != nil {
+		if !containsString(mailer.Languages, *req.Language) {
+			return nil, fmt.Errorf("%w: language must be one of %s", ErrInvalidNotificationRequest, strings.Join(mailer.Languages, ", "))
+		}
+		settings.Language = *req.Language
+	}
+	if req.Enabled != nil {
+		settings.Enabled = *req.Enabled
+	}
+
+	if err := s.Repo.UpdateEmailSettings(ctx, employeeID, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
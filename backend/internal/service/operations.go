@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+)
+
+// OperationsService — операции администратора из tenderctl. Проверок прав REST API здесь
+// нет: вызывающий сам отвечает за доступ к базе, а каждое изменение пишется в журнал
+// вместе с оператором.
+type OperationsService struct {
+	Repo    *repository.PostgresRepository
+	sealing *BidSealing
+}
+
+func NewOperationsService(repo *repository.PostgresRepository, sealing *BidSealing) *OperationsService {
+	return &OperationsService{Repo: repo, sealing: sealing}
+}
+
+type CreateEmployeeRequest struct {
+	Username  string
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+func (s *OperationsService) CreateEmployee(ctx context.Context, req CreateEmployeeRequest) (*domain.Employee, error) {
+	e := &domain.Employee{
+		Username:  strings.TrimSpace(req.Username),
+		FirstName: strings.TrimSpace(req.FirstName),
+		LastName:  strings.TrimSpace(req.LastName),
+	}
+	if e.Username == "" || len(e.Username) > 50 {
+		return nil, fmt.Errorf("%w: username must be 1 to 50 characters", ErrInvalidOperation)
+	}
+	if email := strings.TrimSpace(req.Email); email != "" {
+		if !strings.Contains(email, "@") {
+			return nil, fmt.Errorf("%w: invalid email %q", ErrInvalidOperation, email)
+		}
+		e.Email = &email
+	}
+	if err := s.Repo.InsertEmployee(ctx, e); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "employee created", slog.String("employee_id", e.ID), slog.String("username", e.Username))
+	return e, nil
+}
+
+type CreateOrganizationRequest struct {
+	Name        string
+	Description string
+	Type        string
+}
+
+func (s *OperationsService) CreateOrganization(ctx context.Context, req CreateOrganizationRequest) (*domain.Organization, error) {
+	o := &domain.Organization{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Type:        strings.ToUpper(strings.TrimSpace(req.Type)),
+	}
+	if o.Name == "" || len([]rune(o.Name)) > 100 {
+		return nil, fmt.Errorf("%w: name must be 1 to 100 characters", ErrInvalidOperation)
+	}
+	switch o.Type {
+	case "", "IE", "LLC", "JSC":
+	default:
+		return nil, repository.ErrInvalidOrganizationType
+	}
+	if err := s.Repo.InsertOrganization(ctx, o); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "organization created", slog.String("organization_id", o.ID), slog.String("name", o.Name))
+	return o, nil
+}
+
+// AddResponsible возвращает added = false, если сотрудник уже отвечает за организацию.
+func (s *OperationsService) AddResponsible(ctx context.Context, organizationID, username string) (bool, error) {
+	added, err := s.Repo.AddResponsible(ctx, organizationID, username)
+	if err != nil {
+		return false, err
+	}
+	if added {
+		slog.InfoContext(ctx, "responsible added", slog.String("organization_id", organizationID), slog.String("username", username))
+	}
+	return added, nil
+}
+
+func (s *OperationsService) ListTenders(ctx context.Context, filter repository.TenderListFilter) ([]*domain.Tender, error) {
+	if filter.Status != "" && !validTenderStatus(filter.Status) {
+		return nil, fmt.Errorf("%w: unknown tender status %q", ErrInvalidOperation, filter.Status)
+	}
+	return s.Repo.ListTenders(ctx, filter)
+}
+
+// TenderHistory возвращает тендер, включая архивный, и его прошлые версии от старых к новым.
+func (s *OperationsService) TenderHistory(ctx context.Context, tenderID string) (*domain.Tender, []*domain.TenderVersion, error) {
+	tender, err := s.Repo.GetTenderIncludingArchived(ctx, tenderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	versions, err := s.Repo.TenderVersionsByTenderIDs(ctx, []string{tenderID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tender, versions, nil
+}
+
+// ForceTenderStatus переводит тендер в status с причиной reason, которая остается в
+// истории версий. Уведомления не рассылаются. При закрытии запечатанного тендера
+// предложения вскрываются от имени operator, как при закрытии через API.
+func (s *OperationsService) ForceTenderStatus(ctx context.Context, tenderID, status, reason, operator string) (*domain.Tender, error) {
+	if !validTenderStatus(status) {
+		return nil, fmt.Errorf("%w: unknown tender status %q", ErrInvalidOperation, status)
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidOperation)
+	}
+
+	current, err := s.Repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Sealed && current.Status == domain.TenderStatusClosed && status != domain.TenderStatusClosed {
+		return nil, ErrSealedTenderClosed
+	}
+
+	tender, err := s.Repo.ForceTenderStatus(ctx, tenderID, status, reason)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "tender status forced",
+		slog.String("tender_id", tenderID), slog.String("from", current.Status), slog.String("to", status),
+		slog.String("operator", operator), slog.String("reason", reason))
+
+	if tender.Sealed && tender.Status == domain.TenderStatusClosed {
+		if _, err := s.sealing.UnsealTender(ctx, tenderID, operator, UnsealOnCloseReason); err != nil {
+			return tender, fmt.Errorf("tender closed, but bids were not unsealed: %w", err)
+		}
+	}
+	return tender, nil
+}
+
+func (s *OperationsService) RollbackTender(ctx context.Context, tenderID string, version int, operator string) (*domain.Tender, error) {
+	current, err := s.Repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSealedRollback(ctx, s.Repo, current, version); err != nil {
+		return nil, err
+	}
+
+	tender, err := s.Repo.RollbackTender(ctx, tenderID, version)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "tender rolled back",
+		slog.String("tender_id", tenderID), slog.Int("version", version), slog.String("operator", operator))
+	return tender, nil
+}
+
+// RollbackBid не расшифровывает запечатанное содержимое: до закрытия тендера оно
+// остается скрытым и в результате.
+func (s *OperationsService) RollbackBid(ctx context.Context, bidID string, version int, operator string) (*domain.Bid, error) {
+	bid, err := s.Repo.RollbackBid(ctx, bidID, version)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "bid rolled back",
+		slog.String("bid_id", bidID), slog.Int("version", version), slog.String("operator", operator))
+	return bid, nil
+}
+
+func validTenderStatus(status string) bool {
+	switch status {
+	case domain.TenderStatusCreated, domain.TenderStatusPublished, domain.TenderStatusClosed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"tender_srevice/internal/repository"
+)
+
+// Проверки аргументов срабатывают до обращения к базе.
+func TestOperationsValidation(t *testing.T) {
+	s := NewOperationsService(nil, nil)
+	ctx := context.Background()
+
+	if _, err := s.ForceTenderStatus(ctx, "t1", "ARCHIVED", "cleanup", "ops"); !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("unknown status: err = %v, want ErrInvalidOperation", err)
+	}
+	if _, err := s.ForceTenderStatus(ctx, "t1", "CLOSED", "  ", "ops"); !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("blank reason: err = %v, want ErrInvalidOperation", err)
+	}
+	if _, err := s.ListTenders(ctx, repository.TenderListFilter{Status: "closed"}); !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("lowercase status filter: err = %v, want ErrInvalidOperation", err)
+	}
+	if _, err := s.CreateEmployee(ctx, CreateEmployeeRequest{Username: " "}); !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("blank username: err = %v, want ErrInvalidOperation", err)
+	}
+	if _, err := s.CreateEmployee(ctx, CreateEmployeeRequest{Username: "anna", Email: "anna"}); !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("bad email: err = %v, want ErrInvalidOperation", err)
+	}
+	if _, err := s.CreateOrganization(ctx, CreateOrganizationRequest{Name: "ООО Ромашка", Type: "GmbH"}); !errors.Is(err, repository.ErrInvalidOrganizationType) {
+		t.Errorf("unknown organization type: err = %v, want ErrInvalidOrganizationType", err)
+	}
+}
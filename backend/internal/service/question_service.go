@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"time"
+)
+
+const (
+	maxQuestionLength        = 2000
+	maxRejectionReasonLength = 500
+)
+
+// QuestionService ведет вопросы участников по опубликованным тендерам. Вопрос видят
+// автор и ответственные за организацию; после ответа он становится публичным.
+type QuestionService struct {
+	Repo          *repository.PostgresRepository
+	notifications *NotificationService
+}
+
+func NewQuestionService(repo *repository.PostgresRepository, notifications *NotificationService) *QuestionService {
+	return &QuestionService{Repo: repo, notifications: notifications}
+}
+
+// AnswerRequest — ответ на вопрос. QuestionText заменяет публикуемый текст вопроса
+// (например, чтобы убрать из него сведения об авторе). Amendment меняет условия
+// тендера вместе с ответом и создает новую версию тендера.
+type AnswerRequest struct {
+	Answer       string           `json:"answer"`
+	QuestionText *string          `json:"questionText"`
+	Amendment    *TenderAmendment `json:"amendment"`
+}
+
+type TenderAmendment struct {
+	Name               *string    `json:"name"`
+	Description        *string    `json:"description"`
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
+}
+
+func (s *QuestionService) Settings(ctx context.Context, tenderID string) (*domain.QuestionSettings, error) {
+	tender, err := s.Repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	return s.settings(ctx, tender)
+}
+
+func (s *QuestionService) settings(ctx context.Context, tender *domain.Tender) (*domain.QuestionSettings, error) {
+	deadline, err := s.Repo.GetQuestionDeadline(ctx, tender.ID)
+	if err != nil {
+		return nil, err
+	}
+	settings := &domain.QuestionSettings{TenderID: tender.ID, Deadline: deadline, EffectiveDeadline: deadline}
+	if deadline == nil {
+		settings.EffectiveDeadline = tender.SubmissionDeadline
+	}
+	return settings, nil
+}
+
+// SetDeadline задает срок приема вопросов; nil возвращает срок по умолчанию — срок подачи предложений.
+func (s *QuestionService) SetDeadline(ctx context.Context, tenderID, username string, deadline *time.Time) (*domain.QuestionSettings, error) {
+	tender, err := s.Repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.responsible(ctx, tender, username); err != nil {
+		return nil, err
+	}
+	if deadline != nil {
+		if !deadline.After(time.Now()) {
+			return nil, fmt.Errorf("%w: deadline must be in the future", ErrInvalidQuestion)
+		}
+		if tender.SubmissionDeadline != nil && deadline.After(*tender.SubmissionDeadline) {
+			return nil, fmt.Errorf("%w: deadline must not be after the submission deadline", ErrInvalidQuestion)
+		}
+	}
+
+	if err := s.Repo.SetQuestionDeadline(ctx, tenderID, deadline); err != nil {
+		return nil, err
+	}
+	return s.settings(ctx, tender)
+}
+
+// Ask принимает вопрос участника по опубликованному тендеру до срока приема вопросов.
+func (s *QuestionService) Ask(ctx context.Context, tenderID, username, text string) (*domain.TenderQuestion, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || len([]rune(text)) > maxQuestionLength {
+		return nil, fmt.Errorf("%w: text must be 1 to %d characters", ErrInvalidQuestion, maxQuestionLength)
+	}
+	userID, err := s.userID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	tender, err := s.Repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Status != domain.TenderStatusPublished {
+		return nil, ErrQuestionsClosed
+	}
+	inOrganization, err := s.Repo.IsUserInTenderOrganization(ctx, username, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if inOrganization {
+		return nil, ErrQuestionForbidden
+	}
+	settings, err := s.settings(ctx, tender)
+	if err != nil {
+		return nil, err
+	}
+	if settings.EffectiveDeadline != nil && !time.Now().Before(*settings.EffectiveDeadline) {
+		return nil, ErrQuestionDeadlinePassed
+	}
+
+	q := &domain.TenderQuestion{TenderID: tenderID, AuthorID: userID, Text: text}
+	if err := s.Repo.InsertQuestion(ctx, q); err != nil {
+		return nil, err
+	}
+	s.notifications.questionAsked(ctx, tender, q)
+	q.AuthorUsername = ""
+	q.Mine = true
+	return q, nil
+}
+
+// List возвращает отвеченные вопросы и вопросы самого пользователя; ответственным —
+// все вопросы с авторами, с отбором по status. username может быть пустым.
+func (s *QuestionService) List(ctx context.Context, tenderID, username, status string) ([]*domain.TenderQuestion, error) {
+	switch status {
+	case "", domain.QuestionStatusPending, domain.QuestionStatusAnswered, domain.QuestionStatusRejected:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidQuestion, status)
+	}
+	if _, err := s.Repo.GetTenderByID(ctx, tenderID); err != nil {
+		return nil, err
+	}
+
+	var viewerID string
+	var isResponsible bool
+	if username != "" {
+		id, err := s.userID(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		viewerID = id
+		if isResponsible, err = s.Repo.IsUserResponsibleForTender(ctx, username, tenderID); err != nil {
+			return nil, err
+		}
+	}
+
+	questions, err := s.Repo.ListQuestions(ctx, tenderID, viewerID, isResponsible, status)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range questions {
+		q.Mine = viewerID != "" && q.AuthorID == viewerID
+		if !isResponsible {
+			hideQuestionAuthor(q)
+		}
+	}
+	return questions, nil
+}
+
+// Answer публикует ответ ответственного за организацию тендера. Повторный ответ
+// заменяет прежний, отклоненный вопрос при ответе снова становится видимым.
+func (s *QuestionService) Answer(ctx context.Context, questionID, username string, req AnswerRequest) (*domain.TenderQuestion, error) {
+	answer := strings.TrimSpace(req.Answer)
+	if answer == "" {
+		return nil, fmt.Errorf("%w: answer is required", ErrInvalidQuestion)
+	}
+	q, err := s.Repo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	tender, err := s.Repo.GetTenderByID(ctx, q.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.responsible(ctx, tender, username)
+	if err != nil {
+		return nil, err
+	}
+	if req.QuestionText != nil {
+		text := strings.TrimSpace(*req.QuestionText)
+		if text == "" || len([]rune(text)) > maxQuestionLength {
+			return nil, fmt.Errorf("%w: questionText must be 1 to %d characters", ErrInvalidQuestion, maxQuestionLength)
+		}
+		q.Text = text
+	}
+
+	var amended *domain.Tender
+	if a := req.Amendment; a != nil {
+		if a.Name == nil && a.Description == nil && a.SubmissionDeadline == nil {
+			return nil, fmt.Errorf("%w: amendment must change name, description or submissionDeadline", ErrInvalidQuestion)
+		}
+		if tender.Status == domain.TenderStatusClosed {
+			return nil, repository.ErrTenderClosed
+		}
+		changed := *tender
+		if a.Name != nil {
+			changed.Name = *a.Name
+		}
+		if a.Description != nil {
+			changed.Description = *a.Description
+		}
+		if a.SubmissionDeadline != nil {
+			if !a.SubmissionDeadline.After(time.Now()) {
+				return nil, ErrDeadlineInPast
+			}
+			changed.SubmissionDeadline = a.SubmissionDeadline
+		}
+		amended = &changed
+	}
+
+	q.Answer = &answer
+	if err := s.Repo.AnswerQuestion(ctx, q, userID, amended); err != nil {
+		return nil, err
+	}
+	if amended != nil {
+		s.notifications.tenderUpdated(ctx, amended, username)
+	}
+	s.notifications.questionAnswered(ctx, tender, q, username)
+	return q, nil
+}
+
+// Reject скрывает вопрос от других участников (модерация); автору видна причина.
+func (s *QuestionService) Reject(ctx context.Context, questionID, username, reason string) (*domain.TenderQuestion, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > maxRejectionReasonLength {
+		return nil, fmt.Errorf("%w: reason must be 1 to %d characters", ErrInvalidQuestion, maxRejectionReasonLength)
+	}
+	q, err := s.Repo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	tender, err := s.Repo.GetTenderByID(ctx, q.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.responsible(ctx, tender, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.RejectQuestion(ctx, questionID, userID, reason); err != nil {
+		return nil, err
+	}
+	q.Status = domain.QuestionStatusRejected
+	q.RejectionReason = &reason
+	return q, nil
+}
+
+func (s *QuestionService) responsible(ctx context.Context, tender *domain.Tender, username string) (string, error) {
+	userID, err := s.userID(ctx, username)
+	if err != nil {
+		return "", err
+	}
+	isResponsible, err := s.Repo.IsUserResponsibleForOrganization(ctx, username, tender.OrganizationID)
+	if err != nil {
+		return "", err
+	}
+	if !isResponsible {
+		return "", ErrNotResponsible
+	}
+	return userID, nil
+}
+
+func (s *QuestionService) userID(ctx context.Context, username string) (string, error) {
+	id, err := s.Repo.GetUserIDByUsername(ctx, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrUnknownUser
+	}
+	return id, err
+}
+
+// hideQuestionAuthor убирает сведения, которые видят только ответственные.
+func hideQuestionAuthor(q *domain.TenderQuestion) {
+	q.AuthorUsername = ""
+	q.OriginalText = nil
+}
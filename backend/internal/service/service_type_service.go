@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"unicode/utf8"
+)
+
+var (
+	serviceTypeCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	languagePattern        = regexp.MustCompile(`^[a-z]{2,3}$`)
+)
+
+type ServiceTypeService struct {
+	Repo *repository.PostgresRepository
+}
+
+func NewServiceTypeService(repo *repository.PostgresRepository) *ServiceTypeService {
+	return &ServiceTypeService{Repo: repo}
+}
+
+// ServiceTypeRequest — тело создания или замены типа услуги. Active по умолчанию true.
+type ServiceTypeRequest struct {
+	ParentCode *string           `json:"parentCode"`
+	Labels     map[string]string `json:"labels"`
+	Aliases    []string          `json:"aliases"`
+	Active     *bool             `json:"active"`
+}
+
+// List возвращает справочник с подписями на языке lang.
+func (s *ServiceTypeService) List(ctx context.Context, lang string, includeInactive bool) ([]*domain.ServiceType, error) {
+	types, err := s.Repo.ListServiceTypes(ctx, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range types {
+		st.Label = st.LabelFor(lang)
+	}
+	return types, nil
+}
+
+func (s *ServiceTypeService) Get(ctx context.Context, code, lang string) (*domain.ServiceType, error) {
+	st, err := s.Repo.GetServiceType(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	st.Label = st.LabelFor(lang)
+	return st, nil
+}
+
+// Save создает тип с кодом code или полностью заменяет существующий. Возвращает true, если тип создан.
+func (s *ServiceTypeService) Save(ctx context.Context, code string, req ServiceTypeRequest) (*domain.ServiceType, bool, error) {
+	if !serviceTypeCodePattern.MatchString(code) || len(code) > 100 {
+		return nil, false, fmt.Errorf("%w: code must match %s and be at most 100 characters", ErrInvalidServiceType, serviceTypeCodePattern)
+	}
+	if req.ParentCode != nil && *req.ParentCode == "" {
+		req.ParentCode = nil
+	}
+	if req.Labels[domain.DefaultLanguage] == "" {
+		return nil, false, fmt.Errorf("%w: labels.%s is required", ErrInvalidServiceType, domain.DefaultLanguage)
+	}
+	labels := make(map[string]string, len(req.Labels))
+	for lang, label := range req.Labels {
+		label = strings.TrimSpace(label)
+		if !languagePattern.MatchString(lang) {
+			return nil, false, fmt.Errorf("%w: label language %q must be an ISO 639 code", ErrInvalidServiceType, lang)
+		}
+		if label == "" || utf8.RuneCountInString(label) > 255 {
+			return nil, false, fmt.Errorf("%w: label %q must be 1 to 255 characters", ErrInvalidServiceType, lang)
+		}
+		labels[lang] = label
+	}
+
+	// Синонимы хранятся в том виде, в котором с ними сравнивается значение из запроса
+	seen := map[string]bool{}
+	aliases := []string{}
+	for _, alias := range req.Aliases {
+		alias = strings.ToLower(strings.TrimSpace(alias))
+		if alias == "" || utf8.RuneCountInString(alias) > 100 {
+			return nil, false, fmt.Errorf("%w: aliases must be 1 to 100 characters", ErrInvalidServiceType)
+		}
+		if strings.ToUpper(alias) == code || seen[alias] {
+			continue
+		}
+		seen[alias] = true
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	st := &domain.ServiceType{
+		Code:       code,
+		ParentCode: req.ParentCode,
+		Labels:     labels,
+		Aliases:    aliases,
+		Active:     req.Active == nil || *req.Active,
+	}
+	created, err := s.Repo.SaveServiceType(ctx, st)
+	if err != nil {
+		return nil, false, err
+	}
+	st.Label = st.LabelFor(domain.DefaultLanguage)
+	return st, created, nil
+}
+
+func (s *ServiceTypeService) Delete(ctx context.Context, code string) error {
+	return s.Repo.DeleteServiceType(ctx, code)
+}
+
+// resolveServiceType переводит значение из запроса (код в любом регистре или синоним) в код справочника.
+// Неактивный тип принимается только при allowInactive.
+func resolveServiceType(ctx context.Context, repo *repository.PostgresRepository, value string, allowInactive bool) (string, error) {
+	refs, err := repo.ResolveServiceTypes(ctx, []string{value})
+	if err != nil {
+		return "", err
+	}
+	ref, ok := refs[value]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownServiceType, value)
+	}
+	if !ref.Active && !allowInactive {
+		return "", fmt.Errorf("%w: %s is no longer active", ErrUnknownServiceType, ref.Code)
+	}
+	return ref.Code, nil
+}
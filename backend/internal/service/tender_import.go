@@ -0,0 +1,324 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ImportFormatCSV   = "csv"
+	ImportFormatJSONL = "jsonl"
+
+	importBatchSize = 500
+)
+
+// ImportTenderRow — строка файла импорта. Line — номер строки в исходном файле.
+type ImportTenderRow struct {
+	Line            int    `json:"-"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	ServiceType     string `json:"serviceType"`
+	Status          string `json:"status"`
+	OrganizationID  string `json:"organizationId"`
+	CreatorUsername string `json:"creatorUsername"`
+	// SubmissionDeadline — необязательный срок подачи в формате RFC 3339
+	SubmissionDeadline string `json:"submissionDeadline"`
+}
+
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportedTender struct {
+	Line int    `json:"line"`
+	ID   string `json:"id,omitempty"`
+}
+
+// ImportReport — итог импорта. Строки с ошибками пропускаются, остальные
+// вставляются (или, при DryRun, только проверяются).
+type ImportReport struct {
+	DryRun   bool             `json:"dryRun"`
+	Total    int              `json:"total"`
+	Valid    int              `json:"valid"`
+	Imported int              `json:"imported"`
+	Tenders  []ImportedTender `json:"tenders"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+var csvImportColumns = []string{"name", "description", "serviceType", "status", "organizationId", "creatorUsername", "submissionDeadline"}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ParseTenderImport читает CSV (с заголовком) или JSON Lines. Синтаксические ошибки
+// отдельных строк попадают в список ошибок, а не прерывают разбор.
+func ParseTenderImport(r io.Reader, format string) ([]ImportTenderRow, []ImportRowError, error) {
+	switch format {
+	case ImportFormatCSV:
+		return parseTenderCSV(r)
+	case ImportFormatJSONL:
+		return parseTenderJSONL(r)
+	default:
+		return nil, nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+func parseTenderCSV(r io.Reader) ([]ImportTenderRow, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for name := range index {
+		if !containsString(csvImportColumns, name) {
+			return nil, nil, fmt.Errorf("unknown CSV column %q, expected %s", name, strings.Join(csvImportColumns, ", "))
+		}
+	}
+
+	var (
+		rows   []ImportTenderRow
+		errs   []ImportRowError
+		record []string
+	)
+	for {
+		record, err = reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				errs = append(errs, ImportRowError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		get := func(column string) string {
+			i, ok := index[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		rows = append(rows, ImportTenderRow{
+			Line:               line,
+			Name:               get("name"),
+			Description:        get("description"),
+			ServiceType:        get("serviceType"),
+			Status:             get("status"),
+			OrganizationID:     get("organizationId"),
+			CreatorUsername:    get("creatorUsername"),
+			SubmissionDeadline: get("submissionDeadline"),
+		})
+	}
+	return rows, errs, nil
+}
+
+func parseTenderJSONL(r io.Reader) ([]ImportTenderRow, []ImportRowError, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+
+	var (
+		rows []ImportTenderRow
+		errs []ImportRowError
+		line int
+	)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		dec := json.NewDecoder(strings.NewReader(text))
+		dec.DisallowUnknownFields()
+		var row ImportTenderRow
+		if err := dec.Decode(&row); err != nil {
+			errs = append(errs, ImportRowError{Line: line, Message: "invalid JSON: " + err.Error()})
+			continue
+		}
+		row.Line = line
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read JSON Lines: %w", err)
+	}
+	return rows, errs, nil
+}
+
+// ImportTenders проверяет все строки и вставляет корректные одной транзакцией.
+// parseErrors — ошибки разбора из ParseTenderImport, они включаются в отчет.
+func (s *TenderService) ImportTenders(ctx context.Context, rows []ImportTenderRow, parseErrors []ImportRowError, dryRun bool) (*ImportReport, error) {
+	report := &ImportReport{
+		DryRun:  dryRun,
+		Total:   len(rows) + len(parseErrors),
+		Tenders: []ImportedTender{},
+		Errors:  append([]ImportRowError{}, parseErrors...),
+	}
+
+	var orgIDs, usernames, values []string
+	for _, row := range rows {
+		if row.ServiceType != "" {
+			values = append(values, row.ServiceType)
+		}
+		if uuidPattern.MatchString(row.OrganizationID) {
+			orgIDs = append(orgIDs, strings.ToLower(row.OrganizationID))
+		}
+		if row.CreatorUsername != "" {
+			usernames = append(usernames, row.CreatorUsername)
+		}
+	}
+	organizations, err := s.Repo.ExistingOrganizationIDs(ctx, orgIDs)
+	if err != nil {
+		return nil, err
+	}
+	responsibles, err := s.Repo.ResponsibleOrganizations(ctx, usernames)
+	if err != nil {
+		return nil, err
+	}
+	serviceTypes, err := s.Repo.ResolveServiceTypes(ctx, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tenders []*domain.Tender
+		lines   []int
+	)
+	for _, row := range rows {
+		rowErrs := validateImportRow(row, organizations, responsibles, serviceTypes)
+		if len(rowErrs) > 0 {
+			report.Errors = append(report.Errors, rowErrs...)
+			continue
+		}
+		status := row.Status
+		if status == "" {
+			status = domain.TenderStatusCreated
+		}
+		tenders = append(tenders, &domain.Tender{
+			Name:               row.Name,
+			Description:        row.Description,
+			ServiceType:        serviceTypes[row.ServiceType].Code,
+			Status:             status,
+			OrganizationID:     strings.ToLower(row.OrganizationID),
+			CreatorUsername:    row.CreatorUsername,
+			SubmissionDeadline: parseImportDeadline(row.SubmissionDeadline),
+		})
+		lines = append(lines, row.Line)
+	}
+	report.Valid = len(tenders)
+
+	if !dryRun && len(tenders) > 0 {
+		if err := s.Repo.CopyTenders(ctx, tenders, importBatchSize); err != nil {
+			return nil, err
+		}
+		report.Imported = len(tenders)
+	}
+	for i, t := range tenders {
+		report.Tenders = append(report.Tenders, ImportedTender{Line: lines[i], ID: t.ID})
+	}
+	// Ошибки разбора и проверки собираются в разных проходах
+	sort.SliceStable(report.Errors, func(i, j int) bool { return report.Errors[i].Line < report.Errors[j].Line })
+	return report, nil
+}
+
+func validateImportRow(row ImportTenderRow, organizations map[string]bool, responsibles map[string]map[string]bool,
+	serviceTypes map[string]repository.ServiceTypeRef) []ImportRowError {
+	var errs []ImportRowError
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, ImportRowError{Line: row.Line, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if row.Name == "" {
+		fail("name", "is required")
+	} else if utf8.RuneCountInString(row.Name) > 255 {
+		fail("name", "must be at most 255 characters")
+	}
+	if row.ServiceType == "" {
+		fail("serviceType", "is required")
+	} else if ref, ok := serviceTypes[row.ServiceType]; !ok {
+		fail("serviceType", "unknown service type %q", row.ServiceType)
+	} else if !ref.Active {
+		fail("serviceType", "service type %s is no longer active", ref.Code)
+	}
+	switch row.Status {
+	case "", domain.TenderStatusCreated, domain.TenderStatusPublished, domain.TenderStatusClosed:
+	default:
+		fail("status", "must be one of %s, %s, %s", domain.TenderStatusCreated, domain.TenderStatusPublished, domain.TenderStatusClosed)
+	}
+
+	if row.SubmissionDeadline != "" {
+		if deadline := parseImportDeadline(row.SubmissionDeadline); deadline == nil {
+			fail("submissionDeadline", "must be an RFC 3339 timestamp")
+		} else if row.Status != domain.TenderStatusClosed && !deadline.After(time.Now()) {
+			// Закрытые тендеры переносятся с уже прошедшим сроком
+			fail("submissionDeadline", "must be in the future")
+		}
+	}
+
+	orgID := strings.ToLower(row.OrganizationID)
+	orgOK := false
+	switch {
+	case row.OrganizationID == "":
+		fail("organizationId", "is required")
+	case !uuidPattern.MatchString(row.OrganizationID):
+		fail("organizationId", "must be a UUID")
+	case !organizations[orgID]:
+		fail("organizationId", "organization %s does not exist", row.OrganizationID)
+	default:
+		orgOK = true
+	}
+
+	if row.CreatorUsername == "" {
+		fail("creatorUsername", "is required")
+	} else if orgs, ok := responsibles[row.CreatorUsername]; !ok {
+		fail("creatorUsername", "employee %q does not exist", row.CreatorUsername)
+	} else if orgOK && !orgs[orgID] {
+		fail("creatorUsername", "employee %q is not responsible for organization %s", row.CreatorUsername, row.OrganizationID)
+	}
+	return errs
+}
+
+// parseImportDeadline возвращает nil для пустого или некорректного значения.
+func parseImportDeadline(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -5,15 +5,21 @@ import (
 	"tender_srevice/internal/domain"
 	"tender_srevice/internal/repository"
 	"fmt"
+	"log/slog"
+	"time"
 )
 
 type TenderService struct {
-	Repo *repository.PostgresRepository
+	Repo          *repository.PostgresRepository
+	sealing       *BidSealing
+	notifications *NotificationService
 }
 
-func NewTenderService(repo *repository.PostgresRepository) *TenderService {
+func NewTenderService(repo *repository.PostgresRepository, sealing *BidSealing, notifications *NotificationService) *TenderService {
 	return &TenderService{
-		Repo: repo,
+		Repo:          repo,
+		sealing:       sealing,
+		notifications: notifications,
 	}
 }
 
@@ -25,19 +31,43 @@ type CreateTenderRequest struct {
 	Status           string
 	OrganizationID   string
 	CreatorUsername  string
+	SubmissionDeadline *time.Time
+	Sealed           bool
 }
 
 func (s *TenderService) CreateTender(ctx context.Context, req CreateTenderRequest) (*domain.Tender, error) {
+	return s.createTender(ctx, req, nil)
+}
+
+// createTender создает тендер вместе с критериями оценки, если они заданы.
+func (s *TenderService) createTender(ctx context.Context, req CreateTenderRequest, criteria []domain.CriterionSpec) (*domain.Tender, error) {
+	if req.SubmissionDeadline != nil && !req.SubmissionDeadline.After(time.Now()) {
+		return nil, ErrDeadlineInPast
+	}
+	if req.Sealed && !s.sealing.Enabled() {
+		return nil, ErrSealingUnavailable
+	}
+	serviceType, err := resolveServiceType(ctx, s.Repo, req.ServiceType, false)
+	if err != nil {
+		return nil, err
+	}
+
 	newTender := &domain.Tender{
 		Name:             req.Name,
 		Description:      req.Description,
-		ServiceType:      req.ServiceType,
+		ServiceType:      serviceType,
 		Status:           req.Status,
 		OrganizationID:   req.OrganizationID,
 		CreatorUsername:  req.CreatorUsername,
+		SubmissionDeadline: req.SubmissionDeadline,
+		Sealed:           req.Sealed,
 	}
 
-	err := s.Repo.InsertTender(ctx, newTender)
+	if len(criteria) > 0 {
+		err = s.Repo.InsertTenderWithCriteria(ctx, newTender, criteria)
+	} else {
+		err = s.Repo.InsertTender(ctx, newTender)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +75,28 @@ func (s *TenderService) CreateTender(ctx context.Context, req CreateTenderReques
 	return newTender, nil
 }
 
-func (s *TenderService) GetTenders(ctx context.Context) ([]*domain.Tender, error) {
-	return s.Repo.GetAllTenders(ctx)
+// GetTenders при непустом serviceType отбирает тендеры этого типа вместе с подтипами.
+func (s *TenderService) GetTenders(ctx context.Context, serviceType string) ([]*domain.Tender, error) {
+	if serviceType != "" {
+		code, err := resolveServiceType(ctx, s.Repo, serviceType, true)
+		if err != nil {
+			return nil, err
+		}
+		serviceType = code
+	}
+	return s.Repo.GetAllTenders(ctx, serviceType)
+}
+
+// ExportTenders передает в fn те же тендеры, что возвращает GetTenders, по одному.
+func (s *TenderService) ExportTenders(ctx context.Context, serviceType string, fn func(*domain.Tender) error) error {
+	if serviceType != "" {
+		code, err := resolveServiceType(ctx, s.Repo, serviceType, true)
+		if err != nil {
+			return err
+		}
+		serviceType = code
+	}
+	return s.Repo.StreamTenders(ctx, serviceType, fn)
 }
 
 func (s *TenderService) GetTendersByUsername(ctx context.Context, username string) ([]*domain.Tender, error) {
@@ -65,7 +115,7 @@ func (s *TenderService) GetTenderStatus(ctx context.Context, tenderID, currentUs
 
 	isResponsible, err := s.Repo.IsUserResponsibleForOrganization(ctx, currentUsername, tender.OrganizationID)
 	if err != nil {
-		fmt.Println("Ошибка при проверке ответственности:", err)
+		slog.ErrorContext(ctx, "failed to check user responsibility", slog.String("tender_id", tenderID), slog.Any("error", err))
 		return "", err
 	}
 
@@ -82,11 +132,10 @@ type TenderUpdateRequest struct {
 	Name        *string `json:"name"`
 	Description *string `json:"description"`
 	ServiceType *string `json:"serviceType"`
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
 }
 
 func (s *TenderService) UpdateTender(ctx context.Context, req TenderUpdateRequest) (*domain.Tender, error) {
-	
-	fmt.Println(req.Username)
 	if req.TenderID == nil {
 		return nil, fmt.Errorf("tender ID is required")
 	}
@@ -96,34 +145,40 @@ func (s *TenderService) UpdateTender(ctx context.Context, req TenderUpdateReques
 		return nil, err
 	}
 
-	fmt.Println("Creator Username:", tender.CreatorUsername)
-	
 	if req.Username == nil {
 		return nil, fmt.Errorf("username is required service")
 	}
-	fmt.Println("Request Username:", *req.Username)
 
 	if tender.CreatorUsername != *req.Username {
 		return nil, fmt.Errorf("unauthorized")
 	}
 
 	if req.Name != nil {
-		nameValue := *req.Name
-		fmt.Println("Name value:", nameValue)
-		tender.Name = nameValue
+		tender.Name = *req.Name
 	}
 
 	if req.Description != nil {
 		tender.Description = *req.Description
 	}
-	if req.ServiceType != nil {
-		tender.ServiceType = *req.ServiceType
+	if req.ServiceType != nil && *req.ServiceType != tender.ServiceType {
+		code, err := resolveServiceType(ctx, s.Repo, *req.ServiceType, false)
+		if err != nil {
+			return nil, err
+		}
+		tender.ServiceType = code
+	}
+	if req.SubmissionDeadline != nil {
+		if !req.SubmissionDeadline.After(time.Now()) {
+			return nil, ErrDeadlineInPast
+		}
+		tender.SubmissionDeadline = req.SubmissionDeadline
 	}
 
 	err = s.Repo.UpdateTender(ctx, tender)
 	if err != nil {
 		return nil, err
 	}
+	s.notifications.tenderUpdated(ctx, tender, *req.Username)
 
 	return tender, nil
 }
@@ -142,7 +197,7 @@ func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderID, newSta
 
 	isResponsible, err := s.Repo.IsUserResponsibleForOrganization(ctx, currentUsername, tender.OrganizationID)
     if err != nil {
-		fmt.Println("Errordasd:", err)
+		slog.ErrorContext(ctx, "failed to check user responsibility", slog.String("tender_id", tenderID), slog.Any("error", err))
         return nil, err
     }
 
@@ -150,16 +205,48 @@ func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderID, newSta
         return nil, fmt.Errorf("unauthorized")
     }
 
+	// Предложения запечатанного тендера вскрываются при закрытии, скрыть их снова нельзя
+	if tender.Sealed && tender.Status == domain.TenderStatusClosed && newStatus != domain.TenderStatusClosed {
+		return nil, ErrSealedTenderClosed
+	}
+
+	previousStatus := tender.Status
 	tender.Status = newStatus  // Добавьте эту строку
 
 	err = s.Repo.UpdateTenderStatus(ctx, tender)
 	if err != nil {
 		return nil, err
 	}
+	if previousStatus != newStatus {
+		s.notifications.tenderStatusChanged(ctx, tender, previousStatus, currentUsername)
+	}
+
+	if tender.Sealed && tender.Status == domain.TenderStatusClosed {
+		// При ошибке предложения вскроются при первом чтении списка
+		if _, err := s.sealing.UnsealTender(ctx, tenderID, currentUsername, UnsealOnCloseReason); err != nil {
+			slog.ErrorContext(ctx, "failed to unseal bids", slog.String("tender_id", tenderID), slog.Any("error", err))
+		}
+	}
 
 	return tender, nil
 }
 
+// checkSealedRollback не дает откатом вернуть закрытый запечатанный тендер в статус,
+// в котором принимаются предложения: они уже вскрыты.
+func checkSealedRollback(ctx context.Context, repo *repository.PostgresRepository, tender *domain.Tender, version int) error {
+	if !tender.Sealed || tender.Status != domain.TenderStatusClosed {
+		return nil
+	}
+	status, err := repo.GetTenderVersionStatus(ctx, tender.ID, version)
+	if err != nil {
+		return err
+	}
+	if status != domain.TenderStatusClosed {
+		return ErrSealedTenderClosed
+	}
+	return nil
+}
+
 func (s *TenderService) RollbackTender(ctx context.Context, tenderID string, version int, username string) (*domain.Tender, error) {
 	tender, err := s.Repo.GetTenderByID(ctx, tenderID)
 	if err != nil {
@@ -174,11 +261,15 @@ func (s *TenderService) RollbackTender(ctx context.Context, tenderID string, ver
 	if !isResponsible {
 		return nil, fmt.Errorf("unauthorized")
 	}
+	if err := checkSealedRollback(ctx, s.Repo, tender, version); err != nil {
+		return nil, err
+	}
 
 	updatedTender, err := s.Repo.RollbackTender(ctx, tenderID, version)
 	if err != nil {
 		return nil, err
 	}
+	s.notifications.tenderUpdated(ctx, updatedTender, username)
 
 	return updatedTender, nil
 }
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"tender_srevice/internal/domain"
+	"tender_srevice/internal/repository"
+	"time"
+)
+
+// placeholderPattern находит плейсхолдеры шаблона: {{quarter}}, {{ region_name }}.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// TemplateService хранит шаблоны тендеров организации. Шаблонами управляют
+// и пользуются только ответственные за организацию.
+type TemplateService struct {
+	Repo *repository.PostgresRepository
+}
+
+func NewTemplateService(repo *repository.PostgresRepository) *TemplateService {
+	return &TemplateService{Repo: repo}
+}
+
+type TemplateRequest struct {
+	Title       string             `json:"title"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	ServiceType string             `json:"serviceType"`
+	Sealed      bool               `json:"sealed"`
+	Criteria    []CriterionRequest `json:"criteria"`
+}
+
+func (s *TemplateService) CreateTemplate(ctx context.Context, organizationID, username string, req TemplateRequest) (*domain.TenderTemplate, error) {
+	title := strings.TrimSpace(req.Title)
+	name := strings.TrimSpace(req.Name)
+	switch {
+	case title == "" || len([]rune(title)) > 100:
+		return nil, fmt.Errorf("%w: title must be 1 to 100 characters", ErrInvalidTemplate)
+	case name == "" || len([]rune(name)) > 255:
+		return nil, fmt.Errorf("%w: name must be 1 to 255 characters", ErrInvalidTemplate)
+	}
+	criteria, err := validateCriteria(req.Criteria)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOrganizationResponsible(ctx, s.Repo, username, organizationID); err != nil {
+		return nil, err
+	}
+	serviceType, err := resolveServiceType(ctx, s.Repo, req.ServiceType, false)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &domain.TenderTemplate{
+		OrganizationID: organizationID,
+		Title:          title,
+		Name:           name,
+		Description:    req.Description,
+		ServiceType:    serviceType,
+		Sealed:         req.Sealed,
+		Criteria:       criterionSpecs(criteria),
+		CreatedBy:      username,
+	}
+	if err := s.Repo.InsertTemplate(ctx, t); err != nil {
+		return nil, err
+	}
+	t.Placeholders = templatePlaceholders(t.Name, t.Description)
+	return t, nil
+}
+
+func (s *TemplateService) ListTemplates(ctx context.Context, organizationID, username string) ([]*domain.TenderTemplate, error) {
+	if err := checkOrganizationResponsible(ctx, s.Repo, username, organizationID); err != nil {
+		return nil, err
+	}
+	templates, err := s.Repo.ListTemplates(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		t.Placeholders = templatePlaceholders(t.Name, t.Description)
+	}
+	return templates, nil
+}
+
+func (s *TemplateService) GetTemplate(ctx context.Context, templateID, username string) (*domain.TenderTemplate, error) {
+	t, err := s.Repo.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOrganizationResponsible(ctx, s.Repo, username, t.OrganizationID); err != nil {
+		return nil, err
+	}
+	t.Placeholders = templatePlaceholders(t.Name, t.Description)
+	return t, nil
+}
+
+func (s *TemplateService) DeleteTemplate(ctx context.Context, templateID, username string) error {
+	if _, err := s.GetTemplate(ctx, templateID, username); err != nil {
+		return err
+	}
+	return s.Repo.DeleteTemplate(ctx, templateID)
+}
+
+// CloneTenderRequest задает поля, которые у копии отличаются от исходного тендера.
+type CloneTenderRequest struct {
+	Name               *string    `json:"name"`
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
+}
+
+// CloneTender создает тендер в статусе CREATED с названием, описанием, типом услуг,
+// признаком запечатанности и критериями оценки исходного тендера. Срок подачи,
+// аукцион, вложения и вопросы не копируются.
+func (s *TenderService) CloneTender(ctx context.Context, tenderID, username string, req CloneTenderRequest) (*domain.Tender, error) {
+	source, err := s.Repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOrganizationResponsible(ctx, s.Repo, username, source.OrganizationID); err != nil {
+		return nil, err
+	}
+	criteria, err := s.Repo.ListCriteria(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := source.Name
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if name == "" || len([]rune(name)) > 255 {
+			return nil, fmt.Errorf("%w: name must be 1 to 255 characters", ErrInvalidTemplate)
+		}
+	}
+	return s.createTender(ctx, CreateTenderRequest{
+		Name:               name,
+		Description:        source.Description,
+		ServiceType:        source.ServiceType,
+		Status:             domain.TenderStatusCreated,
+		OrganizationID:     source.OrganizationID,
+		CreatorUsername:    username,
+		SubmissionDeadline: req.SubmissionDeadline,
+		Sealed:             source.Sealed,
+	}, criterionSpecs(criteria))
+}
+
+// TemplateTenderRequest — создание тендера по шаблону. Непустые поля CreateTenderRequest
+// заменяют значения шаблона, Placeholders заполняют плейсхолдеры названия и описания.
+type TemplateTenderRequest struct {
+	CreateTenderRequest
+	TemplateID   string
+	Placeholders map[string]string
+}
+
+func (s *TenderService) CreateTenderFromTemplate(ctx context.Context, req TemplateTenderRequest) (*domain.Tender, error) {
+	t, err := s.Repo.GetTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrganizationID != "" && req.OrganizationID != t.OrganizationID {
+		return nil, fmt.Errorf("%w: template belongs to another organization", ErrInvalidTemplate)
+	}
+	if err := checkOrganizationResponsible(ctx, s.Repo, req.CreatorUsername, t.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	tender := req.CreateTenderRequest
+	tender.OrganizationID = t.OrganizationID
+	tender.Sealed = tender.Sealed || t.Sealed
+	if tender.Status == "" {
+		tender.Status = domain.TenderStatusCreated
+	}
+	if tender.ServiceType == "" {
+		tender.ServiceType = t.ServiceType
+	}
+	if tender.Name == "" {
+		if tender.Name, err = fillPlaceholders(t.Name, req.Placeholders); err != nil {
+			return nil, err
+		}
+		if len([]rune(tender.Name)) > 255 {
+			return nil, fmt.Errorf("%w: filled name is longer than 255 characters", ErrInvalidTemplate)
+		}
+	}
+	if tender.Description == "" {
+		if tender.Description, err = fillPlaceholders(t.Description, req.Placeholders); err != nil {
+			return nil, err
+		}
+	}
+	return s.createTender(ctx, tender, t.Criteria)
+}
+
+// templatePlaceholders возвращает имена плейсхолдеров в порядке первого появления.
+func templatePlaceholders(texts ...string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				names = append(names, m[1])
+			}
+		}
+	}
+	return names
+}
+
+// fillPlaceholders подставляет значения плейсхолдеров; без значения для какого-либо
+// из них возвращает ErrInvalidTemplate.
+func fillPlaceholders(text string, values map[string]string) (string, error) {
+	var missing []string
+	filled := placeholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+		name := placeholderPattern.FindStringSubmatch(m)[1]
+		value, ok := values[name]
+		if !ok && !containsString(missing, name) {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%w: missing placeholder values: %s", ErrInvalidTemplate, strings.Join(missing, ", "))
+	}
+	return filled, nil
+}
+
+func criterionSpecs(criteria []domain.Criterion) []domain.CriterionSpec {
+	specs := make([]domain.CriterionSpec, len(criteria))
+	for i, c := range criteria {
+		specs[i] = c.CriterionSpec
+	}
+	return specs
+}
+
+func checkOrganizationResponsible(ctx context.Context, repo *repository.PostgresRepository, username, organizationID string) error {
+	if _, err := repo.GetUserIDByUsername(ctx, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUnknownUser
+		}
+		return err
+	}
+	isResponsible, err := repo.IsUserResponsibleForOrganization(ctx, username, organizationID)
+	if err != nil {
+		return err
+	}
+	if !isResponsible {
+		return ErrNotResponsible
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTemplatePlaceholders(t *testing.T) {
+	got := templatePlaceholders("Поставка за {{quarter}} {{ year }}", "{{year}}: {{region_name}}, {{ 1bad }}")
+	want := []string{"quarter", "year", "region_name"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("placeholders = %v, want %v", got, want)
+	}
+}
+
+func TestFillPlaceholders(t *testing.T) {
+	filled, err := fillPlaceholders("Поставка за {{quarter}} {{ year }}", map[string]string{"quarter": "II квартал", "year": "2024", "extra": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filled != "Поставка за II квартал 2024" {
+		t.Fatalf("filled = %q", filled)
+	}
+
+	_, err = fillPlaceholders("{{a}} {{b}} {{a}}", map[string]string{"b": ""})
+	if !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("err = %v, want ErrInvalidTemplate", err)
+	}
+	if err.Error() != "invalid tender template request: missing placeholder values: a" {
+		t.Fatalf("err = %q", err)
+	}
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("object not found")
+
+// ObjectInfo описывает сохраняемый объект. SHA256 — hex-дайджест содержимого;
+// S3 использует его для подписи запроса и проверки целостности на стороне хранилища.
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+	SHA256      string
+}
+
+// Storage хранит содержимое вложений по ключу вида "tenders/<id>/<attachment id>".
+// Метаданные (имя файла, размер, контрольная сумма) хранятся в базе данных.
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, info ObjectInfo) error
+	// Get возвращает ErrNotFound, если объекта нет.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete не считает ошибкой отсутствие объекта.
+	Delete(ctx context.Context, key string) error
+}